@@ -0,0 +1,129 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database/types"
+)
+
+// createActionItem creates a new action item
+func (s *Server) createActionItem(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+	user := r.Context().Value("User").(uuid.UUID)
+
+	var body dto.ActionItemCreateRequest
+	if err := render.Decode(r, &body); err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	body.Board = board
+	body.User = user
+
+	actionItem, err := s.actionItems.Create(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	if s.basePath == "/" {
+		w.Header().Set("Location", fmt.Sprintf("%s://%s/boards/%s/action-items/%s", common.GetProtocol(r), r.Host, board, actionItem.ID))
+	} else {
+		w.Header().Set("Location", fmt.Sprintf("%s://%s%s/boards/%s/action-items/%s", common.GetProtocol(r), r.Host, s.basePath, board, actionItem.ID))
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, actionItem)
+}
+
+// getActionItems get all action items of a board
+func (s *Server) getActionItems(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+
+	actionItems, err := s.actionItems.List(r.Context(), board)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, actionItems)
+}
+
+// getUserActionItems get all action items of the current user across all boards
+func (s *Server) getUserActionItems(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("User").(uuid.UUID)
+
+	var status *types.ActionItemStatus
+	if statusQuery := r.URL.Query().Get("status"); statusQuery != "" {
+		var parsedStatus types.ActionItemStatus
+		if err := parsedStatus.UnmarshalJSON([]byte(strconv.Quote(statusQuery))); err != nil {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid status")))
+			return
+		}
+		status = &parsedStatus
+	}
+
+	actionItems, err := s.actionItems.ListForUser(r.Context(), user, status)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, actionItems)
+}
+
+// getActionItem get an action item
+func (s *Server) getActionItem(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value("ActionItem").(uuid.UUID)
+
+	actionItem, err := s.actionItems.Get(r.Context(), id)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, actionItem)
+}
+
+// updateActionItem updates an action item
+func (s *Server) updateActionItem(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value("ActionItem").(uuid.UUID)
+
+	var body dto.ActionItemUpdateRequest
+	if err := render.Decode(r, &body); err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	body.ID = id
+	actionItem, err := s.actionItems.Update(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, actionItem)
+}
+
+// deleteActionItem deletes an action item
+func (s *Server) deleteActionItem(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value("ActionItem").(uuid.UUID)
+
+	if err := s.actionItems.Delete(r.Context(), id); err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
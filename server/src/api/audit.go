@@ -0,0 +1,67 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
+)
+
+// getAuditLog get the audit log of a board, optionally filtered by actor, entity type and time range
+func (s *Server) getAuditLog(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+	query := r.URL.Query()
+
+	requestFilter := filter.AuditFilter{Board: board}
+	if actorQuery := query.Get("actor"); actorQuery != "" {
+		actor, err := uuid.Parse(actorQuery)
+		if err != nil {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid actor")))
+			return
+		}
+		requestFilter.Actor = &actor
+	}
+	if entityQuery := query.Get("entity"); entityQuery != "" {
+		var entityType types.AuditEntity
+		if err := entityType.UnmarshalJSON([]byte(strconv.Quote(entityQuery))); err != nil {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid entity type")))
+			return
+		}
+		requestFilter.EntityType = &entityType
+	}
+	if fromQuery := query.Get("from"); fromQuery != "" {
+		from, err := time.Parse(time.RFC3339, fromQuery)
+		if err != nil {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid start of time range")))
+			return
+		}
+		requestFilter.From = &from
+	}
+	if toQuery := query.Get("to"); toQuery != "" {
+		to, err := time.Parse(time.RFC3339, toQuery)
+		if err != nil {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid end of time range")))
+			return
+		}
+		requestFilter.To = &to
+	}
+	if requestFilter.From != nil && requestFilter.To != nil && requestFilter.To.Before(*requestFilter.From) {
+		common.Throw(w, r, common.BadRequestError(errors.New("end of time range is before its start")))
+		return
+	}
+
+	entries, err := s.audit.List(r.Context(), requestFilter)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, entries)
+}
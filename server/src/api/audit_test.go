@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
+	"scrumlr.io/server/services"
+)
+
+type AuditMock struct {
+	services.Audit
+	mock.Mock
+}
+
+func (m *AuditMock) List(ctx context.Context, f filter.AuditFilter) ([]*dto.AuditEntry, error) {
+	args := m.Called(f)
+	return args.Get(0).([]*dto.AuditEntry), args.Error(1)
+}
+
+type AuditTestSuite struct {
+	suite.Suite
+}
+
+func TestAuditTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
+
+func (suite *AuditTestSuite) TestGetAuditLog() {
+	boardId, _ := uuid.NewRandom()
+	actor, _ := uuid.NewRandom()
+	entityType := types.AuditEntityVoting
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		filter       *filter.AuditFilter
+	}{
+		{
+			name:         "without filter",
+			query:        "",
+			expectedCode: http.StatusOK,
+			filter:       &filter.AuditFilter{Board: boardId},
+		},
+		{
+			name:         "with filter",
+			query:        "?actor=" + actor.String() + "&entity=VOTING&from=2024-01-01T00:00:00Z",
+			expectedCode: http.StatusOK,
+			filter:       &filter.AuditFilter{Board: boardId, Actor: &actor, EntityType: &entityType, From: &from},
+		},
+		{
+			name:         "invalid actor",
+			query:        "?actor=jack",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid entity type",
+			query:        "?entity=NOTE",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid time range",
+			query:        "?from=2024-01-02T00:00:00Z&to=2024-01-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			mock := new(AuditMock)
+			s.audit = mock
+
+			if tt.filter != nil {
+				mock.On("List", *tt.filter).Return([]*dto.AuditEntry{}, nil)
+			}
+
+			req := NewTestRequestBuilder("GET", "/"+tt.query, nil).
+				AddToContext("Board", boardId)
+			rr := httptest.NewRecorder()
+
+			s.getAuditLog(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			mock.AssertExpectations(suite.T())
+		})
+	}
+}
@@ -46,6 +46,66 @@ func (s *Server) createBoard(w http.ResponseWriter, r *http.Request) {
 	render.Respond(w, r, b)
 }
 
+// importBoard creates a new board from a board export
+func (s *Server) importBoard(w http.ResponseWriter, r *http.Request) {
+	owner := r.Context().Value("User").(uuid.UUID)
+
+	// parse request
+	var body dto.ImportBoardRequest
+	if err := render.Decode(r, &body); err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	body.Owner = owner
+
+	b, err := s.boards.Import(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	// build the response
+	if s.basePath == "/" {
+		w.Header().Set("Location", fmt.Sprintf("%s://%s/boards/%s", common.GetProtocol(r), r.Host, b.ID))
+	} else {
+		w.Header().Set("Location", fmt.Sprintf("%s://%s%s/boards/%s", common.GetProtocol(r), r.Host, s.basePath, b.ID))
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, b)
+}
+
+// duplicateBoard creates a copy of a board
+func (s *Server) duplicateBoard(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+	owner := r.Context().Value("User").(uuid.UUID)
+
+	// parse request
+	var body dto.DuplicateBoardRequest
+	if err := render.Decode(r, &body); err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	body.ID = board
+	body.Owner = owner
+
+	b, err := s.boards.Duplicate(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	// build the response
+	if s.basePath == "/" {
+		w.Header().Set("Location", fmt.Sprintf("%s://%s/boards/%s", common.GetProtocol(r), r.Host, b.ID))
+	} else {
+		w.Header().Set("Location", fmt.Sprintf("%s://%s%s/boards/%s", common.GetProtocol(r), r.Host, s.basePath, b.ID))
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, b)
+}
+
 // deleteBoard deletes a board
 func (s *Server) deleteBoard(w http.ResponseWriter, r *http.Request) {
 	board := r.Context().Value("Board").(uuid.UUID)
@@ -257,12 +317,34 @@ func (s *Server) deleteTimer(w http.ResponseWriter, r *http.Request) {
 	render.Respond(w, r, board)
 }
 
+func (s *Server) pauseTimer(w http.ResponseWriter, r *http.Request) {
+	boardId := r.Context().Value("Board").(uuid.UUID)
+	board, err := s.boards.PauseTimer(r.Context(), boardId)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, board)
+}
+
+func (s *Server) resumeTimer(w http.ResponseWriter, r *http.Request) {
+	boardId := r.Context().Value("Board").(uuid.UUID)
+	board, err := s.boards.ResumeTimer(r.Context(), boardId)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, board)
+}
+
 func (s *Server) exportBoard(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromRequest(r)
 
 	boardId := r.Context().Value("Board").(uuid.UUID)
 
-	board, _, sessions, columns, notes, _, votings, _, _, err := s.boards.FullBoard(r.Context(), boardId)
+	board, _, sessions, columns, notes, _, votings, _, assignments, _, err := s.boards.FullBoard(r.Context(), boardId)
 	if err != nil {
 		common.Throw(w, r, err)
 		return
@@ -286,13 +368,7 @@ func (s *Server) exportBoard(w http.ResponseWriter, r *http.Request) {
 
 	if r.Header.Get("Accept") == "" || r.Header.Get("Accept") == "*/*" || r.Header.Get("Accept") == "application/json" {
 		render.Status(r, http.StatusOK)
-		render.Respond(w, r, struct {
-			Board        *dto.Board          `json:"board"`
-			Participants []*dto.BoardSession `json:"participants"`
-			Columns      []*dto.Column       `json:"columns"`
-			Notes        []*dto.Note         `json:"notes"`
-			Votings      []*dto.Voting       `json:"votings"`
-		}{
+		render.Respond(w, r, dto.BoardExport{
 			Board:        board,
 			Participants: sessions,
 			Columns:      visibleColumns,
@@ -301,7 +377,7 @@ func (s *Server) exportBoard(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	} else if r.Header.Get("Accept") == "text/csv" {
-		header := []string{"note_id", "author_id", "author", "text", "column_id", "column", "rank", "stack"}
+		header := []string{"note_id", "author_id", "author", "text", "column_id", "column", "rank", "stack", "content_type", "checklist", "links"}
 		for index, voting := range votings {
 			if voting.Status == types.VotingStatusClosed {
 				header = append(header, fmt.Sprintf("voting_%d", index))
@@ -338,6 +414,9 @@ func (s *Server) exportBoard(w http.ResponseWriter, r *http.Request) {
 				column,
 				strconv.Itoa(note.Position.Rank),
 				stack,
+				string(note.ContentType),
+				csvChecklist(note.Checklist),
+				csvLinks(note.Links),
 			}
 
 			for _, voting := range votings {
@@ -362,6 +441,22 @@ func (s *Server) exportBoard(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		return
+	} else if r.Header.Get("Accept") == "text/markdown" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		err := newExportDocument(board, sessions, visibleColumns, visibleNotes, votings, assignments).writeMarkdown(w)
+		if err != nil {
+			log.Errorw("failed to respond with markdown", "err", err)
+		}
+		return
+	} else if r.Header.Get("Accept") == "text/html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		err := newExportDocument(board, sessions, visibleColumns, visibleNotes, votings, assignments).writeHTML(w)
+		if err != nil {
+			log.Errorw("failed to respond with html", "err", err)
+		}
+		return
 	}
 
 	render.Status(r, http.StatusNotAcceptable)
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/logger"
+)
+
+// BoardCommandType is the type of a command sent by a client over the board socket.
+type BoardCommandType string
+
+const (
+	BoardCommandNoteCreate     BoardCommandType = "NOTE_CREATE"
+	BoardCommandNoteMove       BoardCommandType = "NOTE_MOVE"
+	BoardCommandNoteUpdate     BoardCommandType = "NOTE_UPDATE"
+	BoardCommandVoteAdd        BoardCommandType = "VOTE_ADD"
+	BoardCommandVoteRemove     BoardCommandType = "VOTE_REMOVE"
+	BoardCommandReactionAdd    BoardCommandType = "REACTION_ADD"
+	BoardCommandReactionRemove BoardCommandType = "REACTION_REMOVE"
+	BoardCommandSessionUpdate  BoardCommandType = "SESSION_UPDATE"
+)
+
+// BoardCommandResponseType is the type of the response to a command, which is either an acknowledgement or an error.
+type BoardCommandResponseType string
+
+const (
+	BoardCommandResponseAck   BoardCommandResponseType = "ACK"
+	BoardCommandResponseError BoardCommandResponseType = "ERROR"
+)
+
+// BoardCommand is a command sent by a client over the board socket. The request id is chosen by the client and is
+// part of the response, so that the client is able to match the responses to its commands.
+type BoardCommand struct {
+	RequestID string           `json:"requestId"`
+	Type      BoardCommandType `json:"type"`
+	Payload   json.RawMessage  `json:"payload"`
+}
+
+// BoardCommandResponse is the acknowledgement of a command with the resulting entity, if any, or the error that
+// occurred while executing the command.
+type BoardCommandResponse struct {
+	Type      BoardCommandResponseType `json:"type"`
+	RequestID string                   `json:"requestId"`
+	Data      interface{}              `json:"data,omitempty"`
+	Error     *BoardCommandError       `json:"error,omitempty"`
+}
+
+// BoardCommandError is the error of a command, with the status code the equivalent REST request would respond with.
+type BoardCommandError struct {
+	Code   int    `json:"code"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// noteCommandPayload is the payload of the commands to move or update a note.
+type noteCommandPayload struct {
+	Note uuid.UUID `json:"note"`
+	dto.NoteUpdateRequest
+}
+
+// reactionCommandPayload is the payload of the command to remove a reaction.
+type reactionCommandPayload struct {
+	Reaction uuid.UUID `json:"reaction"`
+}
+
+// sessionCommandPayload is the payload of the command to update the own session of the caller.
+type sessionCommandPayload struct {
+	Ready      *bool `json:"ready"`
+	RaisedHand *bool `json:"raisedHand"`
+}
+
+// handleBoardCommand executes a command of the user on the board and returns the response to send to the user. The
+// commands are executed by the same services and with the same permissions as the equivalent REST requests.
+func (s *Server) handleBoardCommand(ctx context.Context, board, user uuid.UUID, message []byte) BoardCommandResponse {
+	var command BoardCommand
+	if err := json.Unmarshal(message, &command); err != nil {
+		return newBoardCommandErrorResponse(command.RequestID, common.BadRequestError(errors.New("invalid command")))
+	}
+
+	ctx = context.WithValue(context.WithValue(ctx, "Board", board), "User", user)
+	data, err := s.executeBoardCommand(ctx, board, user, command)
+	if err != nil {
+		logger.FromContext(ctx).Debugw("unable to execute board command", "board", board, "user", user, "command", command.Type, "err", err)
+		return newBoardCommandErrorResponse(command.RequestID, err)
+	}
+	return BoardCommandResponse{Type: BoardCommandResponseAck, RequestID: command.RequestID, Data: data}
+}
+
+func (s *Server) executeBoardCommand(ctx context.Context, board, user uuid.UUID, command BoardCommand) (interface{}, error) {
+	switch command.Type {
+	case BoardCommandNoteCreate, BoardCommandNoteMove, BoardCommandNoteUpdate, BoardCommandVoteAdd, BoardCommandVoteRemove, BoardCommandReactionAdd, BoardCommandReactionRemove:
+		// archived boards are read-only, just like on the REST resources
+		if err := s.checkBoardWritable(ctx, board); err != nil {
+			return nil, err
+		}
+	case BoardCommandSessionUpdate:
+	default:
+		return nil, common.BadRequestError(fmt.Errorf("unknown command type '%s'", command.Type))
+	}
+
+	switch command.Type {
+	case BoardCommandNoteCreate:
+		var body dto.NoteCreateRequest
+		if err := decodeBoardCommandPayload(command, &body); err != nil {
+			return nil, err
+		}
+		body.Board = board
+		body.User = user
+		return s.notes.Create(ctx, body)
+
+	case BoardCommandNoteMove, BoardCommandNoteUpdate:
+		var payload noteCommandPayload
+		if err := decodeBoardCommandPayload(command, &payload); err != nil {
+			return nil, err
+		}
+		body := payload.NoteUpdateRequest
+		if command.Type == BoardCommandNoteMove {
+			if body.Position == nil {
+				return nil, common.BadRequestError(errors.New("position of note is missing"))
+			}
+			body = dto.NoteUpdateRequest{Position: body.Position}
+		}
+		body.ID = payload.Note
+		body.Board = board
+		return s.notes.Update(ctx, body)
+
+	case BoardCommandVoteAdd:
+		var body dto.VoteRequest
+		if err := decodeBoardCommandPayload(command, &body); err != nil {
+			return nil, err
+		}
+		body.Board = board
+		body.User = user
+		return s.votings.AddVote(ctx, body)
+
+	case BoardCommandVoteRemove:
+		var body dto.VoteRequest
+		if err := decodeBoardCommandPayload(command, &body); err != nil {
+			return nil, err
+		}
+		body.Board = board
+		body.User = user
+		return nil, s.votings.RemoveVote(ctx, body)
+
+	case BoardCommandReactionAdd:
+		var body dto.ReactionCreateRequest
+		if err := decodeBoardCommandPayload(command, &body); err != nil {
+			return nil, err
+		}
+		body.User = user
+		return s.reactions.Create(ctx, board, body)
+
+	case BoardCommandReactionRemove:
+		var payload reactionCommandPayload
+		if err := decodeBoardCommandPayload(command, &payload); err != nil {
+			return nil, err
+		}
+		return nil, s.reactions.Delete(ctx, board, user, payload.Reaction)
+
+	default:
+		var payload sessionCommandPayload
+		if err := decodeBoardCommandPayload(command, &payload); err != nil {
+			return nil, err
+		}
+		return s.sessions.Update(ctx, dto.BoardSessionUpdateRequest{
+			Ready:      payload.Ready,
+			RaisedHand: payload.RaisedHand,
+			Board:      board,
+			User:       user,
+			Caller:     user,
+		})
+	}
+}
+
+func decodeBoardCommandPayload(command BoardCommand, v interface{}) error {
+	if len(command.Payload) == 0 {
+		return common.BadRequestError(errors.New("payload of command is missing"))
+	}
+	if err := json.Unmarshal(command.Payload, v); err != nil {
+		return common.BadRequestError(err)
+	}
+	return nil
+}
+
+func newBoardCommandErrorResponse(requestID string, err error) BoardCommandResponse {
+	apiErr := common.InternalServerError
+	errors.As(err, &apiErr)
+	return BoardCommandResponse{
+		Type:      BoardCommandResponseError,
+		RequestID: requestID,
+		Error: &BoardCommandError{
+			Code:   apiErr.StatusCode,
+			Status: apiErr.StatusText,
+			Error:  apiErr.ErrorText,
+		},
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+)
+
+type BoardCommandsTestSuite struct {
+	suite.Suite
+}
+
+func TestBoardCommandsTestSuite(t *testing.T) {
+	suite.Run(t, new(BoardCommandsTestSuite))
+}
+
+func (suite *BoardCommandsTestSuite) TestCreateNote() {
+	s := new(Server)
+	boards := new(BoardMock)
+	notes := new(NotesMock)
+	s.boards = boards
+	s.notes = notes
+
+	boardID, _ := uuid.NewRandom()
+	userID, _ := uuid.NewRandom()
+	columnID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+
+	boards.On("Get", boardID).Return(&dto.Board{ID: boardID}, nil)
+	notes.On("Create", dto.NoteCreateRequest{Column: columnID, Text: "asdf", Board: boardID, User: userID}).Return(&dto.Note{ID: noteID, Text: "asdf"}, nil)
+
+	message := fmt.Sprintf(`{"requestId": "1", "type": "NOTE_CREATE", "payload": {"column": "%s", "text": "asdf"}}`, columnID)
+	response := s.handleBoardCommand(context.Background(), boardID, userID, []byte(message))
+
+	suite.Equal(BoardCommandResponseAck, response.Type)
+	suite.Equal("1", response.RequestID)
+	suite.Equal(noteID, response.Data.(*dto.Note).ID)
+	suite.Nil(response.Error)
+	boards.AssertExpectations(suite.T())
+	notes.AssertExpectations(suite.T())
+}
+
+func (suite *BoardCommandsTestSuite) TestMoveNoteOnlyChangesPosition() {
+	s := new(Server)
+	boards := new(BoardMock)
+	notes := new(NotesMock)
+	s.boards = boards
+	s.notes = notes
+
+	boardID, _ := uuid.NewRandom()
+	userID, _ := uuid.NewRandom()
+	columnID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+
+	boards.On("Get", boardID).Return(&dto.Board{ID: boardID}, nil)
+	notes.On("Update", dto.NoteUpdateRequest{
+		Position: &dto.NotePosition{Column: columnID, Rank: 2},
+		ID:       noteID,
+		Board:    boardID,
+	}).Return(&dto.Note{ID: noteID}, nil)
+
+	message := fmt.Sprintf(`{"requestId": "move", "type": "NOTE_MOVE", "payload": {"note": "%s", "text": "ignored", "position": {"column": "%s", "rank": 2}}}`, noteID, columnID)
+	response := s.handleBoardCommand(context.Background(), boardID, userID, []byte(message))
+
+	suite.Equal(BoardCommandResponseAck, response.Type)
+	suite.Equal("move", response.RequestID)
+	notes.AssertExpectations(suite.T())
+}
+
+func (suite *BoardCommandsTestSuite) TestCommandOnArchivedBoardShouldFail() {
+	s := new(Server)
+	boards := new(BoardMock)
+	votings := new(VotingMock)
+	s.boards = boards
+	s.votings = votings
+
+	boardID, _ := uuid.NewRandom()
+	userID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+
+	boards.On("Get", boardID).Return(&dto.Board{ID: boardID, Archived: true}, nil)
+
+	message := fmt.Sprintf(`{"requestId": "2", "type": "VOTE_ADD", "payload": {"note": "%s"}}`, noteID)
+	response := s.handleBoardCommand(context.Background(), boardID, userID, []byte(message))
+
+	suite.Equal(BoardCommandResponseError, response.Type)
+	suite.Equal("2", response.RequestID)
+	suite.Equal(http.StatusConflict, response.Error.Code)
+	suite.Equal("board is archived", response.Error.Error)
+	votings.AssertNotCalled(suite.T(), "AddVote")
+}
+
+func (suite *BoardCommandsTestSuite) TestServiceErrorIsReturned() {
+	s := new(Server)
+	boards := new(BoardMock)
+	votings := new(VotingMock)
+	s.boards = boards
+	s.votings = votings
+
+	boardID, _ := uuid.NewRandom()
+	userID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+
+	boards.On("Get", boardID).Return(&dto.Board{ID: boardID}, nil)
+	votings.On("RemoveVote", dto.VoteRequest{Note: noteID, Board: boardID, User: userID}).Return(common.ForbiddenError(errors.New("voting is closed")))
+
+	message := fmt.Sprintf(`{"requestId": "3", "type": "VOTE_REMOVE", "payload": {"note": "%s"}}`, noteID)
+	response := s.handleBoardCommand(context.Background(), boardID, userID, []byte(message))
+
+	suite.Equal(BoardCommandResponseError, response.Type)
+	suite.Equal(http.StatusForbidden, response.Error.Code)
+	votings.AssertExpectations(suite.T())
+}
+
+func (suite *BoardCommandsTestSuite) TestUpdateOwnSession() {
+	s := new(Server)
+	sessions := new(SessionsMock)
+	s.sessions = sessions
+
+	boardID, _ := uuid.NewRandom()
+	userID, _ := uuid.NewRandom()
+	ready := true
+
+	sessions.On("Update", dto.BoardSessionUpdateRequest{Ready: &ready, Board: boardID, User: userID, Caller: userID}).Return(&dto.BoardSession{Ready: true}, nil)
+
+	response := s.handleBoardCommand(context.Background(), boardID, userID, []byte(`{"requestId": "4", "type": "SESSION_UPDATE", "payload": {"ready": true, "role": "OWNER"}}`))
+
+	suite.Equal(BoardCommandResponseAck, response.Type)
+	suite.True(response.Data.(*dto.BoardSession).Ready)
+	sessions.AssertExpectations(suite.T())
+}
+
+func (suite *BoardCommandsTestSuite) TestInvalidCommands() {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{name: "malformed", message: `{"requestId": `},
+		{name: "unknown type", message: `{"requestId": "5", "type": "BOARD_DELETE"}`},
+		{name: "missing payload", message: `{"requestId": "5", "type": "SESSION_UPDATE"}`},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			boardID, _ := uuid.NewRandom()
+			userID, _ := uuid.NewRandom()
+
+			response := s.handleBoardCommand(context.Background(), boardID, userID, []byte(tt.message))
+
+			suite.Equal(BoardCommandResponseError, response.Type)
+			suite.Equal(http.StatusBadRequest, response.Error.Code)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database/types"
+)
+
+// exportNote is a note of a board export with its resolved author, rich content, votes, assignments and stacked notes.
+type exportNote struct {
+	Text        string
+	Links       []types.LinkPreview
+	Checklist   []types.ChecklistItem
+	Author      string
+	Votes       int
+	Assignments []string
+	Stack       []*exportNote
+}
+
+// exportColumn is a column of a board export with its top level notes.
+type exportColumn struct {
+	Name  string
+	Notes []*exportNote
+}
+
+// exportDocument is the view of a board used by the markdown and html exports.
+type exportDocument struct {
+	Name    string
+	Columns []*exportColumn
+}
+
+// newExportDocument groups the notes by column and nests stacked notes under their stack parent. Only the specified
+// columns and notes will be part of the document.
+func newExportDocument(board *dto.Board, sessions []*dto.BoardSession, columns []*dto.Column, notes []*dto.Note, votings []*dto.Voting, assignments []*dto.Assignment) exportDocument {
+	document := exportDocument{Name: "Board"}
+	if board.Name != nil && *board.Name != "" {
+		document.Name = *board.Name
+	}
+
+	authors := map[uuid.UUID]string{}
+	for _, session := range sessions {
+		authors[session.User.ID] = session.User.Name
+	}
+
+	exportNotes := map[uuid.UUID]*exportNote{}
+	for _, note := range notes {
+		author, ok := authors[note.Author]
+		if !ok {
+			author = note.Author.String()
+		}
+		exportNotes[note.ID] = &exportNote{Text: note.Text, Links: note.Links, Checklist: note.Checklist, Author: author}
+	}
+
+	for _, voting := range votings {
+		if voting.Status != types.VotingStatusClosed || voting.VotingResults == nil {
+			continue
+		}
+		for note, result := range voting.VotingResults.Votes {
+			if n, ok := exportNotes[note]; ok {
+				n.Votes += result.Total
+			}
+		}
+	}
+
+	for _, assignment := range assignments {
+		if n, ok := exportNotes[assignment.Note]; ok {
+			n.Assignments = append(n.Assignments, assignment.Name)
+		}
+	}
+
+	for _, column := range columns {
+		c := &exportColumn{Name: column.Name}
+		for _, note := range notes {
+			if note.Position.Column != column.ID {
+				continue
+			}
+			if note.Position.Stack.Valid {
+				if parent, ok := exportNotes[note.Position.Stack.UUID]; ok {
+					parent.Stack = append(parent.Stack, exportNotes[note.ID])
+					continue
+				}
+			}
+			c.Notes = append(c.Notes, exportNotes[note.ID])
+		}
+		document.Columns = append(document.Columns, c)
+	}
+	return document
+}
+
+// writeMarkdown writes the document as markdown with one section per column and nested lists for stacks.
+func (d exportDocument) writeMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", d.Name)
+	for _, column := range d.Columns {
+		fmt.Fprintf(&b, "\n## %s\n\n", column.Name)
+		if len(column.Notes) == 0 {
+			b.WriteString("_No notes_\n")
+		}
+		for _, note := range column.Notes {
+			writeMarkdownNote(&b, note, 0)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownNote(b *strings.Builder, note *exportNote, depth int) {
+	indent := strings.Repeat("  ", depth)
+	text := strings.ReplaceAll(strings.TrimSpace(note.Text), "\n", "\n"+indent+"  ")
+	fmt.Fprintf(b, "%s- %s _(%s)_", indent, text, note.Author)
+	if note.Votes > 0 {
+		fmt.Fprintf(b, " **%d %s**", note.Votes, pluralize(note.Votes, "vote", "votes"))
+	}
+	if len(note.Assignments) > 0 {
+		fmt.Fprintf(b, " → %s", strings.Join(note.Assignments, ", "))
+	}
+	b.WriteString("\n")
+	for _, item := range note.Checklist {
+		check := " "
+		if item.Done {
+			check = "x"
+		}
+		fmt.Fprintf(b, "%s  - [%s] %s\n", indent, check, item.Text)
+	}
+	for _, link := range note.Links {
+		if link.Title != "" {
+			fmt.Fprintf(b, "%s  - [%s](%s)\n", indent, markdownLinkTextEscaper.Replace(link.Title), link.URL)
+		}
+	}
+	for _, stacked := range note.Stack {
+		writeMarkdownNote(b, stacked, depth+1)
+	}
+}
+
+var markdownLinkTextEscaper = strings.NewReplacer("[", "\\[", "]", "\\]")
+
+var htmlExportTemplate = template.Must(template.New("board").Funcs(template.FuncMap{
+	"pluralize": pluralize,
+	"join":      strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{- range .Columns}}
+<h2>{{.Name}}</h2>
+{{- if .Notes}}
+<ul>
+{{- range .Notes}}{{template "note" .}}{{end}}
+</ul>
+{{- else}}
+<p><em>No notes</em></p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+{{define "note"}}
+<li><span style="white-space: pre-wrap">{{.Text}}</span> <em>({{.Author}})</em>
+{{- if .Votes}} <strong>{{.Votes}} {{pluralize .Votes "vote" "votes"}}</strong>{{end}}
+{{- if .Assignments}} &rarr; {{join .Assignments ", "}}{{end}}
+{{- if .Checklist}}
+<ul style="list-style: none">
+{{- range .Checklist}}
+<li><input type="checkbox" disabled{{if .Done}} checked{{end}}> {{.Text}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Links}}
+<ul>
+{{- range .Links}}
+<li><a href="{{.URL}}">{{if .Title}}{{.Title}}{{else}}{{.URL}}{{end}}</a>{{if .Description}} &ndash; {{.Description}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Stack}}
+<ul>
+{{- range .Stack}}{{template "note" .}}{{end}}
+</ul>
+{{- end}}
+</li>
+{{- end}}`))
+
+// writeHTML writes the document as standalone html page with one section per column and nested lists for stacks.
+func (d exportDocument) writeHTML(w io.Writer) error {
+	return htmlExportTemplate.Execute(w, d)
+}
+
+// csvChecklist formats the checklist items of a note as one line per item in the style of a markdown task list.
+func csvChecklist(items []types.ChecklistItem) string {
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		check := " "
+		if item.Done {
+			check = "x"
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s", check, item.Text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// csvLinks formats the links of a note as one line per link.
+func csvLinks(links []types.LinkPreview) string {
+	lines := make([]string, 0, len(links))
+	for _, link := range links {
+		lines = append(lines, link.URL)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
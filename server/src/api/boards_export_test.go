@@ -0,0 +1,113 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database/types"
+)
+
+func exportTestDocument() exportDocument {
+	name := "Retro"
+	author := dto.BoardSession{User: dto.User{ID: uuid.New(), Name: "Jane"}}
+	column := &dto.Column{ID: uuid.New(), Name: "Went well", Visible: true}
+	parent := &dto.Note{ID: uuid.New(), Author: author.User.ID, Text: "Pairing <3", Position: dto.NotePosition{Column: column.ID, Rank: 1}}
+	child := &dto.Note{ID: uuid.New(), Author: author.User.ID, Text: "Mob sessions", Position: dto.NotePosition{Column: column.ID, Stack: uuid.NullUUID{UUID: parent.ID, Valid: true}}}
+	voting := &dto.Voting{
+		Status: types.VotingStatusClosed,
+		VotingResults: &dto.VotingResults{
+			Total: 2,
+			Votes: map[uuid.UUID]dto.VotingResultsPerNote{parent.ID: {Total: 2}},
+		},
+	}
+	openVoting := &dto.Voting{Status: types.VotingStatusOpen}
+	assignment := &dto.Assignment{Note: parent.ID, Name: "John"}
+
+	return newExportDocument(
+		&dto.Board{Name: &name},
+		[]*dto.BoardSession{&author},
+		[]*dto.Column{column},
+		[]*dto.Note{child, parent},
+		[]*dto.Voting{voting, openVoting},
+		[]*dto.Assignment{assignment},
+	)
+}
+
+func TestExportDocumentGroupsNotes(t *testing.T) {
+	document := exportTestDocument()
+
+	assert.Equal(t, "Retro", document.Name)
+	assert.Len(t, document.Columns, 1)
+	assert.Len(t, document.Columns[0].Notes, 1)
+
+	note := document.Columns[0].Notes[0]
+	assert.Equal(t, "Jane", note.Author)
+	assert.Equal(t, 2, note.Votes)
+	assert.Equal(t, []string{"John"}, note.Assignments)
+	assert.Len(t, note.Stack, 1)
+	assert.Equal(t, "Mob sessions", note.Stack[0].Text)
+}
+
+func TestExportDocumentAsMarkdown(t *testing.T) {
+	var b strings.Builder
+	err := exportTestDocument().writeMarkdown(&b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "# Retro\n\n## Went well\n\n- Pairing <3 _(Jane)_ **2 votes** → John\n  - Mob sessions _(Jane)_\n", b.String())
+}
+
+func TestExportDocumentAsHTML(t *testing.T) {
+	var b strings.Builder
+	err := exportTestDocument().writeHTML(&b)
+
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "<h2>Went well</h2>")
+	assert.Contains(t, b.String(), "Pairing &lt;3")
+	assert.Contains(t, b.String(), "<strong>2 votes</strong>")
+	assert.Contains(t, b.String(), "&rarr; John")
+	assert.Contains(t, b.String(), "Mob sessions")
+}
+
+func exportContentTestDocument() exportDocument {
+	author := dto.BoardSession{User: dto.User{ID: uuid.New(), Name: "Jane"}}
+	column := &dto.Column{ID: uuid.New(), Name: "Actions", Visible: true}
+	checklist := &dto.Note{
+		ID:          uuid.New(),
+		Author:      author.User.ID,
+		Text:        "Offsite",
+		ContentType: types.NoteContentTypeChecklist,
+		Checklist:   []types.ChecklistItem{{Text: "Book room", Done: true}, {Text: "Invite team"}},
+		Position:    dto.NotePosition{Column: column.ID, Rank: 1},
+	}
+	link := &dto.Note{
+		ID:          uuid.New(),
+		Author:      author.User.ID,
+		Text:        "Read https://scrumlr.io",
+		ContentType: types.NoteContentTypeMarkdown,
+		Links:       []types.LinkPreview{{URL: "https://scrumlr.io", Title: "[Scrumlr]", Description: "Retrospectives"}},
+		Position:    dto.NotePosition{Column: column.ID},
+	}
+
+	return newExportDocument(&dto.Board{}, []*dto.BoardSession{&author}, []*dto.Column{column}, []*dto.Note{checklist, link}, nil, nil)
+}
+
+func TestExportDocumentWithContentAsMarkdown(t *testing.T) {
+	var b strings.Builder
+	err := exportContentTestDocument().writeMarkdown(&b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "# Board\n\n## Actions\n\n- Offsite _(Jane)_\n  - [x] Book room\n  - [ ] Invite team\n- Read https://scrumlr.io _(Jane)_\n  - [\\[Scrumlr\\]](https://scrumlr.io)\n", b.String())
+}
+
+func TestExportDocumentWithContentAsHTML(t *testing.T) {
+	var b strings.Builder
+	err := exportContentTestDocument().writeHTML(&b)
+
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "<input type=\"checkbox\" disabled checked> Book room")
+	assert.Contains(t, b.String(), "<input type=\"checkbox\" disabled> Invite team")
+	assert.Contains(t, b.String(), "<a href=\"https://scrumlr.io\">[Scrumlr]</a> &ndash; Retrospectives")
+}
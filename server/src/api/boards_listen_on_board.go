@@ -2,28 +2,88 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"scrumlr.io/server/common"
 	dto2 "scrumlr.io/server/common/dto"
 	"scrumlr.io/server/logger"
 	"scrumlr.io/server/realtime"
 )
 
+// boardEventReplayLimit is the number of events per board that are kept to be replayed to reconnecting clients.
+const boardEventReplayLimit = 500
+
 type BoardSubscription struct {
+	board             uuid.UUID
 	subscription      chan *realtime.BoardEvent
-	clients           map[uuid.UUID]*websocket.Conn
+	clients           map[uuid.UUID]*boardClient // by the ids of the connections
+	pending           map[uuid.UUID]*boardClient // clients that wait for the state of the board
 	boardParticipants []*dto2.BoardSession
 	boardSettings     *dto2.Board
 	boardColumns      []*dto2.Column
 	boardNotes        []*dto2.Note
 	boardReactions    []*dto2.Reaction
+
+	// whether the state of the board is cached, which is the case as soon as the first client received the board
+	cached bool
+
+	// the sequence number of the last event and the most recent events, which may be replayed to reconnecting clients.
+	// The first sequence number of the subscription tells apart the sequence numbers of other subscriptions.
+	seq      uint64
+	firstSeq uint64
+	events   []*realtime.BoardEvent
+
+	// the lock guards the clients, the cached board and the events. It is held while events are delivered, so that
+	// clients joining the board don't miss any event.
+	lock sync.Mutex
+
+	// closed as soon as the last client left the board, which stops the listener on the board
+	done chan struct{}
+}
+
+// newBoardSubscription returns a subscription without any clients. The sequence numbers of its events start at a
+// random number, so that they practically never overlap with the sequence numbers of other subscriptions of the same
+// board, be it earlier subscriptions or subscriptions of other instances of the server.
+//
+// Resuming from a sequence number is best-effort: the events are numbered and kept by each instance of the server
+// only as long as any client is connected to the board. Clients reconnecting to another instance or after the last
+// client left the board receive the full board instead.
+func newBoardSubscription(board uuid.UUID) *BoardSubscription {
+	seq := randomSequenceStart()
+	return &BoardSubscription{
+		board:    board,
+		clients:  make(map[uuid.UUID]*boardClient),
+		pending:  make(map[uuid.UUID]*boardClient),
+		seq:      seq,
+		firstSeq: seq,
+		done:     make(chan struct{}),
+	}
+}
+
+// randomSequenceStart returns a random number below 2^52, which leaves room for the events of a subscription while
+// the sequence numbers stay exact within JavaScript numbers.
+func randomSequenceStart() uint64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return uint64(time.Now().UnixMicro())
+	}
+	return binary.BigEndian.Uint64(b[:]) >> 12
 }
 
 type InitEvent struct {
 	Type realtime.BoardEventType `json:"type"`
 	Data EventData               `json:"data"`
+
+	// The sequence number of the last event on the board before the initial state was sent.
+	Seq uint64 `json:"seq"`
 }
 
 type EventData struct {
@@ -36,12 +96,26 @@ type EventData struct {
 	Sessions    []*dto2.BoardSession        `json:"participants"`
 	Requests    []*dto2.BoardSessionRequest `json:"requests"`
 	Assignments []*dto2.Assignment          `json:"assignments"`
+	Comments    []*dto2.Comment             `json:"comments"`
 }
 
+// openBoardSocket opens the websocket of a board. If the client specifies the sequence number of the last event it
+// received with the 'since' parameter, the missed events are replayed. If these events are no longer available, the
+// client receives a fresh INIT event instead.
 func (s *Server) openBoardSocket(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value("Board").(uuid.UUID)
 	userID := r.Context().Value("User").(uuid.UUID)
 
+	var since *uint64
+	if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+		seq, err := strconv.ParseUint(sinceParam, 10, 64)
+		if err != nil {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid sequence number")))
+			return
+		}
+		since = &seq
+	}
+
 	conn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		logger.FromRequest(r).Errorw("unable to upgrade websocket",
@@ -51,11 +125,56 @@ func (s *Server) openBoardSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, requests, sessions, columns, notes, reactions, votings, votes, assignments, err := s.boards.FullBoard(r.Context(), id)
+	client := newBoardClient(userID, conn)
+	defer s.closeBoardSocket(id, client)
+	if since == nil || !s.resumeOnBoard(id, client, *since) {
+		err = s.initOnBoard(r.Context(), id, client)
+		if err != nil {
+			return
+		}
+	}
+
+	// the session stays connected as long as any connection of the user to the board is open
+	err = s.sessions.Connect(r.Context(), id, userID)
+	if err != nil {
+		logger.Get().Warnw("failed to connect session", "board", id, "user", userID, "err", err)
+	}
+	defer s.disconnectBoardSession(id, userID)
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseGoingAway) {
+				logger.Get().Debugw("websocket to user no longer available, about to disconnect", "user", userID)
+			}
+			break
+		}
+		logger.Get().Debugw("received message", "message", message)
+
+		response := s.handleBoardCommand(r.Context(), id, userID, message)
+		if !client.send(response) {
+			logger.Get().Warnw("failed to send command response", "board", id, "user", userID, "request", response.RequestID)
+		}
+	}
+}
+
+// initOnBoard sends the full board to the client and starts to deliver the events of the board to it. The client
+// subscribes to the board before the board is fetched, so that the events published in the meantime are replayed to
+// it after the board.
+func (s *Server) initOnBoard(ctx context.Context, id uuid.UUID, client *boardClient) error {
+	userID := client.user
+
+	var since uint64
+	_ = s.subscribeToBoard(id, func(b *BoardSubscription) error {
+		since = b.seq
+		b.pending[client.id] = client
+		return nil
+	})
+
+	board, requests, sessions, columns, notes, reactions, votings, votes, assignments, comments, err := s.boards.FullBoard(ctx, id)
 	if err != nil {
 		logger.Get().Errorw("failed to prepare init message", "board", id, "user", userID, "err", err)
-		s.closeBoardSocket(id, userID, conn)
-		return
+		return err
 	}
 
 	initEventData := EventData{
@@ -68,6 +187,7 @@ func (s *Server) openBoardSocket(w http.ResponseWriter, r *http.Request) {
 		Sessions:    sessions,
 		Requests:    requests,
 		Assignments: assignments,
+		Comments:    comments,
 	}
 
 	initEvent := InitEvent{
@@ -75,79 +195,387 @@ func (s *Server) openBoardSocket(w http.ResponseWriter, r *http.Request) {
 		Data: initEventData,
 	}
 
+	// the board is cached unfiltered, since the filter of the init event changes the notes
+	boardData := initEventData
+	boardData.Notes = copyNotes(notes)
+
 	initEvent = eventInitFilter(initEvent, userID)
-	err = conn.WriteJSON(initEvent)
+	err = s.listenOnBoard(id, client, since, initEvent, boardData)
 	if err != nil {
 		logger.Get().Errorw("failed to send init message", "board", id, "user", userID, "err", err)
-		s.closeBoardSocket(id, userID, conn)
-		return
 	}
+	return err
+}
 
-	err = s.sessions.Connect(r.Context(), id, userID)
-	if err != nil {
-		logger.Get().Warnw("failed to connect session", "board", id, "user", userID, "err", err)
+// resumeOnBoard replays the events after the specified sequence number to the client and continues to deliver the
+// events of the board to it. It returns false, if the events are not available anymore.
+func (s *Server) resumeOnBoard(id uuid.UUID, client *boardClient, since uint64) bool {
+	s.boardSubscriptionsLock.Lock()
+	defer s.boardSubscriptionsLock.Unlock()
+
+	b, exist := s.boardSubscriptions[id]
+	if !exist {
+		return false
 	}
-	defer s.closeBoardSocket(id, userID, conn)
 
-	s.listenOnBoard(id, userID, conn, initEvent.Data)
+	b.lock.Lock()
+	defer b.lock.Unlock()
 
-	for {
-		_, message, err := conn.ReadMessage()
-		if err != nil {
-			if websocket.IsCloseError(err, websocket.CloseGoingAway) {
-				logger.Get().Debugw("websocket to user no longer available, about to disconnect", "user", userID)
-				delete(s.boardSubscriptions[id].clients, userID)
-				err := s.sessions.Disconnect(r.Context(), id, userID)
-				if err != nil {
-					logger.Get().Warnw("failed to disconnected session", "board", id, "user", userID, "err", err)
-				}
-			}
-			break
+	if !b.cached {
+		return false
+	}
+	events, ok := b.eventsSince(since)
+	if !ok {
+		return false
+	}
+	for _, event := range events {
+		filteredEvent := b.replayFilter(event, client.user)
+		if filteredEvent == nil {
+			continue
+		}
+		if !client.send(filteredEvent) {
+			logger.Get().Warnw("failed to replay message", "board", id, "user", client.user, "seq", event.Seq)
+			client.evict()
+			return true
 		}
-		logger.Get().Debugw("received message", "message", message)
 	}
+	b.clients[client.id] = client
+	return true
 }
 
-func (s *Server) listenOnBoard(boardID, userID uuid.UUID, conn *websocket.Conn, initEventData EventData) {
-	if _, exist := s.boardSubscriptions[boardID]; !exist {
-		s.boardSubscriptions[boardID] = &BoardSubscription{
-			clients: make(map[uuid.UUID]*websocket.Conn),
+// listenOnBoard sends the init event to a pending client of the board, followed by the events after the specified
+// sequence number, and starts to deliver the events of the board to it. The board data is the unfiltered state of the
+// board, which is cached for the first client of the subscription to filter the events for the clients.
+func (s *Server) listenOnBoard(boardID uuid.UUID, client *boardClient, since uint64, initEvent InitEvent, boardData EventData) error {
+	s.boardSubscriptionsLock.Lock()
+	b, exist := s.boardSubscriptions[boardID]
+	s.boardSubscriptionsLock.Unlock()
+	if !exist {
+		return errors.New("board is not subscribed")
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.pending, client.id)
+	events, ok := b.eventsSince(since)
+	if !ok {
+		return errors.New("too many events while the board was fetched")
+	}
+
+	if !b.cached {
+		// the events may be newer than the board data, which was fetched after the client subscribed to the board, so
+		// they are applied to the cache once more
+		b.boardParticipants = boardData.Sessions
+		b.boardSettings = boardData.Board
+		b.boardColumns = boardData.Columns
+		b.boardNotes = boardData.Notes
+		b.boardReactions = boardData.Reactions
+		for _, event := range events {
+			b.cacheEvent(event)
 		}
+		b.cached = true
 	}
 
-	b := s.boardSubscriptions[boardID]
-	b.clients[userID] = conn
-	b.boardParticipants = initEventData.Sessions
-	b.boardSettings = initEventData.Board
-	b.boardColumns = initEventData.Columns
-	b.boardNotes = initEventData.Notes
-	b.boardReactions = initEventData.Reactions
+	initEvent.Seq = since
+	if !client.send(initEvent) {
+		return errors.New("client is closed")
+	}
+	for _, event := range events {
+		filteredEvent := b.replayFilter(event, client.user)
+		if filteredEvent == nil {
+			continue
+		}
+		if !client.send(filteredEvent) {
+			logger.Get().Warnw("failed to replay message", "board", boardID, "user", client.user, "seq", event.Seq)
+			client.evict()
+			return nil
+		}
+	}
+
+	b.clients[client.id] = client
+	return nil
+}
+
+// subscribeToBoard calls join with the subscription of the board, while both the subscriptions of the server and the
+// subscription of the board are locked. The subscription is set up for the first client of the board, whereas the
+// channel of the board is opened without holding any lock, since it waits for the broker.
+func (s *Server) subscribeToBoard(boardID uuid.UUID, join func(b *BoardSubscription) error) error {
+	s.boardSubscriptionsLock.Lock()
+	b, exist := s.boardSubscriptions[boardID]
+	if exist {
+		defer s.boardSubscriptionsLock.Unlock()
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		return join(b)
+	}
+	s.boardSubscriptionsLock.Unlock()
 
-	// if not already done, start listening to board changes
-	if b.subscription == nil {
-		b.subscription = s.realtime.GetBoardChannel(boardID)
+	subscription := s.realtime.GetBoardChannel(boardID)
+
+	s.boardSubscriptionsLock.Lock()
+	b, exist = s.boardSubscriptions[boardID]
+	if !exist {
+		b = newBoardSubscription(boardID)
+		b.subscription = subscription
+		s.boardSubscriptions[boardID] = b
 		go b.startListeningOnBoard()
 	}
+	b.lock.Lock()
+	err := join(b)
+	b.lock.Unlock()
+	s.boardSubscriptionsLock.Unlock()
+
+	if exist {
+		// another client has set up the subscription in the meantime
+		s.realtime.CloseBoardChannel(boardID, subscription)
+	}
+	return err
+}
+
+// leaveBoard stops to deliver the events of the board to the client. The subscription of the board is closed along
+// with the last client of the board.
+func (s *Server) leaveBoard(boardID uuid.UUID, client *boardClient) {
+	s.boardSubscriptionsLock.Lock()
+	b, exist := s.boardSubscriptions[boardID]
+	if !exist {
+		s.boardSubscriptionsLock.Unlock()
+		return
+	}
+
+	b.lock.Lock()
+	delete(b.clients, client.id)
+	delete(b.pending, client.id)
+	empty := len(b.clients) == 0 && len(b.pending) == 0
+	b.lock.Unlock()
+
+	if empty {
+		delete(s.boardSubscriptions, boardID)
+		close(b.done)
+	}
+	s.boardSubscriptionsLock.Unlock()
+
+	if empty && b.subscription != nil {
+		s.realtime.CloseBoardChannel(boardID, b.subscription)
+	}
 }
 
 func (b *BoardSubscription) startListeningOnBoard() {
 	for {
 		select {
-		case msg := <-b.subscription:
+		case msg, ok := <-b.subscription:
+			if !ok {
+				return
+			}
 			logger.Get().Debugw("message received", "message", msg)
-			for id, conn := range b.clients {
-				filteredMsg := b.eventFilter(msg, id)
-				err := conn.WriteJSON(filteredMsg)
-				if err != nil {
-					logger.Get().Warnw("failed to send message", "message", filteredMsg, "err", err)
-				}
+			b.deliver(msg)
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// deliver queues the event for all clients of the board. The event is filtered once per user, even if the user is
+// connected more than once. Clients whose queue is full are evicted from the board.
+func (b *BoardSubscription) deliver(msg *realtime.BoardEvent) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.addEvent(msg)
+	b.cacheEvent(msg)
+	filteredMsgs := make(map[uuid.UUID]*realtime.BoardEvent)
+	for id, client := range b.clients {
+		filteredMsg, filtered := filteredMsgs[client.user]
+		if !filtered {
+			filteredMsg = b.eventFilter(msg, client.user)
+			filteredMsgs[client.user] = filteredMsg
+		}
+		if filteredMsg == nil {
+			continue
+		}
+		if !client.send(filteredMsg) {
+			logger.Get().Warnw("evicting slow client from board", "board", b.board, "user", client.user, "connection", id)
+			client.evict()
+			delete(b.clients, id)
+		}
+	}
+}
+
+// addEvent assigns the next sequence number to the event and keeps it for replays. The event must not be changed
+// afterwards, since it is shared with the write pumps of the clients. The caller must hold the lock.
+func (b *BoardSubscription) addEvent(event *realtime.BoardEvent) {
+	b.seq++
+	event.Seq = b.seq
+
+	b.events = append(b.events, event)
+	if len(b.events) > boardEventReplayLimit {
+		b.events = b.events[len(b.events)-boardEventReplayLimit:]
+	}
+}
+
+// eventsSince returns the events after the specified sequence number. It returns false, if some of these events are
+// not kept anymore or the sequence number is unknown to this subscription. The caller must hold the lock.
+func (b *BoardSubscription) eventsSince(since uint64) ([]*realtime.BoardEvent, bool) {
+	if since < b.firstSeq || since > b.seq {
+		return nil, false
+	}
+	missed := b.seq - since
+	if missed > uint64(len(b.events)) {
+		return nil, false
+	}
+	return b.events[uint64(len(b.events))-missed:], true
+}
+
+// cacheEvent applies the event to the cached board. It is called once for every event before the event is filtered for
+// the clients, since the filter depends on the cached board.
+func (b *BoardSubscription) cacheEvent(event *realtime.BoardEvent) {
+	switch event.Type {
+	case realtime.BoardEventBoardUpdated:
+		board, err := parseBoardUpdated(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse board to cache", "type", event.Type, "err", err)
+			return
+		}
+		b.boardSettings = board
+	case realtime.BoardEventColumnsUpdated:
+		columns, err := parseColumnUpdated(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse columns to cache", "type", event.Type, "err", err)
+			return
+		}
+		b.boardColumns = columns
+	case realtime.BoardEventParticipantCreated, realtime.BoardEventParticipantUpdated:
+		session, err := parseBoardSession(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse participant to cache", "type", event.Type, "err", err)
+			return
+		}
+		b.boardParticipants = upsertSessions(b.boardParticipants, session)
+	case realtime.BoardEventParticipantsUpdated:
+		sessions, err := parseBoardSessions(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse participants to cache", "type", event.Type, "err", err)
+			return
+		}
+		b.boardParticipants = upsertSessions(b.boardParticipants, sessions...)
+	case realtime.BoardEventNotesUpdated, realtime.BoardEventNotesSync:
+		notes, err := parseNotesUpdated(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse notes to cache", "type", event.Type, "err", err)
+			return
+		}
+		b.boardNotes = notes
+	case realtime.BoardEventNoteCreated, realtime.BoardEventNoteTextUpdated:
+		note, err := parseNote(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse note to cache", "type", event.Type, "err", err)
+			return
+		}
+		b.boardNotes = upsertNotes(b.boardNotes, note)
+	case realtime.BoardEventNotesMoved:
+		notes, err := parseNotesUpdated(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse notes to cache", "type", event.Type, "err", err)
+			return
+		}
+		b.boardNotes = upsertNotes(b.boardNotes, notes...)
+	case realtime.BoardEventNoteDeleted:
+		deleted, err := parseNoteDeleted(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse deleted note to cache", "type", event.Type, "err", err)
+			return
+		}
+		b.boardNotes = removeNote(b.boardNotes, deleted.Note, deleted.DeleteStack)
+	}
+}
+
+// upsertSessions replaces the cached sessions of the same users as the specified sessions and adds the remaining
+// sessions.
+func upsertSessions(cached []*dto2.BoardSession, sessions ...*dto2.BoardSession) []*dto2.BoardSession {
+	index := make(map[uuid.UUID]int, len(cached))
+	for i, session := range cached {
+		index[session.User.ID] = i
+	}
+	for _, session := range sessions {
+		if i, ok := index[session.User.ID]; ok {
+			cached[i] = session
+		} else {
+			index[session.User.ID] = len(cached)
+			cached = append(cached, session)
+		}
+	}
+	return cached
+}
+
+// upsertNotes replaces the cached notes with the same id as the specified notes and adds the remaining notes.
+func upsertNotes(cached []*dto2.Note, notes ...*dto2.Note) []*dto2.Note {
+	index := make(map[uuid.UUID]int, len(cached))
+	for i, note := range cached {
+		index[note.ID] = i
+	}
+	for _, note := range notes {
+		if i, ok := index[note.ID]; ok {
+			cached[i] = note
+		} else {
+			index[note.ID] = len(cached)
+			cached = append(cached, note)
+		}
+	}
+	return cached
+}
+
+// removeNote removes the note from the cached notes. The notes of its stack are either removed as well or the topmost
+// of them takes the place of the note, just like in the database.
+func removeNote(cached []*dto2.Note, id uuid.UUID, deleteStack bool) []*dto2.Note {
+	var deleted *dto2.Note
+	remaining := make([]*dto2.Note, 0, len(cached))
+	var children []*dto2.Note
+	for _, note := range cached {
+		if note.ID == id {
+			deleted = note
+			continue
+		}
+		if note.Position.Stack.Valid && note.Position.Stack.UUID == id {
+			if deleteStack {
+				continue
 			}
+			children = append(children, note)
+		}
+		remaining = append(remaining, note)
+	}
+	if deleted == nil || len(children) == 0 {
+		return remaining
+	}
+
+	parent := children[0]
+	for _, child := range children {
+		if child.Position.Rank > parent.Position.Rank {
+			parent = child
 		}
 	}
+	for _, child := range children {
+		child.Position.Stack = uuid.NullUUID{UUID: parent.ID, Valid: true}
+	}
+	parent.Position.Stack = uuid.NullUUID{}
+	parent.Position.Rank = deleted.Position.Rank
+	return remaining
+}
+
+// replayFilter filters a replayed event for the client. Replayed events are delivered unchanged to moderators, just
+// like all other events. It returns nil, if the event is not visible to the client.
+func (b *BoardSubscription) replayFilter(event *realtime.BoardEvent, userID uuid.UUID) *realtime.BoardEvent {
+	if isModerator(userID, b.boardParticipants) {
+		return event
+	}
+	return b.eventFilter(event, userID)
+}
+
+func (s *Server) closeBoardSocket(board uuid.UUID, client *boardClient) {
+	client.close()
+	s.leaveBoard(board, client)
 }
 
-func (s *Server) closeBoardSocket(board, user uuid.UUID, conn *websocket.Conn) {
-	_ = conn.Close()
+func (s *Server) disconnectBoardSession(board, user uuid.UUID) {
 	err := s.sessions.Disconnect(context.Background(), board, user)
 	if err != nil {
 		logger.Get().Warnw("failed to disconnected session", "board", board, "user", user, "err", err)
@@ -0,0 +1,297 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database/types"
+	"scrumlr.io/server/realtime"
+)
+
+func TestAddEventAssignsSequenceNumbers(t *testing.T) {
+	b := BoardSubscription{}
+	first := &realtime.BoardEvent{Type: realtime.BoardEventNotesUpdated}
+	second := &realtime.BoardEvent{Type: realtime.BoardEventColumnsUpdated}
+
+	b.addEvent(first)
+	b.addEvent(second)
+
+	assert.Equal(t, uint64(1), first.Seq)
+	assert.Equal(t, uint64(2), second.Seq)
+	assert.Equal(t, uint64(2), b.seq)
+}
+
+func TestEventsSince(t *testing.T) {
+	b := BoardSubscription{}
+	for i := 0; i < 3; i++ {
+		b.addEvent(&realtime.BoardEvent{Type: realtime.BoardEventNotesUpdated})
+	}
+
+	events, ok := b.eventsSince(1)
+	assert.True(t, ok)
+	assert.Len(t, events, 2)
+	assert.Equal(t, uint64(2), events[0].Seq)
+	assert.Equal(t, uint64(3), events[1].Seq)
+
+	events, ok = b.eventsSince(3)
+	assert.True(t, ok)
+	assert.Empty(t, events)
+
+	events, ok = b.eventsSince(0)
+	assert.True(t, ok)
+	assert.Len(t, events, 3)
+
+	_, ok = b.eventsSince(4)
+	assert.False(t, ok)
+}
+
+func TestEventsSinceWithGapBeyondReplayLimit(t *testing.T) {
+	b := BoardSubscription{}
+	for i := 0; i < boardEventReplayLimit+10; i++ {
+		b.addEvent(&realtime.BoardEvent{Type: realtime.BoardEventNotesUpdated})
+	}
+
+	assert.Len(t, b.events, boardEventReplayLimit)
+
+	_, ok := b.eventsSince(9)
+	assert.False(t, ok)
+
+	events, ok := b.eventsSince(10)
+	assert.True(t, ok)
+	assert.Len(t, events, boardEventReplayLimit)
+	assert.Equal(t, uint64(11), events[0].Seq)
+}
+
+func TestReplayFilterKeepsCachedNotesOfModerators(t *testing.T) {
+	moderator := uuid.New()
+	participant := uuid.New()
+	column := &dto.Column{ID: uuid.New(), Visible: true}
+	cachedNotes := []*dto.Note{{ID: uuid.New(), Author: moderator, Text: "current", Position: dto.NotePosition{Column: column.ID}}}
+	b := BoardSubscription{
+		boardParticipants: []*dto.BoardSession{
+			{User: dto.User{ID: moderator}, Role: types.SessionRoleModerator},
+			{User: dto.User{ID: participant}, Role: types.SessionRoleParticipant},
+		},
+		boardSettings: &dto.Board{ShowNotesOfOtherUsers: true},
+		boardColumns:  []*dto.Column{column},
+		boardNotes:    cachedNotes,
+	}
+	event := &realtime.BoardEvent{
+		Type: realtime.BoardEventNotesUpdated,
+		Data: []*dto.Note{{ID: cachedNotes[0].ID, Author: moderator, Text: "past", Position: dto.NotePosition{Column: column.ID}}},
+		Seq:  7,
+	}
+
+	assert.Equal(t, event, b.replayFilter(event, moderator))
+	assert.Equal(t, cachedNotes, b.boardNotes)
+
+	filtered := b.replayFilter(event, participant)
+	assert.Equal(t, uint64(7), filtered.Seq)
+	assert.Len(t, filtered.Data, 1)
+}
+
+func TestCacheEventAppliesNoteDeltas(t *testing.T) {
+	column := uuid.New()
+	first := &dto.Note{ID: uuid.New(), Text: "first", Position: dto.NotePosition{Column: column, Rank: 0}}
+	second := &dto.Note{ID: uuid.New(), Text: "second", Position: dto.NotePosition{Column: column, Rank: 1}}
+	b := BoardSubscription{boardNotes: []*dto.Note{first, second}}
+
+	created := dto.Note{ID: uuid.New(), Text: "created", Position: dto.NotePosition{Column: column, Rank: 2}}
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventNoteCreated, Data: created})
+	assert.Len(t, b.boardNotes, 3)
+
+	updated := *first
+	updated.Text = "updated"
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventNoteTextUpdated, Data: updated})
+	assert.Equal(t, "updated", b.boardNotes[0].Text)
+
+	movedFirst := updated
+	movedFirst.Position.Rank = 1
+	movedSecond := *second
+	movedSecond.Position.Rank = 0
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventNotesMoved, Data: []dto.Note{movedFirst, movedSecond}})
+	assert.Equal(t, []*dto.Note{&movedFirst, &movedSecond, &created}, b.boardNotes)
+}
+
+func TestCacheEventRemovesDeletedNotes(t *testing.T) {
+	column := uuid.New()
+	parent := &dto.Note{ID: uuid.New(), Position: dto.NotePosition{Column: column, Rank: 3}}
+	lower := &dto.Note{ID: uuid.New(), Position: dto.NotePosition{Column: column, Stack: uuid.NullUUID{UUID: parent.ID, Valid: true}, Rank: 0}}
+	upper := &dto.Note{ID: uuid.New(), Position: dto.NotePosition{Column: column, Stack: uuid.NullUUID{UUID: parent.ID, Valid: true}, Rank: 1}}
+
+	b := BoardSubscription{boardNotes: copyNotes([]*dto.Note{parent, lower, upper})}
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventNoteDeleted, Data: map[string]interface{}{"note": parent.ID, "deleteStack": true}})
+	assert.Empty(t, b.boardNotes)
+
+	b = BoardSubscription{boardNotes: copyNotes([]*dto.Note{parent, lower, upper})}
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventNoteDeleted, Data: map[string]interface{}{"note": parent.ID, "deleteStack": false}})
+	assert.Len(t, b.boardNotes, 2)
+	assert.Equal(t, dto.NotePosition{Column: column, Stack: uuid.NullUUID{UUID: upper.ID, Valid: true}, Rank: 0}, b.boardNotes[0].Position)
+	assert.Equal(t, dto.NotePosition{Column: column, Rank: 3}, b.boardNotes[1].Position)
+}
+
+func TestCacheEventUpdatesBoardState(t *testing.T) {
+	user := uuid.New()
+	b := BoardSubscription{boardParticipants: []*dto.BoardSession{{User: dto.User{ID: user}, Role: types.SessionRoleParticipant}}}
+
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventBoardUpdated, Data: dto.Board{AccessPolicy: types.AccessPolicyPublic, ShowAuthors: true}})
+	assert.True(t, b.boardSettings.ShowAuthors)
+
+	column := dto.Column{ID: uuid.New(), Color: types.ColorBacklogBlue, Visible: true}
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventColumnsUpdated, Data: []dto.Column{column}})
+	assert.Equal(t, []*dto.Column{&column}, b.boardColumns)
+
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventParticipantUpdated, Data: dto.BoardSession{User: dto.User{ID: user}, Role: types.SessionRoleModerator}})
+	assert.True(t, isModerator(user, b.boardParticipants))
+	assert.Len(t, b.boardParticipants, 1)
+
+	b.cacheEvent(&realtime.BoardEvent{Type: realtime.BoardEventParticipantCreated, Data: dto.BoardSession{User: dto.User{ID: uuid.New()}, Role: types.SessionRoleParticipant}})
+	assert.Len(t, b.boardParticipants, 2)
+}
+
+// joinTestBoard subscribes the client to the board, as if the board was about to be fetched for it, and returns the
+// sequence number of the subscription at that time.
+func joinTestBoard(s *Server, board uuid.UUID, client *boardClient) uint64 {
+	var since uint64
+	_ = s.subscribeToBoard(board, func(b *BoardSubscription) error {
+		since = b.seq
+		b.pending[client.id] = client
+		return nil
+	})
+	return since
+}
+
+func TestListenOnBoardReplaysEventsPublishedWhileFetchingTheBoard(t *testing.T) {
+	moderator := uuid.New()
+	column := &dto.Column{ID: uuid.New(), Color: types.ColorBacklogBlue, Visible: true}
+	sessions := []*dto.BoardSession{{User: dto.User{ID: moderator}, Role: types.SessionRoleModerator}}
+	note := &dto.Note{ID: uuid.New(), Author: moderator, Text: "before", Position: dto.NotePosition{Column: column.ID}}
+
+	board := uuid.New()
+	b := newBoardSubscription(board)
+	s := Server{boardSubscriptions: map[uuid.UUID]*BoardSubscription{board: b}}
+
+	conn, remote := newTestWebsocket(t)
+	client := newBoardClient(moderator, conn)
+	defer client.close()
+	since := joinTestBoard(&s, board, client)
+
+	// the board is fetched before the event is delivered, but the client subscribed to the board before
+	boardData := EventData{Board: &dto.Board{ID: board, AccessPolicy: types.AccessPolicyPublic}, Columns: []*dto.Column{column}, Notes: []*dto.Note{note}, Sessions: sessions}
+	updated := *note
+	updated.Text = "after"
+	b.deliver(&realtime.BoardEvent{Type: realtime.BoardEventNoteTextUpdated, Data: updated})
+
+	err := s.listenOnBoard(board, client, since, InitEvent{Type: realtime.BoardEventInit, Data: boardData}, boardData)
+	assert.Nil(t, err)
+	assert.Contains(t, b.clients, client.id)
+	assert.Empty(t, b.pending)
+	assert.Equal(t, "after", b.boardNotes[0].Text)
+
+	var initEvent InitEvent
+	_ = remote.SetReadDeadline(time.Now().Add(5 * time.Second))
+	assert.Nil(t, remote.ReadJSON(&initEvent))
+	assert.Equal(t, realtime.BoardEventInit, initEvent.Type)
+	assert.Equal(t, since, initEvent.Seq)
+
+	var event realtime.BoardEvent
+	assert.Nil(t, remote.ReadJSON(&event))
+	assert.Equal(t, realtime.BoardEventNoteTextUpdated, event.Type)
+	assert.Equal(t, since+1, event.Seq)
+}
+
+func TestListenOnBoardKeepsCacheOfSubscription(t *testing.T) {
+	moderator := uuid.New()
+	column := &dto.Column{ID: uuid.New(), Color: types.ColorBacklogBlue, Visible: true}
+	sessions := []*dto.BoardSession{{User: dto.User{ID: moderator}, Role: types.SessionRoleModerator}}
+
+	board := uuid.New()
+	b := newBoardSubscription(board)
+	b.cached = true
+	b.boardSettings = &dto.Board{ID: board}
+	b.boardColumns = []*dto.Column{column}
+	b.boardParticipants = sessions
+	b.boardNotes = []*dto.Note{{ID: uuid.New(), Text: "current", Position: dto.NotePosition{Column: column.ID}}}
+	s := Server{boardSubscriptions: map[uuid.UUID]*BoardSubscription{board: b}}
+
+	conn, _ := newTestWebsocket(t)
+	client := newBoardClient(moderator, conn)
+	defer client.close()
+	since := joinTestBoard(&s, board, client)
+
+	// the board data of a joining client may be older than the cache, so it must not replace the cache
+	stale := EventData{Board: &dto.Board{ID: board}, Columns: []*dto.Column{column}, Notes: []*dto.Note{}, Sessions: sessions}
+	err := s.listenOnBoard(board, client, since, InitEvent{Type: realtime.BoardEventInit, Data: stale}, stale)
+	assert.Nil(t, err)
+	assert.Len(t, b.boardNotes, 1)
+	assert.Equal(t, "current", b.boardNotes[0].Text)
+}
+
+func TestLeaveBoardKeepsSubscriptionOfPendingClients(t *testing.T) {
+	board := uuid.New()
+	b := newBoardSubscription(board)
+	s := Server{boardSubscriptions: map[uuid.UUID]*BoardSubscription{board: b}}
+	pending := &boardClient{id: uuid.New(), user: uuid.New()}
+	joinTestBoard(&s, board, pending)
+
+	s.leaveBoard(board, &boardClient{id: uuid.New(), user: uuid.New()})
+	assert.Contains(t, s.boardSubscriptions, board)
+
+	s.leaveBoard(board, pending)
+	assert.NotContains(t, s.boardSubscriptions, board)
+}
+
+func TestEventsSinceRejectsSequenceNumbersOfOtherSubscriptions(t *testing.T) {
+	board := uuid.New()
+	earlier := newBoardSubscription(board)
+	later := newBoardSubscription(board)
+	for i := 0; i < 3; i++ {
+		earlier.addEvent(&realtime.BoardEvent{Type: realtime.BoardEventNotesUpdated})
+		later.addEvent(&realtime.BoardEvent{Type: realtime.BoardEventNotesUpdated})
+	}
+
+	_, ok := later.eventsSince(earlier.seq)
+	assert.False(t, ok)
+	_, ok = later.eventsSince(later.firstSeq - 1)
+	assert.False(t, ok)
+
+	events, ok := later.eventsSince(later.firstSeq)
+	assert.True(t, ok)
+	assert.Len(t, events, 3)
+}
+
+func TestResumeAfterLastClientLeftFallsBackToInit(t *testing.T) {
+	moderator := uuid.New()
+	sessions := []*dto.BoardSession{{User: dto.User{ID: moderator}, Role: types.SessionRoleModerator}}
+	board := uuid.New()
+	first := newBoardSubscription(board)
+	first.cached = true
+	first.boardParticipants = sessions
+	s := Server{boardSubscriptions: map[uuid.UUID]*BoardSubscription{board: first}}
+
+	client := &boardClient{id: uuid.New(), user: moderator}
+	first.clients[client.id] = client
+	first.addEvent(&realtime.BoardEvent{Type: realtime.BoardEventBoardTimerExpired})
+	since := first.seq
+
+	// the events of the board are dropped along with the subscription, once the last client left the board
+	s.leaveBoard(board, client)
+	assert.NotContains(t, s.boardSubscriptions, board)
+	assert.False(t, s.resumeOnBoard(board, client, since))
+
+	// another client subscribes to the board again, whose events are numbered independently
+	second := newBoardSubscription(board)
+	second.cached = true
+	second.boardParticipants = sessions
+	second.addEvent(&realtime.BoardEvent{Type: realtime.BoardEventBoardTimerExpired})
+	s.boardSubscriptions[board] = second
+
+	conn, _ := newTestWebsocket(t)
+	reconnected := newBoardClient(moderator, conn)
+	defer reconnected.close()
+	assert.False(t, s.resumeOnBoard(board, reconnected, since))
+	assert.NotContains(t, second.clients, reconnected.id)
+}
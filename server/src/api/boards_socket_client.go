@@ -0,0 +1,135 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"scrumlr.io/server/logger"
+)
+
+const (
+	// boardClientQueueSize is the number of messages that may be pending for a client. Clients that don't keep up with
+	// the events of the board are disconnected, so that they don't delay the other clients of the board. The queue
+	// holds all events that may be replayed to a reconnecting client.
+	boardClientQueueSize = 2 * boardEventReplayLimit
+
+	// boardClientWriteWait is the time allowed to write a message to a client.
+	boardClientWriteWait = 10 * time.Second
+
+	// boardClientPongWait is the time allowed to read the next pong message from a client.
+	boardClientPongWait = 60 * time.Second
+
+	// boardClientPingPeriod is the period of the pings sent to a client, which must be less than the pong wait.
+	boardClientPingPeriod = (boardClientPongWait * 9) / 10
+)
+
+// boardClient is a websocket connection of a user to a board. A user may be connected more than once, e.g. with several
+// tabs, so every connection has an id of its own. All messages to the client are queued and written by a goroutine of
+// its own, which also keeps the connection alive with pings and closes the connection, so that no caller ever waits
+// for the client while holding a lock.
+type boardClient struct {
+	id    uuid.UUID
+	user  uuid.UUID
+	conn  *websocket.Conn
+	queue chan interface{}
+
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// the close message sent by the writer before the connection is closed, which is set before done is closed
+	closeMessage []byte
+}
+
+func newBoardClient(user uuid.UUID, conn *websocket.Conn) *boardClient {
+	c := &boardClient{
+		id:    uuid.New(),
+		user:  user,
+		conn:  conn,
+		queue: make(chan interface{}, boardClientQueueSize),
+		done:  make(chan struct{}),
+	}
+
+	// the read deadline is extended on every pong, so that dead connections are detected by the read loop
+	_ = conn.SetReadDeadline(time.Now().Add(boardClientPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(boardClientPongWait))
+	})
+
+	go c.writePump()
+	return c
+}
+
+// send queues the message for the client. It returns false, if the queue of the client is full or the client has
+// been closed.
+func (c *boardClient) send(v interface{}) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.queue <- v:
+		return true
+	default:
+		return false
+	}
+}
+
+// close closes the connection of the client. Messages that are still queued are discarded. It is safe to close a
+// client more than once. The connection is closed by the writer of the client, so close never blocks.
+func (c *boardClient) close() {
+	c.closeWithMessage(nil)
+}
+
+// evict closes the connection of a client that doesn't keep up with the events of the board. The client is asked to
+// try again later, so that it may resume from the last event it received.
+func (c *boardClient) evict() {
+	c.closeWithMessage(websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client is too slow"))
+}
+
+func (c *boardClient) closeWithMessage(message []byte) {
+	c.closeOnce.Do(func() {
+		c.closeMessage = message
+		close(c.done)
+	})
+}
+
+func (c *boardClient) writePump() {
+	ticker := time.NewTicker(boardClientPingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		// a closed client takes precedence over the queued messages, which are discarded
+		select {
+		case <-c.done:
+			if c.closeMessage != nil {
+				_ = c.conn.WriteControl(websocket.CloseMessage, c.closeMessage, time.Now().Add(boardClientWriteWait))
+			}
+			return
+		default:
+		}
+
+		select {
+		case msg := <-c.queue:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(boardClientWriteWait))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				logger.Get().Warnw("failed to send message", "user", c.user, "connection", c.id, "err", err)
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(boardClientWriteWait)); err != nil {
+				logger.Get().Debugw("failed to ping client", "user", c.user, "connection", c.id, "err", err)
+				c.close()
+				return
+			}
+		case <-c.done:
+		}
+	}
+}
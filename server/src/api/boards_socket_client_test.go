@@ -0,0 +1,175 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database/types"
+	"scrumlr.io/server/realtime"
+)
+
+// newTestWebsocket returns both ends of a websocket connection, the one of the server first.
+func newTestWebsocket(t *testing.T) (*websocket.Conn, *websocket.Conn) {
+	connections := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		assert.Nil(t, err)
+		connections <- conn
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	conn := <-connections
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, client
+}
+
+func TestBoardClientWritesQueuedMessagesInOrder(t *testing.T) {
+	conn, remote := newTestWebsocket(t)
+	client := newBoardClient(uuid.New(), conn)
+	defer client.close()
+
+	assert.True(t, client.send(realtime.BoardEvent{Type: realtime.BoardEventNoteCreated, Seq: 1}))
+	assert.True(t, client.send(realtime.BoardEvent{Type: realtime.BoardEventNotesMoved, Seq: 2}))
+
+	for _, expected := range []uint64{1, 2} {
+		var event realtime.BoardEvent
+		_ = remote.SetReadDeadline(time.Now().Add(5 * time.Second))
+		err := remote.ReadJSON(&event)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, event.Seq)
+	}
+}
+
+func TestBoardClientDoesNotQueueMessagesAfterClose(t *testing.T) {
+	conn, _ := newTestWebsocket(t)
+	client := newBoardClient(uuid.New(), conn)
+
+	client.close()
+	client.close()
+
+	assert.False(t, client.send(realtime.BoardEvent{Type: realtime.BoardEventNoteCreated}))
+}
+
+func TestDeliverEvictsSlowClients(t *testing.T) {
+	slowConn, slowRemote := newTestWebsocket(t)
+	// the queue of the slow client is never drained, since its writer is not started
+	slow := &boardClient{id: uuid.New(), user: uuid.New(), conn: slowConn, queue: make(chan interface{}, 1), done: make(chan struct{})}
+	slow.queue <- realtime.BoardEvent{}
+
+	fastConn, _ := newTestWebsocket(t)
+	fast := newBoardClient(uuid.New(), fastConn)
+	defer fast.close()
+
+	b := newBoardSubscription(uuid.New())
+	b.clients[slow.id] = slow
+	b.clients[fast.id] = fast
+
+	b.deliver(&realtime.BoardEvent{Type: realtime.BoardEventBoardTimerExpired})
+
+	assert.NotContains(t, b.clients, slow.id)
+	assert.Contains(t, b.clients, fast.id)
+
+	// the close message is sent by the writer of the client, never while the board is locked
+	go slow.writePump()
+	_ = slowRemote.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err := slowRemote.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater))
+}
+
+func TestDeliverToAllConnectionsOfUser(t *testing.T) {
+	user := uuid.New()
+	firstConn, firstRemote := newTestWebsocket(t)
+	first := newBoardClient(user, firstConn)
+	defer first.close()
+	secondConn, secondRemote := newTestWebsocket(t)
+	second := newBoardClient(user, secondConn)
+	defer second.close()
+
+	b := newBoardSubscription(uuid.New())
+	b.clients[first.id] = first
+	b.clients[second.id] = second
+
+	b.deliver(&realtime.BoardEvent{Type: realtime.BoardEventBoardTimerExpired})
+
+	for _, remote := range []*websocket.Conn{firstRemote, secondRemote} {
+		var event realtime.BoardEvent
+		_ = remote.SetReadDeadline(time.Now().Add(5 * time.Second))
+		err := remote.ReadJSON(&event)
+		assert.Nil(t, err)
+		assert.Equal(t, realtime.BoardEventBoardTimerExpired, event.Type)
+		assert.Equal(t, b.seq, event.Seq)
+	}
+}
+
+// The delivered events are shared with the writers of the clients, so this test has to pass with the race detector.
+func TestDeliverBoardUpdatedToParticipantsAndModerators(t *testing.T) {
+	moderator := uuid.New()
+	participant := uuid.New()
+	moderatorConn, moderatorRemote := newTestWebsocket(t)
+	moderatorClient := newBoardClient(moderator, moderatorConn)
+	defer moderatorClient.close()
+	participantConn, participantRemote := newTestWebsocket(t)
+	participantClient := newBoardClient(participant, participantConn)
+	defer participantClient.close()
+
+	board := uuid.New()
+	b := newBoardSubscription(board)
+	b.boardParticipants = []*dto.BoardSession{
+		{User: dto.User{ID: moderator}, Role: types.SessionRoleModerator},
+		{User: dto.User{ID: participant}, Role: types.SessionRoleParticipant},
+	}
+	b.clients[moderatorClient.id] = moderatorClient
+	b.clients[participantClient.id] = participantClient
+
+	const events = 10
+	for i := 0; i < events; i++ {
+		b.deliver(&realtime.BoardEvent{Type: realtime.BoardEventBoardUpdated, Data: dto.Board{ID: board, AccessPolicy: types.AccessPolicyPublic}})
+	}
+
+	for _, remote := range []*websocket.Conn{moderatorRemote, participantRemote} {
+		for i := events - 1; i >= 0; i-- {
+			var event realtime.BoardEvent
+			_ = remote.SetReadDeadline(time.Now().Add(5 * time.Second))
+			err := remote.ReadJSON(&event)
+			assert.Nil(t, err)
+			assert.Equal(t, realtime.BoardEventBoardUpdated, event.Type)
+			assert.Equal(t, b.seq-uint64(i), event.Seq)
+		}
+	}
+}
+
+func TestLeaveBoardClosesSubscriptionOfLastClient(t *testing.T) {
+	user := uuid.New()
+	first := &boardClient{id: uuid.New(), user: user}
+	second := &boardClient{id: uuid.New(), user: user}
+	board := uuid.New()
+	b := newBoardSubscription(board)
+	b.clients[first.id] = first
+	b.clients[second.id] = second
+	s := Server{boardSubscriptions: map[uuid.UUID]*BoardSubscription{board: b}}
+
+	s.leaveBoard(board, first)
+	assert.Contains(t, s.boardSubscriptions, board)
+
+	s.leaveBoard(board, second)
+	assert.NotContains(t, s.boardSubscriptions, board)
+	select {
+	case <-b.done:
+	default:
+		assert.Fail(t, "listener on the board has not been stopped")
+	}
+
+	// leaving a board that is not subscribed anymore has no effect
+	s.leaveBoard(board, second)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/services"
+)
+
+type BoardMock struct {
+	services.Boards
+	mock.Mock
+}
+
+func (m *BoardMock) Get(ctx context.Context, id uuid.UUID) (*dto.Board, error) {
+	args := m.Called(id)
+	return args.Get(0).(*dto.Board), args.Error(1)
+}
+
+func (m *BoardMock) Duplicate(ctx context.Context, body dto.DuplicateBoardRequest) (*dto.Board, error) {
+	args := m.Called(body)
+	return args.Get(0).(*dto.Board), args.Error(1)
+}
+
+func (m *BoardMock) ListColumns(ctx context.Context, id uuid.UUID) ([]*dto.Column, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*dto.Column), args.Error(1)
+}
+
+type BoardTestSuite struct {
+	suite.Suite
+}
+
+func TestBoardTestSuite(t *testing.T) {
+	suite.Run(t, new(BoardTestSuite))
+}
+
+func (suite *BoardTestSuite) TestBoardWritableContext() {
+	tests := []struct {
+		name         string
+		archived     bool
+		expectedCode int
+	}{
+		{
+			name:         "board is writable",
+			archived:     false,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "board is archived",
+			archived:     true,
+			expectedCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			mock := new(BoardMock)
+
+			boardId, _ := uuid.NewRandom()
+			mock.On("Get", boardId).Return(&dto.Board{ID: boardId, Archived: tt.archived}, nil)
+			s.boards = mock
+
+			req := NewTestRequestBuilder("POST", "/", nil).
+				AddToContext("Board", boardId)
+
+			rr := httptest.NewRecorder()
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			s.BoardWritableContext(next).ServeHTTP(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			mock.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *BoardTestSuite) TestDuplicateBoard() {
+	tests := []struct {
+		name         string
+		expectedCode int
+		err          error
+	}{
+		{
+			name:         "all ok",
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "api err",
+			expectedCode: http.StatusBadRequest,
+			err:          common.BadRequestError(errors.New("assignments can only be copied along with the notes")),
+		},
+		{
+			name:         "unexpected err",
+			expectedCode: http.StatusInternalServerError,
+			err:          errors.New("oops"),
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			s.basePath = "/"
+			mock := new(BoardMock)
+
+			boardId, _ := uuid.NewRandom()
+			userId, _ := uuid.NewRandom()
+			name := "Copy"
+			mock.On("Duplicate", dto.DuplicateBoardRequest{
+				Name:  &name,
+				Notes: true,
+				ID:    boardId,
+				Owner: userId,
+			}).Return(&dto.Board{ID: uuid.New(), Name: &name}, tt.err)
+			s.boards = mock
+
+			req := NewTestRequestBuilder("POST", "/", strings.NewReader(`{"name": "Copy", "notes": true}`)).
+				AddToContext("Board", boardId).
+				AddToContext("User", userId)
+
+			rr := httptest.NewRecorder()
+			s.duplicateBoard(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			mock.AssertExpectations(suite.T())
+		})
+	}
+}
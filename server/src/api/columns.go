@@ -55,6 +55,21 @@ func (s *Server) deleteColumn(w http.ResponseWriter, r *http.Request) {
 	render.Respond(w, r, nil)
 }
 
+// restoreColumn restores a deleted column together with its notes
+func (s *Server) restoreColumn(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+	id := r.Context().Value("Column").(uuid.UUID)
+
+	column, err := s.boards.RestoreColumn(r.Context(), board, id)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, column)
+}
+
 // updateColumn updates a column
 func (s *Server) updateColumn(w http.ResponseWriter, r *http.Request) {
 	board := r.Context().Value("Board").(uuid.UUID)
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/logger"
+)
+
+// getComments returns the comments of a note, which are visible to the user
+func (s *Server) getComments(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromRequest(r)
+	board := r.Context().Value("Board").(uuid.UUID)
+	user := r.Context().Value("User").(uuid.UUID)
+	note := r.Context().Value("Note").(uuid.UUID)
+
+	comments, err := s.comments.List(r.Context(), board, note)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	isMod, err := s.sessions.ModeratorSessionExists(r.Context(), board, user)
+	if err != nil {
+		log.Errorw("unable to verify board session", "err", err)
+		common.Throw(w, r, common.InternalServerError)
+		return
+	}
+
+	if !isMod {
+		settings, err := s.boards.Get(r.Context(), board)
+		if err != nil {
+			common.Throw(w, r, err)
+			return
+		}
+		columns, err := s.boards.ListColumns(r.Context(), board)
+		if err != nil {
+			common.Throw(w, r, common.InternalServerError)
+			return
+		}
+		notes, err := s.notes.List(r.Context(), board)
+		if err != nil {
+			common.Throw(w, r, common.InternalServerError)
+			return
+		}
+
+		visibleNotes := filterNotes(notes, user, settings, columns)
+		comments = filterComments(comments, user, settings, visibleNotes)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, comments)
+}
+
+// createComment creates a new comment on a note
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromRequest(r)
+	board := r.Context().Value("Board").(uuid.UUID)
+	user := r.Context().Value("User").(uuid.UUID)
+	note := r.Context().Value("Note").(uuid.UUID)
+
+	var body dto.CommentCreateRequest
+	if err := render.Decode(r, &body); err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	isMod, err := s.sessions.ModeratorSessionExists(r.Context(), board, user)
+	if err != nil {
+		log.Errorw("unable to verify board session", "err", err)
+		common.Throw(w, r, common.InternalServerError)
+		return
+	}
+
+	if !isMod {
+		// participants may only comment on notes that are visible to them
+		settings, err := s.boards.Get(r.Context(), board)
+		if err != nil {
+			common.Throw(w, r, err)
+			return
+		}
+		columns, err := s.boards.ListColumns(r.Context(), board)
+		if err != nil {
+			common.Throw(w, r, common.InternalServerError)
+			return
+		}
+		commentedNote, err := s.notes.Get(r.Context(), note)
+		if err != nil {
+			common.Throw(w, r, err)
+			return
+		}
+		if len(filterNotes([]*dto.Note{commentedNote}, user, settings, columns)) == 0 {
+			common.Throw(w, r, common.NotFoundError)
+			return
+		}
+	}
+
+	body.Board = board
+	body.Note = note
+	body.User = user
+
+	comment, err := s.comments.Create(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, comment)
+}
+
+// updateComment updates the text of a comment
+func (s *Server) updateComment(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+	user := r.Context().Value("User").(uuid.UUID)
+	note := r.Context().Value("Note").(uuid.UUID)
+	id := r.Context().Value("Comment").(uuid.UUID)
+
+	var body dto.CommentUpdateRequest
+	if err := render.Decode(r, &body); err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	body.ID = id
+	body.Board = board
+	body.Note = note
+	body.User = user
+
+	comment, err := s.comments.Update(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, comment)
+}
+
+// deleteComment deletes a comment
+func (s *Server) deleteComment(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+	user := r.Context().Value("User").(uuid.UUID)
+	note := r.Context().Value("Note").(uuid.UUID)
+	id := r.Context().Value("Comment").(uuid.UUID)
+
+	if err := s.comments.Delete(r.Context(), board, note, user, id); err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/services"
+)
+
+type CommentsMock struct {
+	services.Comments
+	mock.Mock
+}
+
+func (m *CommentsMock) Create(ctx context.Context, req dto.CommentCreateRequest) (*dto.Comment, error) {
+	args := m.Called(req)
+	return args.Get(0).(*dto.Comment), args.Error(1)
+}
+
+func (m *CommentsMock) List(ctx context.Context, board, note uuid.UUID) ([]*dto.Comment, error) {
+	args := m.Called(board, note)
+	return args.Get(0).([]*dto.Comment), args.Error(1)
+}
+
+type SessionsMock struct {
+	services.BoardSessions
+	mock.Mock
+}
+
+func (m *SessionsMock) Update(ctx context.Context, body dto.BoardSessionUpdateRequest) (*dto.BoardSession, error) {
+	args := m.Called(body)
+	return args.Get(0).(*dto.BoardSession), args.Error(1)
+}
+
+func (m *SessionsMock) ModeratorSessionExists(ctx context.Context, board, user uuid.UUID) (bool, error) {
+	args := m.Called(board, user)
+	return args.Bool(0), args.Error(1)
+}
+
+type CommentsTestSuite struct {
+	suite.Suite
+}
+
+func TestCommentsTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentsTestSuite))
+}
+
+func (suite *CommentsTestSuite) TestCreateComment() {
+	tests := []struct {
+		name         string
+		expectedCode int
+		err          error
+	}{
+		{
+			name:         "all ok",
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "empty text",
+			expectedCode: http.StatusBadRequest,
+			err:          common.BadRequestError(errors.New("the text of a comment must not be empty")),
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			mock := new(CommentsMock)
+			sessions := new(SessionsMock)
+			s.comments = mock
+			s.sessions = sessions
+
+			boardId, _ := uuid.NewRandom()
+			userId, _ := uuid.NewRandom()
+			noteId, _ := uuid.NewRandom()
+
+			sessions.On("ModeratorSessionExists", boardId, userId).Return(true, nil)
+
+			mock.On("Create", dto.CommentCreateRequest{
+				Text:  "a remark",
+				Board: boardId,
+				Note:  noteId,
+				User:  userId,
+			}).Return(&dto.Comment{}, tt.err)
+
+			req := NewTestRequestBuilder("POST", "/", strings.NewReader(`{"text": "a remark"}`)).
+				AddToContext("Board", boardId).
+				AddToContext("User", userId).
+				AddToContext("Note", noteId)
+			rr := httptest.NewRecorder()
+
+			s.createComment(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			mock.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *CommentsTestSuite) TestCreateCommentAsParticipant() {
+	tests := []struct {
+		name                  string
+		showNotesOfOtherUsers bool
+		columnVisible         bool
+		expectedCode          int
+	}{
+		{
+			name:                  "visible note of other user",
+			showNotesOfOtherUsers: true,
+			columnVisible:         true,
+			expectedCode:          http.StatusCreated,
+		},
+		{
+			name:                  "hidden note of other user",
+			showNotesOfOtherUsers: false,
+			columnVisible:         true,
+			expectedCode:          http.StatusNotFound,
+		},
+		{
+			name:                  "note in hidden column",
+			showNotesOfOtherUsers: true,
+			columnVisible:         false,
+			expectedCode:          http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			comments := new(CommentsMock)
+			sessions := new(SessionsMock)
+			boards := new(BoardMock)
+			notes := new(NotesMock)
+			s.comments = comments
+			s.sessions = sessions
+			s.boards = boards
+			s.notes = notes
+
+			boardId, _ := uuid.NewRandom()
+			userId, _ := uuid.NewRandom()
+			otherUserId, _ := uuid.NewRandom()
+			column := &dto.Column{ID: uuid.New(), Visible: tt.columnVisible}
+			note := &dto.Note{ID: uuid.New(), Author: otherUserId, Position: dto.NotePosition{Column: column.ID}}
+
+			sessions.On("ModeratorSessionExists", boardId, userId).Return(false, nil)
+			boards.On("Get", boardId).Return(&dto.Board{ID: boardId, ShowNotesOfOtherUsers: tt.showNotesOfOtherUsers, ShowAuthors: true}, nil)
+			boards.On("ListColumns", boardId).Return([]*dto.Column{column}, nil)
+			notes.On("Get", note.ID).Return(note, nil)
+			if tt.expectedCode == http.StatusCreated {
+				comments.On("Create", dto.CommentCreateRequest{
+					Text:  "a remark",
+					Board: boardId,
+					Note:  note.ID,
+					User:  userId,
+				}).Return(&dto.Comment{}, nil)
+			}
+
+			req := NewTestRequestBuilder("POST", "/", strings.NewReader(`{"text": "a remark"}`)).
+				AddToContext("Board", boardId).
+				AddToContext("User", userId).
+				AddToContext("Note", note.ID)
+			rr := httptest.NewRecorder()
+
+			s.createComment(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			comments.AssertExpectations(suite.T())
+			if tt.expectedCode != http.StatusCreated {
+				comments.AssertNotCalled(suite.T(), "Create", mock.Anything)
+			}
+		})
+	}
+}
+
+func (suite *CommentsTestSuite) TestGetCommentsAsParticipant() {
+	s := new(Server)
+	comments := new(CommentsMock)
+	sessions := new(SessionsMock)
+	boards := new(BoardMock)
+	notes := new(NotesMock)
+	s.comments = comments
+	s.sessions = sessions
+	s.boards = boards
+	s.notes = notes
+
+	boardId, _ := uuid.NewRandom()
+	userId, _ := uuid.NewRandom()
+	otherUserId, _ := uuid.NewRandom()
+	column := &dto.Column{ID: uuid.New(), Visible: true}
+	note := &dto.Note{ID: uuid.New(), Author: userId, Position: dto.NotePosition{Column: column.ID}}
+	ownComment := &dto.Comment{ID: uuid.New(), Note: note.ID, Author: userId}
+	otherComment := &dto.Comment{ID: uuid.New(), Note: note.ID, Author: otherUserId}
+
+	comments.On("List", boardId, note.ID).Return([]*dto.Comment{ownComment, otherComment}, nil)
+	sessions.On("ModeratorSessionExists", boardId, userId).Return(false, nil)
+	boards.On("Get", boardId).Return(&dto.Board{ID: boardId, ShowNotesOfOtherUsers: false, ShowAuthors: true}, nil)
+	boards.On("ListColumns", boardId).Return([]*dto.Column{column}, nil)
+	notes.On("List", boardId).Return([]*dto.Note{note}, nil)
+
+	req := NewTestRequestBuilder("GET", "/", nil).
+		AddToContext("Board", boardId).
+		AddToContext("User", userId).
+		AddToContext("Note", note.ID)
+	rr := httptest.NewRecorder()
+
+	s.getComments(rr, req.Request())
+
+	var result []*dto.Comment
+	suite.Equal(http.StatusOK, rr.Result().StatusCode)
+	suite.Nil(render.DecodeJSON(rr.Result().Body, &result))
+	suite.Equal(1, len(result))
+	suite.Equal(ownComment.ID, result[0].ID)
+}
@@ -142,6 +142,20 @@ func (s *Server) ReactionContext(next http.Handler) http.Handler {
 	})
 }
 
+func (s *Server) CommentContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commentParam := chi.URLParam(r, "comment")
+		comment, err := uuid.Parse(commentParam)
+		if err != nil {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid comment id")))
+			return
+		}
+
+		commentContext := context.WithValue(r.Context(), "Comment", comment)
+		next.ServeHTTP(w, r.WithContext(commentContext))
+	})
+}
+
 func (s *Server) AssignmentContext(next http.Handler) http.Handler {
   return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
     assignmentParam := chi.URLParam(r, "assignment")
@@ -168,3 +182,89 @@ func (s *Server) VotingContext(next http.Handler) http.Handler {
     next.ServeHTTP(w, r.WithContext(votingContext))
   })
 }
+
+func (s *Server) TemplateContext(next http.Handler) http.Handler {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    templateParam := chi.URLParam(r, "template")
+    id, err := uuid.Parse(templateParam)
+    if err != nil {
+      common.Throw(w, r, common.BadRequestError(errors.New("invalid template id")))
+      return
+    }
+
+    user := r.Context().Value("User").(uuid.UUID)
+
+    template, err := s.templates.Get(r.Context(), id)
+    if err != nil {
+      common.Throw(w, r, err)
+      return
+    }
+
+    // templates are private to their creator
+    if template.Creator != user {
+      common.Throw(w, r, common.NotFoundError)
+      return
+    }
+
+    templateContext := context.WithValue(r.Context(), "Template", id)
+    next.ServeHTTP(w, r.WithContext(templateContext))
+  })
+}
+
+func (s *Server) ActionItemContext(next http.Handler) http.Handler {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    actionItemParam := chi.URLParam(r, "actionItem")
+    id, err := uuid.Parse(actionItemParam)
+    if err != nil {
+      common.Throw(w, r, common.BadRequestError(errors.New("invalid action item id")))
+      return
+    }
+
+    actionItem, err := s.actionItems.Get(r.Context(), id)
+    if err != nil {
+      common.Throw(w, r, err)
+      return
+    }
+
+    // action items are accessible through their board or by their owner
+    if board, ok := r.Context().Value("Board").(uuid.UUID); ok {
+      if !actionItem.Board.Valid || actionItem.Board.UUID != board {
+        common.Throw(w, r, common.NotFoundError)
+        return
+      }
+    } else if actionItem.Owner != r.Context().Value("User").(uuid.UUID) {
+      common.Throw(w, r, common.NotFoundError)
+      return
+    }
+
+    actionItemContext := context.WithValue(r.Context(), "ActionItem", id)
+    next.ServeHTTP(w, r.WithContext(actionItemContext))
+  })
+}
+
+func (s *Server) BoardWritableContext(next http.Handler) http.Handler {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    board := r.Context().Value("Board").(uuid.UUID)
+
+    if err := s.checkBoardWritable(r.Context(), board); err != nil {
+      common.Throw(w, r, err)
+      return
+    }
+
+    next.ServeHTTP(w, r)
+  })
+}
+
+// checkBoardWritable returns a conflict error for archived boards, since they are read-only.
+func (s *Server) checkBoardWritable(ctx context.Context, board uuid.UUID) error {
+  b, err := s.boards.Get(ctx, board)
+  if err != nil {
+    logger.FromContext(ctx).Errorw("unable to get board", "err", err)
+    return common.InternalServerError
+  }
+
+  if b.Archived {
+    return common.ConflictError(errors.New("board is archived"))
+  }
+  return nil
+}
@@ -49,6 +49,39 @@ func parseNotesUpdated(data interface{}) ([]*dto.Note, error) {
 	return ret, nil
 }
 
+func parseNote(data interface{}) (*dto.Note, error) {
+	var ret *dto.Note
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+type NoteDeleted struct {
+	Note        uuid.UUID `json:"note"`
+	DeleteStack bool      `json:"deleteStack"`
+}
+
+func parseNoteDeleted(data interface{}) (*NoteDeleted, error) {
+	var ret *NoteDeleted
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 func parseBoardUpdated(data interface{}) (*dto.Board, error) {
 	var ret *dto.Board
 
@@ -63,6 +96,48 @@ func parseBoardUpdated(data interface{}) (*dto.Board, error) {
 	return ret, nil
 }
 
+func parseBoardSessions(data interface{}) ([]*dto.BoardSession, error) {
+	var ret []*dto.BoardSession
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func parseBoardSession(data interface{}) (*dto.BoardSession, error) {
+	var ret *dto.BoardSession
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func parseCommentsUpdated(data interface{}) ([]*dto.Comment, error) {
+	var ret []*dto.Comment
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 type VotingUpdated struct {
 	Notes  []*dto.Note `json:"notes"`
 	Voting *dto.Voting `json:"voting"`
@@ -93,6 +168,25 @@ func filterColumns(eventColumns []*dto.Column) []*dto.Column {
 	return visibleColumns
 }
 
+func isColumnVisible(columnID uuid.UUID, columns []*dto.Column) bool {
+	for _, column := range columns {
+		if column.ID == columnID {
+			return column.Visible
+		}
+	}
+	return false
+}
+
+// copyNotes returns copies of the notes, which may be filtered without changing the notes shared by all clients.
+func copyNotes(notes []*dto.Note) []*dto.Note {
+	copies := make([]*dto.Note, 0, len(notes))
+	for _, note := range notes {
+		copied := *note
+		copies = append(copies, &copied)
+	}
+	return copies
+}
+
 func filterNotes(eventNotes []*dto.Note, userID uuid.UUID, boardSettings *dto.Board, columns []*dto.Column) []*dto.Note {
 	var visibleNotes = make([]*dto.Note, 0, len(eventNotes))
 	for _, note := range eventNotes {
@@ -117,6 +211,30 @@ func filterNotes(eventNotes []*dto.Note, userID uuid.UUID, boardSettings *dto.Bo
 	return visibleNotes
 }
 
+// filterComments applies the visibility of notes to their comments: comments are only visible on visible notes and
+// comments of other users are hidden as long as the notes of other users are hidden. The author of comments is hidden
+// accordingly.
+func filterComments(eventComments []*dto.Comment, userID uuid.UUID, boardSettings *dto.Board, visibleNotes []*dto.Note) []*dto.Comment {
+	var visibleComments = make([]*dto.Comment, 0, len(eventComments))
+	for _, comment := range eventComments {
+		for _, note := range visibleNotes {
+			if comment.Note == note.ID {
+				if boardSettings.ShowNotesOfOtherUsers || userID == comment.Author {
+					visibleComments = append(visibleComments, comment)
+				}
+			}
+		}
+	}
+	// Authors
+	for _, comment := range visibleComments {
+		if !boardSettings.ShowAuthors && comment.Author != userID {
+			comment.Author = uuid.Nil
+		}
+	}
+
+	return visibleComments
+}
+
 func filterVotingUpdated(voting *VotingUpdated, userID uuid.UUID, boardSettings *dto.Board, columns []*dto.Column) *VotingUpdated {
 	filteredVoting := voting
 	// Filter voting notes
@@ -181,6 +299,8 @@ func filterVoting(voting *dto.Voting, filteredNotes []*dto.Note, userID uuid.UUI
 	return filteredVoting
 }
 
+// eventFilter returns the event as it is visible to the user. The event is shared by all clients of the board, so the
+// filter returns a copy instead of changing it.
 func (boardSubscription *BoardSubscription) eventFilter(event *realtime.BoardEvent, userID uuid.UUID) *realtime.BoardEvent {
 	isMod := isModerator(userID, boardSubscription.boardParticipants)
 	if event.Type == realtime.BoardEventColumnsUpdated {
@@ -188,15 +308,14 @@ func (boardSubscription *BoardSubscription) eventFilter(event *realtime.BoardEve
 		if err != nil {
 			logger.Get().Errorw("unable to parse columnUpdated in event filter", "board", boardSubscription.boardSettings.ID, "session", userID, "error", err)
 		}
-		// Cache the incoming changes, mod only since they receive all changes
 		if isMod {
-			boardSubscription.boardColumns = columns
 			return event
 		}
 
 		filteredColumns := filterColumns(columns)
 		ret := realtime.BoardEvent{
 			Type: event.Type,
+			Seq:  event.Seq,
 			Data: filteredColumns,
 		}
 
@@ -210,28 +329,87 @@ func (boardSubscription *BoardSubscription) eventFilter(event *realtime.BoardEve
 		}
 
 		if isMod {
-			boardSubscription.boardNotes = notes
 			return event
 		}
 
 		filteredNotes := filterNotes(notes, userID, boardSubscription.boardSettings, boardSubscription.boardColumns)
 		ret := realtime.BoardEvent{
 			Type: event.Type,
+			Seq:  event.Seq,
 			Data: filteredNotes,
 		}
 
 		return &ret
 	}
 
+	// The note deltas are applied to the cached notes by the listener on the board before they are filtered. Events that
+	// contain no note visible to the client are not delivered at all.
+	if event.Type == realtime.BoardEventNoteCreated || event.Type == realtime.BoardEventNoteTextUpdated {
+		note, err := parseNote(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse note in event filter", "board", boardSubscription.boardSettings.ID, "session", userID, "error", err)
+			return nil
+		}
+		if isMod {
+			return event
+		}
+
+		filteredNotes := filterNotes([]*dto.Note{note}, userID, boardSubscription.boardSettings, boardSubscription.boardColumns)
+		if len(filteredNotes) == 0 {
+			return nil
+		}
+		ret := realtime.BoardEvent{
+			Type: event.Type,
+			Seq:  event.Seq,
+			Data: filteredNotes[0],
+		}
+		return &ret
+	}
+
+	if event.Type == realtime.BoardEventNotesMoved {
+		notes, err := parseNotesUpdated(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse notesMoved in event filter", "board", boardSubscription.boardSettings.ID, "session", userID, "error", err)
+			return nil
+		}
+		if isMod {
+			return event
+		}
+
+		for _, note := range notes {
+			if !isColumnVisible(note.Position.Column, boardSubscription.boardColumns) {
+				// notes moved into a hidden column have to disappear for the client, which a delta is unable to express,
+				// so the client receives all of its visible notes instead
+				ret := realtime.BoardEvent{
+					Type: realtime.BoardEventNotesSync,
+					Seq:  event.Seq,
+					Data: filterNotes(copyNotes(boardSubscription.boardNotes), userID, boardSubscription.boardSettings, boardSubscription.boardColumns),
+				}
+				return &ret
+			}
+		}
+
+		filteredNotes := filterNotes(notes, userID, boardSubscription.boardSettings, boardSubscription.boardColumns)
+		if len(filteredNotes) == 0 {
+			return nil
+		}
+		ret := realtime.BoardEvent{
+			Type: event.Type,
+			Seq:  event.Seq,
+			Data: filteredNotes,
+		}
+		return &ret
+	}
+
 	if event.Type == realtime.BoardEventBoardUpdated {
 		boardSettings, err := parseBoardUpdated(event.Data)
 		if err != nil {
 			logger.Get().Errorw("unable to parse boardUpdated in event filter", "board", boardSubscription.boardSettings.ID, "session", userID, "error", err)
 		}
 		if isMod {
-			boardSubscription.boardSettings = boardSettings
-			event.Data = boardSettings
-			return event
+			ret := *event
+			ret.Data = boardSettings
+			return &ret
 		}
 		return event // after this event, a syncNotes event is triggered from the board service
 	}
@@ -251,11 +429,32 @@ func (boardSubscription *BoardSubscription) eventFilter(event *realtime.BoardEve
 		filteredVoting := filterVotingUpdated(voting, userID, boardSubscription.boardSettings, boardSubscription.boardColumns)
 		ret := realtime.BoardEvent{
 			Type: event.Type,
+			Seq:  event.Seq,
 			Data: filteredVoting,
 		}
 		return &ret
 	}
 
+	if event.Type == realtime.BoardEventCommentsUpdated {
+		comments, err := parseCommentsUpdated(event.Data)
+		if err != nil {
+			logger.Get().Errorw("unable to parse commentsUpdated in event filter", "board", boardSubscription.boardSettings.ID, "session", userID, "error", err)
+		}
+		if isMod {
+			return event
+		}
+
+		// filter a copy of the notes, since the cached notes are shared by all clients
+		filteredNotes := filterNotes(copyNotes(boardSubscription.boardNotes), userID, boardSubscription.boardSettings, boardSubscription.boardColumns)
+		filteredComments := filterComments(comments, userID, boardSubscription.boardSettings, filteredNotes)
+		ret := realtime.BoardEvent{
+			Type: event.Type,
+			Seq:  event.Seq,
+			Data: filteredComments,
+		}
+		return &ret
+	}
+
 	if event.Type == realtime.BoardEventNotesSync {
 		notes, err := parseNotesUpdated(event.Data)
 		if err != nil {
@@ -263,13 +462,13 @@ func (boardSubscription *BoardSubscription) eventFilter(event *realtime.BoardEve
 		}
 
 		if isMod {
-			boardSubscription.boardNotes = notes
 			return event
 		}
 
 		filteredNotes := filterNotes(notes, userID, boardSubscription.boardSettings, boardSubscription.boardColumns)
 		ret := realtime.BoardEvent{
 			Type: event.Type,
+			Seq:  event.Seq,
 			Data: filteredNotes,
 		}
 
@@ -297,6 +496,7 @@ func eventInitFilter(event InitEvent, clientID uuid.UUID) InitEvent {
 			Sessions:    event.Data.Sessions,
 			Requests:    event.Data.Requests,
 			Assignments: event.Data.Assignments,
+			Comments:    nil,
 		},
 	}
 	// Columns
@@ -313,6 +513,8 @@ func eventInitFilter(event InitEvent, clientID uuid.UUID) InitEvent {
 				aVote := dto.Vote{
 					Voting: v.Voting,
 					Note:   n.ID,
+					Weight: v.Weight,
+					Rank:   v.Rank,
 				}
 				visibleVotes = append(visibleVotes, &aVote)
 			}
@@ -325,10 +527,14 @@ func eventInitFilter(event InitEvent, clientID uuid.UUID) InitEvent {
 		visibleVotings = append(visibleVotings, filteredVoting)
 	}
 
+	// Comments
+	filteredComments := filterComments(event.Data.Comments, clientID, event.Data.Board, filteredNotes)
+
 	retEvent.Data.Columns = filteredColumns
 	retEvent.Data.Notes = filteredNotes
 	retEvent.Data.Votes = visibleVotes
 	retEvent.Data.Votings = visibleVotings
+	retEvent.Data.Comments = filteredComments
 
 	return retEvent
 }
@@ -79,6 +79,24 @@ var (
 			Stack:  uuid.NullUUID{},
 		},
 	}
+	aParticipantComment = dto.Comment{
+		ID:     uuid.New(),
+		Note:   aParticipantNote.ID,
+		Author: participantBoardSession.User.ID,
+		Text:   "User Comment",
+	}
+	aModeratorComment = dto.Comment{
+		ID:     uuid.New(),
+		Note:   aParticipantNote.ID,
+		Author: moderatorBoardSession.User.ID,
+		Text:   "Moderator Comment",
+	}
+	aOwnerComment = dto.Comment{
+		ID:     uuid.New(),
+		Note:   aOwnerNote.ID,
+		Author: ownerBoardSession.User.ID,
+		Text:   "Owner Comment",
+	}
 	boardSub = &BoardSubscription{
 		boardParticipants: []*dto.BoardSession{&moderatorBoardSession, &ownerBoardSession, &participantBoardSession},
 		boardColumns:      []*dto.Column{&aSeeableColumn, &aHiddenColumn},
@@ -99,6 +117,10 @@ var (
 		Type: realtime.BoardEventNotesUpdated,
 		Data: []*dto.Note{&aParticipantNote, &aModeratorNote, &aOwnerNote},
 	}
+	commentEvent = &realtime.BoardEvent{
+		Type: realtime.BoardEventCommentsUpdated,
+		Data: []*dto.Comment{&aParticipantComment, &aModeratorComment, &aOwnerComment},
+	}
 	votingID   = uuid.New()
 	votingData = &VotingUpdated{
 		Notes: []*dto.Note{&aParticipantNote, &aModeratorNote, &aOwnerNote},
@@ -142,6 +164,7 @@ var (
 			Sessions:    boardSessions,
 			Requests:    []*dto.BoardSessionRequest{},
 			Assignments: []*dto.Assignment{},
+			Comments:    []*dto.Comment{&aParticipantComment, &aModeratorComment, &aOwnerComment},
 		},
 	}
 )
@@ -155,12 +178,22 @@ func TestEventFilter(t *testing.T) {
 	t.Run("TestParseColumnData", testParseColumnData)
 	t.Run("TestParseNoteData", testParseNoteData)
 	t.Run("TestParseVotingData", testParseVotingData)
+	t.Run("TestParseCommentData", testParseCommentData)
 	t.Run("TestFilterColumnsAsOwner", testColumnFilterAsOwner)
 	t.Run("TestFilterColumnsAsModerator", testColumnFilterAsModerator)
 	t.Run("TestFilterColumnsAsParticipant", testColumnFilterAsParticipant)
 	t.Run("TestFilterNotesAsOwner", testNoteFilterAsOwner)
 	t.Run("TestFilterNotesAsModerator", testNoteFilterAsModerator)
 	t.Run("TestFilterNotesAsParticipant", testNoteFilterAsParticipant)
+	t.Run("TestFilterCommentsAsModerator", testCommentFilterAsModerator)
+	t.Run("TestFilterCommentsAsParticipant", testCommentFilterAsParticipant)
+	t.Run("TestFilterCommentsHidesAuthors", testCommentFilterHidesAuthors)
+	t.Run("TestFilterNoteCreatedAsModerator", testNoteCreatedFilterAsModerator)
+	t.Run("TestFilterNoteCreatedAsParticipant", testNoteCreatedFilterAsParticipant)
+	t.Run("TestFilterNoteTextUpdatedOfHiddenNoteAsParticipant", testNoteTextUpdatedFilterOfHiddenNoteAsParticipant)
+	t.Run("TestFilterNotesMovedAsModerator", testNotesMovedFilterAsModerator)
+	t.Run("TestFilterNotesMovedAsParticipant", testNotesMovedFilterAsParticipant)
+	t.Run("TestFilterNotesMovedIntoHiddenColumnAsParticipant", testNotesMovedIntoHiddenColumnFilterAsParticipant)
 	t.Run("TestFilterVotingUpdatedAsOwner", testFilterVotingUpdatedAsOwner)
 	t.Run("TestFilterVotingUpdatedAsModerator", testFilterVotingUpdatedAsModerator)
 	t.Run("TestFilterVotingUpdatedAsParticipant", testFilterVotingUpdatedAsParticipant)
@@ -236,6 +269,15 @@ func testParseVotingData(t *testing.T) {
 	assert.Equal(t, expectedVoting, actualVoting)
 }
 
+func testParseCommentData(t *testing.T) {
+	expectedComments := []*dto.Comment{&aParticipantComment, &aModeratorComment, &aOwnerComment}
+	actualComments, err := parseCommentsUpdated(commentEvent.Data)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, actualComments)
+	assert.Equal(t, expectedComments, actualComments)
+}
+
 func testColumnFilterAsParticipant(t *testing.T) {
 	expectedColumnEvent := &realtime.BoardEvent{
 		Type: realtime.BoardEventColumnsUpdated,
@@ -297,6 +339,106 @@ func testNoteFilterAsModerator(t *testing.T) {
 	assert.Equal(t, expectedNoteEvent, returnedNoteEvent)
 }
 
+func testCommentFilterAsParticipant(t *testing.T) {
+	expectedCommentEvent := &realtime.BoardEvent{
+		Type: realtime.BoardEventCommentsUpdated,
+		Data: []*dto.Comment{&aParticipantComment},
+	}
+	returnedCommentEvent := boardSub.eventFilter(commentEvent, participantBoardSession.User.ID)
+
+	assert.Equal(t, expectedCommentEvent, returnedCommentEvent)
+}
+
+func testCommentFilterAsModerator(t *testing.T) {
+	expectedCommentEvent := &realtime.BoardEvent{
+		Type: realtime.BoardEventCommentsUpdated,
+		Data: []*dto.Comment{&aParticipantComment, &aModeratorComment, &aOwnerComment},
+	}
+	returnedCommentEvent := boardSub.eventFilter(commentEvent, moderatorBoardSession.User.ID)
+
+	assert.Equal(t, expectedCommentEvent, returnedCommentEvent)
+}
+
+func testCommentFilterHidesAuthors(t *testing.T) {
+	participantComment := aParticipantComment
+	moderatorComment := aModeratorComment
+	ownerComment := aOwnerComment
+	settings := &dto.Board{ShowNotesOfOtherUsers: true, ShowAuthors: false}
+
+	visibleComments := filterComments([]*dto.Comment{&participantComment, &moderatorComment, &ownerComment}, participantBoardSession.User.ID, settings, []*dto.Note{&aParticipantNote})
+
+	assert.Equal(t, 2, len(visibleComments))
+	assert.Equal(t, participantBoardSession.User.ID, visibleComments[0].Author)
+	assert.Equal(t, uuid.Nil, visibleComments[1].Author)
+}
+
+func noteDeltaSubscription() *BoardSubscription {
+	return &BoardSubscription{
+		boardParticipants: []*dto.BoardSession{&moderatorBoardSession, &ownerBoardSession, &participantBoardSession},
+		boardColumns:      []*dto.Column{&aSeeableColumn, &aHiddenColumn},
+		boardNotes:        copyNotes([]*dto.Note{&aParticipantNote, &aModeratorNote, &aOwnerNote}),
+		boardSettings:     &dto.Board{ShowNotesOfOtherUsers: false, ShowAuthors: true},
+	}
+}
+
+func testNoteCreatedFilterAsModerator(t *testing.T) {
+	event := &realtime.BoardEvent{Type: realtime.BoardEventNoteCreated, Data: &aOwnerNote}
+	returnedEvent := noteDeltaSubscription().eventFilter(event, moderatorBoardSession.User.ID)
+
+	assert.Equal(t, event, returnedEvent)
+}
+
+func testNoteCreatedFilterAsParticipant(t *testing.T) {
+	sub := noteDeltaSubscription()
+
+	ownEvent := &realtime.BoardEvent{Type: realtime.BoardEventNoteCreated, Data: &aParticipantNote}
+	assert.Equal(t, &realtime.BoardEvent{Type: realtime.BoardEventNoteCreated, Data: &aParticipantNote}, sub.eventFilter(ownEvent, participantBoardSession.User.ID))
+
+	otherEvent := &realtime.BoardEvent{Type: realtime.BoardEventNoteCreated, Data: &aModeratorNote}
+	assert.Nil(t, sub.eventFilter(otherEvent, participantBoardSession.User.ID))
+}
+
+func testNoteTextUpdatedFilterOfHiddenNoteAsParticipant(t *testing.T) {
+	sub := noteDeltaSubscription()
+	sub.boardSettings.ShowNotesOfOtherUsers = true
+
+	event := &realtime.BoardEvent{Type: realtime.BoardEventNoteTextUpdated, Data: &aOwnerNote}
+	assert.Nil(t, sub.eventFilter(event, participantBoardSession.User.ID))
+}
+
+func testNotesMovedFilterAsModerator(t *testing.T) {
+	event := &realtime.BoardEvent{Type: realtime.BoardEventNotesMoved, Data: []*dto.Note{&aParticipantNote, &aOwnerNote}}
+	returnedEvent := noteDeltaSubscription().eventFilter(event, moderatorBoardSession.User.ID)
+
+	assert.Equal(t, event, returnedEvent)
+}
+
+func testNotesMovedFilterAsParticipant(t *testing.T) {
+	sub := noteDeltaSubscription()
+
+	event := &realtime.BoardEvent{Type: realtime.BoardEventNotesMoved, Data: []*dto.Note{&aParticipantNote, &aModeratorNote}}
+	expectedEvent := &realtime.BoardEvent{Type: realtime.BoardEventNotesMoved, Data: []*dto.Note{&aParticipantNote}}
+	assert.Equal(t, expectedEvent, sub.eventFilter(event, participantBoardSession.User.ID))
+
+	otherEvent := &realtime.BoardEvent{Type: realtime.BoardEventNotesMoved, Data: []*dto.Note{&aModeratorNote}}
+	assert.Nil(t, sub.eventFilter(otherEvent, participantBoardSession.User.ID))
+}
+
+func testNotesMovedIntoHiddenColumnFilterAsParticipant(t *testing.T) {
+	sub := noteDeltaSubscription()
+	movedNote := aParticipantNote
+	movedNote.Position.Column = aHiddenColumn.ID
+	sub.boardNotes = copyNotes([]*dto.Note{&movedNote, &aModeratorNote, &aOwnerNote})
+
+	participantNote := aParticipantNote
+	participantNote.ID = uuid.New()
+	sub.boardNotes = append(sub.boardNotes, &participantNote)
+
+	event := &realtime.BoardEvent{Type: realtime.BoardEventNotesMoved, Data: []*dto.Note{&movedNote}}
+	expectedEvent := &realtime.BoardEvent{Type: realtime.BoardEventNotesSync, Data: []*dto.Note{&participantNote}}
+	assert.Equal(t, expectedEvent, sub.eventFilter(event, participantBoardSession.User.ID))
+}
+
 func testFilterVotingUpdatedAsOwner(t *testing.T) {
 	expectedVotingEvent := &realtime.BoardEvent{
 		Type: realtime.BoardEventVotingUpdated,
@@ -391,6 +533,7 @@ func testInitFilterAsParticipant(t *testing.T) {
 			Sessions:    boardSessions,
 			Requests:    []*dto.BoardSessionRequest{},
 			Assignments: []*dto.Assignment{},
+			Comments:    []*dto.Comment{&aParticipantComment},
 		},
 	}
 	returnedInitEvent := eventInitFilter(initEvent, participantBoardSession.User.ID)
@@ -2,12 +2,14 @@ package api
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
 	"scrumlr.io/server/common"
 	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/logger"
 )
 
 // createNote creates a new note
@@ -108,3 +110,86 @@ func (s *Server) deleteNote(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusNoContent)
 	render.Respond(w, r, nil)
 }
+
+// getNoteHistory get the previous revisions of a note
+func (s *Server) getNoteHistory(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromRequest(r)
+	board := r.Context().Value("Board").(uuid.UUID)
+	user := r.Context().Value("User").(uuid.UUID)
+	noteId := r.Context().Value("Note").(uuid.UUID)
+
+	isMod, err := s.sessions.ModeratorSessionExists(r.Context(), board, user)
+	if err != nil {
+		log.Errorw("unable to verify board session", "err", err)
+		common.Throw(w, r, common.InternalServerError)
+		return
+	}
+
+	var settings *dto.Board
+	if !isMod {
+		// participants may only see the history of notes that are visible to them
+		settings, err = s.boards.Get(r.Context(), board)
+		if err != nil {
+			common.Throw(w, r, err)
+			return
+		}
+		columns, err := s.boards.ListColumns(r.Context(), board)
+		if err != nil {
+			common.Throw(w, r, common.InternalServerError)
+			return
+		}
+		note, err := s.notes.Get(r.Context(), noteId)
+		if err != nil {
+			common.Throw(w, r, err)
+			return
+		}
+		if len(filterNotes([]*dto.Note{note}, user, settings, columns)) == 0 {
+			common.Throw(w, r, common.NotFoundError)
+			return
+		}
+	}
+
+	revisions, err := s.notes.History(r.Context(), board, noteId)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	if settings != nil && !settings.ShowAuthors {
+		for _, revision := range revisions {
+			if revision.Editor != nil && *revision.Editor != user {
+				revision.Editor = nil
+			}
+		}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, revisions)
+}
+
+// restoreNote restores the text of a previous revision of a note or a deleted note
+func (s *Server) restoreNote(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+	user := r.Context().Value("User").(uuid.UUID)
+	noteId := r.Context().Value("Note").(uuid.UUID)
+
+	// the body may be omitted to restore a deleted note
+	var body dto.NoteRestoreRequest
+	if err := render.Decode(r, &body); err != nil && err != io.EOF {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	body.Note = noteId
+	body.Board = board
+	body.User = user
+
+	note, err := s.notes.Restore(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, note)
+}
@@ -31,6 +31,26 @@ func (m *NotesMock) Get(ctx context.Context, id uuid.UUID) (*dto.Note, error) {
 	return args.Get(0).(*dto.Note), args.Error(1)
 }
 
+func (m *NotesMock) List(ctx context.Context, id uuid.UUID) ([]*dto.Note, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*dto.Note), args.Error(1)
+}
+
+func (m *NotesMock) Update(ctx context.Context, body dto.NoteUpdateRequest) (*dto.Note, error) {
+	args := m.Called(body)
+	return args.Get(0).(*dto.Note), args.Error(1)
+}
+
+func (m *NotesMock) History(ctx context.Context, board, id uuid.UUID) ([]*dto.NoteRevision, error) {
+	args := m.Called(board, id)
+	return args.Get(0).([]*dto.NoteRevision), args.Error(1)
+}
+
+func (m *NotesMock) Restore(ctx context.Context, req dto.NoteRestoreRequest) (*dto.Note, error) {
+	args := m.Called(req)
+	return args.Get(0).(*dto.Note), args.Error(1)
+}
+
 type NotesTestSuite struct {
 	suite.Suite
 }
@@ -153,3 +173,160 @@ func (suite *NotesTestSuite) TestGetNote() {
 	}
 
 }
+
+func (suite *NotesTestSuite) TestGetNoteHistory() {
+	tests := []struct {
+		name          string
+		isModerator   bool
+		columnVisible bool
+		expectedCode  int
+	}{
+		{
+			name:          "participant on visible note",
+			columnVisible: true,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "participant on hidden note",
+			columnVisible: false,
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:          "moderator on hidden note",
+			isModerator:   true,
+			columnVisible: false,
+			expectedCode:  http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			notesMock := new(NotesMock)
+			boardMock := new(BoardMock)
+			sessionMock := new(SessionsMock)
+			s.notes = notesMock
+			s.boards = boardMock
+			s.sessions = sessionMock
+
+			boardID, _ := uuid.NewRandom()
+			userID, _ := uuid.NewRandom()
+			authorID, _ := uuid.NewRandom()
+			columnID, _ := uuid.NewRandom()
+			noteID, _ := uuid.NewRandom()
+
+			sessionMock.On("ModeratorSessionExists", boardID, userID).Return(tt.isModerator, nil)
+			if !tt.isModerator {
+				boardMock.On("Get", boardID).Return(&dto.Board{ID: boardID, ShowNotesOfOtherUsers: true}, nil)
+				boardMock.On("ListColumns", boardID).Return([]*dto.Column{{ID: columnID, Visible: tt.columnVisible}}, nil)
+				notesMock.On("Get", noteID).Return(&dto.Note{ID: noteID, Author: authorID, Position: dto.NotePosition{Column: columnID}}, nil)
+			}
+			if tt.expectedCode == http.StatusOK {
+				notesMock.On("History", boardID, noteID).Return([]*dto.NoteRevision{{Editor: &authorID, Text: "foo"}}, nil)
+			}
+
+			req := NewTestRequestBuilder("GET", "/", nil).
+				AddToContext("Board", boardID).
+				AddToContext("User", userID).
+				AddToContext("Note", noteID)
+			rr := httptest.NewRecorder()
+
+			s.getNoteHistory(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			notesMock.AssertExpectations(suite.T())
+			boardMock.AssertExpectations(suite.T())
+			sessionMock.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *NotesTestSuite) TestRestoreNote() {
+	tests := []struct {
+		name         string
+		expectedCode int
+		err          error
+	}{
+		{
+			name:         "all ok",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "not permitted",
+			expectedCode: http.StatusForbidden,
+			err:          common.ForbiddenError(errors.New("only the author or moderators may restore a note")),
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			mock := new(NotesMock)
+			s.notes = mock
+
+			boardId, _ := uuid.NewRandom()
+			userId, _ := uuid.NewRandom()
+			noteId, _ := uuid.NewRandom()
+			revisionId, _ := uuid.NewRandom()
+
+			mock.On("Restore", dto.NoteRestoreRequest{
+				Revision: &revisionId,
+				Note:     noteId,
+				Board:    boardId,
+				User:     userId,
+			}).Return(&dto.Note{ID: noteId}, tt.err)
+
+			req := NewTestRequestBuilder("POST", "/", strings.NewReader(fmt.Sprintf(`{"revision": "%s"}`, revisionId))).
+				AddToContext("Board", boardId).
+				AddToContext("User", userId).
+				AddToContext("Note", noteId)
+			rr := httptest.NewRecorder()
+
+			s.restoreNote(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			mock.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *NotesTestSuite) TestRestoreDeletedNote() {
+	tests := []struct {
+		name         string
+		expectedCode int
+		err          error
+	}{
+		{
+			name:         "all ok",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "column deleted",
+			expectedCode: http.StatusConflict,
+			err:          common.ConflictError(errors.New("the column of the note has been deleted")),
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			mock := new(NotesMock)
+			s.notes = mock
+
+			boardId, _ := uuid.NewRandom()
+			userId, _ := uuid.NewRandom()
+			noteId, _ := uuid.NewRandom()
+
+			mock.On("Restore", dto.NoteRestoreRequest{
+				Note:  noteId,
+				Board: boardId,
+				User:  userId,
+			}).Return(&dto.Note{ID: noteId}, tt.err)
+
+			req := NewTestRequestBuilder("POST", "/", nil).
+				AddToContext("Board", boardId).
+				AddToContext("User", userId).
+				AddToContext("Note", noteId)
+			rr := httptest.NewRecorder()
+
+			s.restoreNote(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			mock.AssertExpectations(suite.T())
+		})
+	}
+}
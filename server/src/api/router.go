@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-chi/cors"
@@ -31,16 +32,21 @@ type Server struct {
 	users          services.Users
 	notes          services.Notes
 	reactions      services.Reactions
+	comments       services.Comments
 	sessions       services.BoardSessions
 	health         services.Health
 	feedback       services.Feedback
 	assignments    services.Assignments
 	boardReactions services.BoardReactions
+	templates      services.Templates
+	actionItems    services.ActionItems
+	audit          services.Audit
 
 	upgrader websocket.Upgrader
 
 	// map of boardSubscriptions with maps of users with connections
 	boardSubscriptions               map[uuid.UUID]*BoardSubscription
+	boardSubscriptionsLock           sync.Mutex
 	boardSessionRequestSubscriptions map[uuid.UUID]*BoardSessionRequestSubscription
 }
 
@@ -53,11 +59,15 @@ func New(
 	users services.Users,
 	notes services.Notes,
 	reactions services.Reactions,
+	comments services.Comments,
 	sessions services.BoardSessions,
 	health services.Health,
 	feedback services.Feedback,
 	assignments services.Assignments,
 	boardReactions services.BoardReactions,
+	templates services.Templates,
+	actionItems services.ActionItems,
+	audit services.Audit,
 	verbose bool,
 	checkOrigin bool,
 ) chi.Router {
@@ -96,11 +106,15 @@ func New(
 		users:                            users,
 		notes:                            notes,
 		reactions:                        reactions,
+		comments:                         comments,
 		sessions:                         sessions,
 		health:                           health,
 		feedback:                         feedback,
 		assignments:                      assignments,
 		boardReactions:                   boardReactions,
+		templates:                        templates,
+		actionItems:                      actionItems,
+		audit:                            audit,
 	}
 
 	// initialize websocket upgrader with origin check depending on options
@@ -154,14 +168,19 @@ func (s *Server) protectedRoutes(r chi.Router) {
 		r.Use(auth.AuthContext)
 
 		r.Post("/boards", s.createBoard)
+		r.Post("/boards/import", s.importBoard)
 
 		r.Route("/boards/{id}", func(r chi.Router) {
 			r.With(s.BoardParticipantContext).Get("/", s.getBoard)
 			r.With(s.BoardParticipantContext).Get("/export", s.exportBoard)
-			r.With(s.BoardParticipantContext).Post("/timer", s.setTimer)
-			r.With(s.BoardParticipantContext).Delete("/timer", s.deleteTimer)
+			r.With(s.BoardParticipantContext, s.BoardWritableContext).Post("/timer", s.setTimer)
+			r.With(s.BoardParticipantContext, s.BoardWritableContext).Delete("/timer", s.deleteTimer)
+			r.With(s.BoardParticipantContext, s.BoardWritableContext).Post("/timer/pause", s.pauseTimer)
+			r.With(s.BoardParticipantContext, s.BoardWritableContext).Post("/timer/resume", s.resumeTimer)
 			r.With(s.BoardModeratorContext).Put("/", s.updateBoard)
 			r.With(s.BoardModeratorContext).Delete("/", s.deleteBoard)
+			r.With(s.BoardModeratorContext).Post("/duplicate", s.duplicateBoard)
+			r.With(s.BoardModeratorContext).Get("/audit", s.getAuditLog)
 
 			s.initBoardSessionRequestResources(r)
 			s.initBoardSessionResources(r)
@@ -172,20 +191,34 @@ func (s *Server) protectedRoutes(r chi.Router) {
 			s.initVoteResources(r)
 			s.initAssignmentResources(r)
 			s.initBoardReactionResources(r)
+			s.initActionItemResources(r)
+			s.initPhaseResources(r)
 		})
 
 		r.Route("/user", func(r chi.Router) {
 			r.Get("/", s.getUser)
 			r.Put("/", s.updateUser)
+			r.Get("/boards", s.getUserBoards)
+
+			r.Route("/action-items", func(r chi.Router) {
+				r.Get("/", s.getUserActionItems)
+				r.Route("/{actionItem}", func(r chi.Router) {
+					r.Use(s.ActionItemContext)
+					r.Get("/", s.getActionItem)
+					r.Put("/", s.updateActionItem)
+				})
+			})
 		})
+
+		s.initTemplateResources(r)
 	})
 }
 
 func (s *Server) initVoteResources(r chi.Router) {
 	r.Route("/votes", func(r chi.Router) {
 		r.Use(s.BoardParticipantContext)
-		r.Post("/", s.addVote)
-		r.Delete("/", s.removeVote)
+		r.With(s.BoardWritableContext).Post("/", s.addVote)
+		r.With(s.BoardWritableContext).Delete("/", s.removeVote)
 		r.Get("/", s.getVotes)
 	})
 }
@@ -194,13 +227,14 @@ func (s *Server) initVotingResources(r chi.Router) {
 	r.Route("/votings", func(r chi.Router) {
 		r.With(s.BoardParticipantContext).Get("/", s.getVotings)
 
-		r.With(s.BoardModeratorContext).Post("/", s.createVoting)
-		r.With(s.BoardModeratorContext).Put("/", s.updateVoting)
+		r.With(s.BoardModeratorContext, s.BoardWritableContext).Post("/", s.createVoting)
+		r.With(s.BoardModeratorContext, s.BoardWritableContext).Put("/", s.updateVoting)
 
 		r.Route("/{voting}", func(r chi.Router) {
 			r.Use(s.VotingContext)
 			r.With(s.BoardParticipantContext).Get("/", s.getVoting)
-			r.With(s.BoardModeratorContext).Put("/", s.updateVoting)
+			r.With(s.BoardParticipantContext).Get("/results", s.getVotingResults)
+			r.With(s.BoardModeratorContext, s.BoardWritableContext).Put("/", s.updateVoting)
 		})
 	})
 }
@@ -247,16 +281,18 @@ func (s *Server) initColumnResources(r chi.Router) {
 	r.Route("/columns", func(r chi.Router) {
 		r.With(s.BoardParticipantContext).Get("/", s.getColumns)
 
-		r.With(s.BoardModeratorContext).Post("/", s.createColumn)
+		r.With(s.BoardModeratorContext, s.BoardWritableContext).Post("/", s.createColumn)
 
 		r.Route("/{column}", func(r chi.Router) {
 			r.Use(s.ColumnContext)
 
 			r.With(s.BoardParticipantContext).Get("/", s.getColumn)
 
-			r.With(s.BoardModeratorContext).Put("/", s.updateColumn)
+			r.With(s.BoardModeratorContext, s.BoardWritableContext).Put("/", s.updateColumn)
+
+			r.With(s.BoardModeratorContext, s.BoardWritableContext).Delete("/", s.deleteColumn)
 
-			r.With(s.BoardModeratorContext).Delete("/", s.deleteColumn)
+			r.With(s.BoardModeratorContext, s.BoardWritableContext).Post("/restore", s.restoreColumn)
 		})
 	})
 }
@@ -266,15 +302,29 @@ func (s *Server) initNoteResources(r chi.Router) {
 		r.Use(s.BoardParticipantContext)
 
 		r.Get("/", s.getNotes)
-		r.Post("/", s.createNote)
+		r.With(s.BoardWritableContext).Post("/", s.createNote)
 
 		r.Route("/{note}", func(r chi.Router) {
 			r.Use(s.NoteContext)
 
 			r.Get("/", s.getNote)
-			r.Put("/", s.updateNote)
+			r.With(s.BoardWritableContext).Put("/", s.updateNote)
+			r.Get("/history", s.getNoteHistory)
+			r.With(s.BoardWritableContext).Post("/restore", s.restoreNote)
+
+			r.With(s.BoardWritableContext).Delete("/", s.deleteNote)
+
+			r.Route("/comments", func(r chi.Router) {
+				r.Get("/", s.getComments)
+				r.With(s.BoardWritableContext).Post("/", s.createComment)
+
+				r.Route("/{comment}", func(r chi.Router) {
+					r.Use(s.CommentContext)
 
-			r.Delete("/", s.deleteNote)
+					r.With(s.BoardWritableContext).Put("/", s.updateComment)
+					r.With(s.BoardWritableContext).Delete("/", s.deleteComment)
+				})
+			})
 		})
 	})
 }
@@ -284,14 +334,14 @@ func (s *Server) initReactionResources(r chi.Router) {
 		r.Use(s.BoardParticipantContext)
 
 		r.Get("/", s.getReactions)
-		r.Post("/", s.createReaction)
+		r.With(s.BoardWritableContext).Post("/", s.createReaction)
 
 		r.Route("/{reaction}", func(r chi.Router) {
 			r.Use(s.ReactionContext)
 
 			r.Get("/", s.getReaction)
-			r.Delete("/", s.removeReaction)
-			r.Put("/", s.updateReaction)
+			r.With(s.BoardWritableContext).Delete("/", s.removeReaction)
+			r.With(s.BoardWritableContext).Put("/", s.updateReaction)
 		})
 	})
 }
@@ -300,10 +350,10 @@ func (s *Server) initAssignmentResources(r chi.Router) {
 	r.Route("/assignments", func(r chi.Router) {
 		r.Use(s.BoardParticipantContext)
 
-		r.Post("/", s.createAssignment)
+		r.With(s.BoardWritableContext).Post("/", s.createAssignment)
 		r.Route("/{assignment}", func(r chi.Router) {
 			r.Use(s.AssignmentContext)
-			r.Delete("/", s.deleteAssignment)
+			r.With(s.BoardWritableContext).Delete("/", s.deleteAssignment)
 		})
 	})
 }
@@ -312,6 +362,45 @@ func (s *Server) initBoardReactionResources(r chi.Router) {
 	r.Route("/board-reactions", func(r chi.Router) {
 		r.Use(s.BoardParticipantContext)
 
-		r.Post("/", s.createBoardReaction)
+		r.With(s.BoardWritableContext).Post("/", s.createBoardReaction)
+	})
+}
+
+func (s *Server) initPhaseResources(r chi.Router) {
+	r.Route("/phases", func(r chi.Router) {
+		r.With(s.BoardParticipantContext).Get("/", s.getPhases)
+		r.With(s.BoardModeratorContext, s.BoardWritableContext).Put("/", s.setPhases)
+	})
+}
+
+func (s *Server) initActionItemResources(r chi.Router) {
+	r.Route("/action-items", func(r chi.Router) {
+		r.Use(s.BoardParticipantContext)
+
+		r.Get("/", s.getActionItems)
+		r.Post("/", s.createActionItem)
+
+		r.Route("/{actionItem}", func(r chi.Router) {
+			r.Use(s.ActionItemContext)
+
+			r.Get("/", s.getActionItem)
+			r.Put("/", s.updateActionItem)
+			r.Delete("/", s.deleteActionItem)
+		})
+	})
+}
+
+func (s *Server) initTemplateResources(r chi.Router) {
+	r.Route("/templates", func(r chi.Router) {
+		r.Get("/", s.getTemplates)
+		r.Post("/", s.createTemplate)
+
+		r.Route("/{template}", func(r chi.Router) {
+			r.Use(s.TemplateContext)
+
+			r.Get("/", s.getTemplate)
+			r.Put("/", s.updateTemplate)
+			r.Delete("/", s.deleteTemplate)
+		})
 	})
 }
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/logger"
+)
+
+// createTemplate creates a new board template
+func (s *Server) createTemplate(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromRequest(r)
+	user := r.Context().Value("User").(uuid.UUID)
+
+	var body dto.TemplateCreateRequest
+	if err := render.Decode(r, &body); err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	if body.Board != nil {
+		// only moderators may capture the configuration of a board
+		isModerator, err := s.sessions.ModeratorSessionExists(r.Context(), *body.Board, user)
+		if err != nil {
+			log.Errorw("unable to verify board session", "err", err)
+			common.Throw(w, r, common.InternalServerError)
+			return
+		}
+		if !isModerator {
+			common.Throw(w, r, common.ForbiddenError(errors.New("not allowed to create a template from this board")))
+			return
+		}
+	}
+
+	body.Creator = user
+	template, err := s.templates.Create(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	if s.basePath == "/" {
+		w.Header().Set("Location", fmt.Sprintf("%s://%s/templates/%s", common.GetProtocol(r), r.Host, template.ID))
+	} else {
+		w.Header().Set("Location", fmt.Sprintf("%s://%s%s/templates/%s", common.GetProtocol(r), r.Host, s.basePath, template.ID))
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, template)
+}
+
+// getTemplates get all templates of the current user
+func (s *Server) getTemplates(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("User").(uuid.UUID)
+
+	templates, err := s.templates.List(r.Context(), user)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, templates)
+}
+
+// getTemplate get a template
+func (s *Server) getTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value("Template").(uuid.UUID)
+
+	template, err := s.templates.Get(r.Context(), id)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, template)
+}
+
+// updateTemplate updates a template
+func (s *Server) updateTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value("Template").(uuid.UUID)
+
+	var body dto.TemplateUpdateRequest
+	if err := render.Decode(r, &body); err != nil {
+		common.Throw(w, r, common.BadRequestError(err))
+		return
+	}
+
+	body.ID = id
+	template, err := s.templates.Update(r.Context(), body)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, template)
+}
+
+// deleteTemplate deletes a template
+func (s *Server) deleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value("Template").(uuid.UUID)
+
+	if err := s.templates.Delete(r.Context(), id); err != nil {
+		common.Throw(w, r, common.InternalServerError)
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
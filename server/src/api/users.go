@@ -1,12 +1,21 @@
 package api
 
 import (
+	"errors"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
 	"net/http"
 	"scrumlr.io/server/common"
 	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
 	"scrumlr.io/server/logger"
+	"strconv"
+)
+
+const (
+	defaultUserBoardsLimit = 20
+	maxUserBoardsLimit     = 100
 )
 
 // getUser get a user
@@ -46,3 +55,44 @@ func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusOK)
 	render.Respond(w, r, updatedUser)
 }
+
+// getUserBoards get the boards the current user participated in
+func (s *Server) getUserBoards(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("User").(uuid.UUID)
+
+	f := filter.UserBoardFilter{User: user, Limit: defaultUserBoardsLimit}
+	query := r.URL.Query()
+	if role := query.Get("role"); role != "" {
+		var sessionRole types.SessionRole
+		if err := sessionRole.UnmarshalJSON([]byte(strconv.Quote(role))); err != nil {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid role")))
+			return
+		}
+		f.Role = &sessionRole
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > maxUserBoardsLimit {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid limit")))
+			return
+		}
+		f.Limit = value
+	}
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			common.Throw(w, r, common.BadRequestError(errors.New("invalid offset")))
+			return
+		}
+		f.Offset = value
+	}
+
+	boards, err := s.boards.ListForUser(r.Context(), f)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, boards)
+}
@@ -88,3 +88,18 @@ func (s *Server) getVotings(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusOK)
 	render.Respond(w, r, votings)
 }
+
+// getVotingResults get the report on the results of a closed voting session
+func (s *Server) getVotingResults(w http.ResponseWriter, r *http.Request) {
+	board := r.Context().Value("Board").(uuid.UUID)
+	id := r.Context().Value("Voting").(uuid.UUID)
+
+	report, err := s.votings.Results(r.Context(), board, id)
+	if err != nil {
+		common.Throw(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, report)
+}
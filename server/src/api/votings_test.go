@@ -48,6 +48,11 @@ func (m *VotingMock) Update(ctx context.Context, body dto.VotingUpdateRequest) (
 	return args.Get(0).(*dto.Voting), args.Error(1)
 }
 
+func (m *VotingMock) Results(ctx context.Context, boardID, id uuid.UUID) (*dto.VotingReport, error) {
+	args := m.Called(boardID, id)
+	return args.Get(0).(*dto.VotingReport), args.Error(1)
+}
+
 func (m *VotingMock) Create(ctx context.Context, body dto.VotingCreateRequest) (*dto.Voting, error) {
 	args := m.Called(body)
 	return args.Get(0).(*dto.Voting), args.Error(1)
@@ -189,3 +194,46 @@ func (suite *VotingTestSuite) TestGetVoting() {
 	mock.AssertExpectations(suite.T())
 
 }
+
+func (suite *VotingTestSuite) TestGetVotingResults() {
+	tests := []struct {
+		name         string
+		expectedCode int
+		err          error
+	}{
+		{
+			name:         "all ok",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "voting not closed",
+			expectedCode: http.StatusConflict,
+			err:          common.ConflictError(errors.New("results are only available for closed votings")),
+		},
+		{
+			name:         "voting not found",
+			expectedCode: http.StatusNotFound,
+			err:          common.NotFoundError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			s := new(Server)
+			mock := new(VotingMock)
+			s.votings = mock
+			boardId, _ := uuid.NewRandom()
+			votingId, _ := uuid.NewRandom()
+
+			mock.On("Results", boardId, votingId).Return(&dto.VotingReport{Voting: votingId}, tt.err)
+
+			req := NewTestRequestBuilder("GET", "/", nil).
+				AddToContext("Board", boardId).
+				AddToContext("Voting", votingId)
+			rr := httptest.NewRecorder()
+
+			s.getVotingResults(rr, req.Request())
+			suite.Equal(tt.expectedCode, rr.Result().StatusCode)
+			mock.AssertExpectations(suite.T())
+		})
+	}
+}
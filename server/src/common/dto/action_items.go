@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+)
+
+// ActionItem is the response for all action item requests.
+type ActionItem struct {
+	ID uuid.UUID `json:"id"`
+
+	// The user responsible for this action item.
+	Owner uuid.UUID `json:"owner"`
+
+	// The board this action item originates from.
+	//
+	// Will be null if the board was deleted.
+	Board uuid.NullUUID `json:"board"`
+
+	// The note this action item originates from.
+	//
+	// Will be null if the note was deleted.
+	Note uuid.NullUUID `json:"note"`
+
+	// The description of the action item.
+	Text string `json:"text"`
+
+	// The date until the action item should be done.
+	DueDate *time.Time `json:"dueDate,omitempty"`
+
+	// The progress of the action item.
+	Status types.ActionItemStatus `json:"status"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (a *ActionItem) From(actionItem database.ActionItem) *ActionItem {
+	a.ID = actionItem.ID
+	a.Owner = actionItem.Owner
+	a.Board = actionItem.Board
+	a.Note = actionItem.Note
+	a.Text = actionItem.Text
+	a.DueDate = actionItem.DueDate
+	a.Status = actionItem.Status
+	a.CreatedAt = actionItem.CreatedAt
+	return a
+}
+
+func (*ActionItem) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func ActionItems(actionItems []database.ActionItem) []*ActionItem {
+	if actionItems == nil {
+		return nil
+	}
+
+	list := make([]*ActionItem, len(actionItems))
+	for index, actionItem := range actionItems {
+		list[index] = new(ActionItem).From(actionItem)
+	}
+	return list
+}
+
+// ActionItemCreateRequest represents the request to create a new action item.
+type ActionItemCreateRequest struct {
+
+	// The user responsible for this action item, which must be a participant of the board.
+	//
+	// Defaults to the user creating the action item.
+	Owner *uuid.UUID `json:"owner"`
+
+	// The note this action item originates from.
+	Note *uuid.UUID `json:"note"`
+
+	// The description of the action item.
+	//
+	// Defaults to the text of the note, if a note is set.
+	Text string `json:"text"`
+
+	// The date until the action item should be done.
+	DueDate *time.Time `json:"dueDate"`
+
+	Board uuid.UUID `json:"-"`
+	User  uuid.UUID `json:"-"`
+}
+
+// ActionItemUpdateRequest represents the request to update an action item.
+type ActionItemUpdateRequest struct {
+
+	// The user responsible for this action item.
+	Owner *uuid.UUID `json:"owner"`
+
+	// The description of the action item.
+	Text *string `json:"text"`
+
+	// The date until the action item should be done.
+	DueDate *time.Time `json:"dueDate"`
+
+	// The progress of the action item.
+	Status *types.ActionItemStatus `json:"status"`
+
+	ID uuid.UUID `json:"-"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+)
+
+// AuditEntry is the response for all audit log requests.
+type AuditEntry struct {
+	ID uuid.UUID `json:"id"`
+
+	// The user who made the change.
+	//
+	// Will be null if the change was made by the system or the user was deleted.
+	Actor uuid.NullUUID `json:"actor"`
+
+	// The type of the changed entity.
+	EntityType types.AuditEntity `json:"entityType"`
+
+	// The id of the changed entity.
+	Entity uuid.UUID `json:"entity"`
+
+	// The kind of change, e.g. 'UPDATED', 'DELETED' or the new status of a voting.
+	Action string `json:"action"`
+
+	// The state of the entity after the change, if available.
+	Payload json.RawMessage `json:"payload,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (a *AuditEntry) From(entry database.AuditEntry) *AuditEntry {
+	a.ID = entry.ID
+	a.Actor = entry.Actor
+	a.EntityType = entry.EntityType
+	a.Entity = entry.Entity
+	a.Action = entry.Action
+	a.Payload = entry.Payload
+	a.CreatedAt = entry.CreatedAt
+	return a
+}
+
+func (*AuditEntry) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func AuditEntries(entries []database.AuditEntry) []*AuditEntry {
+	if entries == nil {
+		return nil
+	}
+
+	list := make([]*AuditEntry, len(entries))
+	for index, entry := range entries {
+		list[index] = new(AuditEntry).From(entry)
+	}
+	return list
+}
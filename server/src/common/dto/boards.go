@@ -32,12 +32,24 @@ type Board struct {
 	TimerStart *time.Time `json:"timerStart,omitempty"`
 	TimerEnd   *time.Time `json:"timerEnd,omitempty"`
 
+	// The remaining milliseconds of the timer, if the timer is paused.
+	TimerRemaining *int64 `json:"timerRemaining,omitempty"`
+
+	// The timer durations in minutes offered to the moderators of the board.
+	TimerPresets []int `json:"timerPresets"`
+
 	// The id of a note to share with other users.
 	// FIXME omitempty works only with nil in combination with pointers
 	SharedNote uuid.NullUUID `json:"sharedNote,omitempty"`
 
 	ShowVoting uuid.NullUUID `json:"showVoting,omitempty"`
 
+	// Archived boards are read-only.
+	Archived bool `json:"archived"`
+
+	// The current phase of the agenda of the board.
+	Phase uuid.NullUUID `json:"phase,omitempty"`
+
 	Passphrase *string `json:"-"`
 	Salt       *string `json:"-"`
 }
@@ -54,6 +66,13 @@ func (b *Board) From(board database.Board) *Board {
 	b.ShowVoting = board.ShowVoting
 	b.TimerStart = board.TimerStart
 	b.TimerEnd = board.TimerEnd
+	b.TimerRemaining = board.TimerRemaining
+	b.TimerPresets = board.TimerPresets
+	if b.TimerPresets == nil {
+		b.TimerPresets = []int{}
+	}
+	b.Archived = board.Archived
+	b.Phase = board.Phase
 	b.Passphrase = board.Passphrase
 	b.Salt = board.Salt
 	return b
@@ -76,11 +95,53 @@ type CreateBoardRequest struct {
 	// The columns to create for the board.
 	Columns []ColumnRequest `json:"columns"`
 
+	// The template to create the board from.
+	//
+	// If set, the columns and board settings of the template are used instead of the columns of this request.
+	Template *uuid.UUID `json:"templateId"`
+
+	Owner uuid.UUID `json:"-"`
+}
+
+// BoardExport is the JSON document of an exported board.
+type BoardExport struct {
+	Board        *Board          `json:"board"`
+	Participants []*BoardSession `json:"participants"`
+	Columns      []*Column       `json:"columns"`
+	Notes        []*Note         `json:"notes"`
+	Votings      []*Voting       `json:"votings"`
+}
+
+// ImportBoardRequest represents the request to create a new board from a board export.
+type ImportBoardRequest struct {
+	BoardExport
+
+	// The passphrase of the imported board, which must be set if the access policy of the
+	// export is 'BY_PASSPHRASE'. Otherwise, the board will be imported with the access policy 'BY_INVITE'.
+	Passphrase *string `json:"passphrase"`
+
+	Owner uuid.UUID `json:"-"`
+}
+
+// DuplicateBoardRequest represents the request to create a copy of a board.
+//
+// The settings and columns of the board are always copied.
+type DuplicateBoardRequest struct {
+	// The name of the new board. The name of the original board is used, if not set.
+	Name *string `json:"name"`
+
+	// Set whether the notes of the board should be copied, including their stacks and ranks.
+	Notes bool `json:"notes"`
+
+	// Set whether the assignments of the notes should be copied. This requires the notes to be copied.
+	Assignments bool `json:"assignments"`
+
+	ID    uuid.UUID `json:"-"`
 	Owner uuid.UUID `json:"-"`
 }
 
 type SetTimerRequest struct {
-	Minutes uint8 `json:"minutes"`
+	Minutes uint16 `json:"minutes"`
 }
 
 // BoardUpdateRequest represents the request to update a board.
@@ -111,10 +172,21 @@ type BoardUpdateRequest struct {
 	// Set the timer end.
 	TimerEnd *time.Time `json:"timerEnd"`
 
+	// Set the timer durations in minutes offered to the moderators of the board.
+	TimerPresets *[]int `json:"timerPresets"`
+
 	// Set the note id of the note to share with other users.
 	SharedNote uuid.NullUUID `json:"sharedNote"`
 
 	ShowVoting uuid.NullUUID `json:"showVoting"`
 
+	// Set whether the board is archived and therefore read-only.
+	Archived *bool `json:"archived"`
+
+	// Activate a phase of the agenda, which applies the settings of the phase to the board.
+	//
+	// Settings of the phase take precedence over the settings of this request.
+	Phase *uuid.UUID `json:"phase"`
+
 	ID uuid.UUID `json:"-"`
 }
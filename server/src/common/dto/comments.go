@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database"
+)
+
+// Comment is the response for all comment requests.
+type Comment struct {
+	// The id of the comment.
+	ID uuid.UUID `json:"id"`
+
+	// The note the comment refers to.
+	Note uuid.UUID `json:"note"`
+
+	// The author of the comment.
+	Author uuid.UUID `json:"author"`
+
+	// The text of the comment.
+	Text string `json:"text"`
+
+	// Flag indicates whether the text of the comment has been changed after its creation.
+	Edited bool `json:"edited"`
+
+	// The creation time of the comment.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (c *Comment) From(comment database.Comment) *Comment {
+	c.ID = comment.ID
+	c.Note = comment.Note
+	c.Author = comment.Author
+	c.Text = comment.Text
+	c.Edited = comment.Edited
+	c.CreatedAt = comment.CreatedAt
+	return c
+}
+
+func (*Comment) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func Comments(comments []database.Comment) []*Comment {
+	if comments == nil {
+		return nil
+	}
+
+	list := make([]*Comment, len(comments))
+	for index, comment := range comments {
+		list[index] = new(Comment).From(comment)
+	}
+	return list
+}
+
+// CommentCreateRequest represents the request to create a new comment on a note.
+type CommentCreateRequest struct {
+	// The text of the comment.
+	Text string `json:"text"`
+
+	Board uuid.UUID `json:"-"`
+	Note  uuid.UUID `json:"-"`
+	User  uuid.UUID `json:"-"`
+}
+
+// CommentUpdateRequest represents the request to update the text of a comment.
+type CommentUpdateRequest struct {
+	// The text of the comment.
+	Text string `json:"text"`
+
+	ID    uuid.UUID `json:"-"`
+	Board uuid.UUID `json:"-"`
+	Note  uuid.UUID `json:"-"`
+	User  uuid.UUID `json:"-"`
+}
@@ -2,9 +2,11 @@ package dto
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
 )
 
 type NotePosition struct {
@@ -30,6 +32,15 @@ type Note struct {
 	// The text of the note.
 	Text string `json:"text"`
 
+	// The content type of the note, which determines how the text is interpreted.
+	ContentType types.NoteContentType `json:"contentType,omitempty"`
+
+	// The previews of the links within the text of the note.
+	Links []types.LinkPreview `json:"links,omitempty"`
+
+	// The checklist items of a checklist note.
+	Checklist []types.ChecklistItem `json:"checklist,omitempty"`
+
 	// The position of the note.
 	Position NotePosition `json:"position"`
 }
@@ -38,6 +49,9 @@ func (n *Note) From(note database.Note) *Note {
 	n.ID = note.ID
 	n.Author = note.Author
 	n.Text = note.Text
+	n.ContentType = note.ContentType
+	n.Links = note.Links
+	n.Checklist = note.Checklist
 	n.Position = NotePosition{
 		Column: note.Column,
 		Stack:  note.Stack,
@@ -70,6 +84,15 @@ type NoteCreateRequest struct {
 	// The text of the note.
 	Text string `json:"text"`
 
+	// The content type of the note. Defaults to plain text.
+	ContentType types.NoteContentType `json:"contentType"`
+
+	// The previews of the links within the text, as unfurled by the client.
+	Links []types.LinkPreview `json:"links"`
+
+	// The checklist items of a checklist note.
+	Checklist []types.ChecklistItem `json:"checklist"`
+
 	Board uuid.UUID `json:"-"`
 	User  uuid.UUID `json:"-"`
 }
@@ -80,6 +103,15 @@ type NoteUpdateRequest struct {
 	// The text of the note.
 	Text *string `json:"text"`
 
+	// The content type of the note.
+	ContentType *types.NoteContentType `json:"contentType"`
+
+	// The previews of the links within the text, as unfurled by the client.
+	Links []types.LinkPreview `json:"links"`
+
+	// The checklist items of a checklist note, which replace the previous items.
+	Checklist *[]types.ChecklistItem `json:"checklist"`
+
 	// The position of the note
 	Position *NotePosition `json:"position"`
 
@@ -93,3 +125,77 @@ type NoteDeleteRequest struct {
 	// Delete note or the complete stack.
 	DeleteStack bool `json:"deleteStack"`
 }
+
+// NoteRevision is the response for the history of a note. A revision is the state of the note before it was changed by
+// the editor.
+type NoteRevision struct {
+	ID uuid.UUID `json:"id"`
+
+	// The time at which the note was changed.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// The user who changed the note, if still existing.
+	Editor *uuid.UUID `json:"editor"`
+
+	// The text of the note before the change.
+	Text string `json:"text"`
+
+	// The content type of the note before the change.
+	ContentType types.NoteContentType `json:"contentType,omitempty"`
+
+	// The link previews of the note before the change.
+	Links []types.LinkPreview `json:"links,omitempty"`
+
+	// The checklist of the note before the change.
+	Checklist []types.ChecklistItem `json:"checklist,omitempty"`
+
+	// The position of the note before the change.
+	Position NotePosition `json:"position"`
+}
+
+func (r *NoteRevision) From(revision database.NoteRevision) *NoteRevision {
+	r.ID = revision.ID
+	r.CreatedAt = revision.CreatedAt
+	r.Editor = nil
+	if revision.Editor.Valid {
+		r.Editor = &revision.Editor.UUID
+	}
+	r.Text = revision.Text
+	r.ContentType = revision.ContentType
+	r.Links = revision.Links
+	r.Checklist = revision.Checklist
+	r.Position = NotePosition{
+		Column: revision.Column,
+		Stack:  revision.Stack,
+		Rank:   revision.Rank,
+	}
+	return r
+}
+
+func (*NoteRevision) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NoteRevisions(revisions []database.NoteRevision) []*NoteRevision {
+	if revisions == nil {
+		return nil
+	}
+
+	list := make([]*NoteRevision, len(revisions))
+	for index, revision := range revisions {
+		list[index] = new(NoteRevision).From(revision)
+	}
+	return list
+}
+
+// NoteRestoreRequest represents the request to restore the text of a previous revision of a note or to restore a
+// deleted note.
+type NoteRestoreRequest struct {
+
+	// The revision to restore. If not set, the deleted note is restored.
+	Revision *uuid.UUID `json:"revision"`
+
+	Note  uuid.UUID `json:"-"`
+	Board uuid.UUID `json:"-"`
+	User  uuid.UUID `json:"-"`
+}
@@ -0,0 +1,136 @@
+package dto
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database"
+)
+
+// PhaseVoting is the configuration of the voting opened by a phase.
+type PhaseVoting struct {
+
+	// The vote limit of the voting.
+	VoteLimit int `json:"voteLimit"`
+
+	// Set whether multiple votes on a single note are allowed.
+	AllowMultipleVotes bool `json:"allowMultipleVotes"`
+
+	// Set whether the votes of other participants are shown.
+	ShowVotesOfOthers bool `json:"showVotesOfOthers"`
+}
+
+// Phase is the response for all phase requests.
+//
+// Settings which are not set will be kept as they are, once the phase is activated.
+type Phase struct {
+	ID uuid.UUID `json:"id"`
+
+	// The phase name.
+	Name string `json:"name"`
+
+	// The position of the phase within the agenda.
+	Index int `json:"index"`
+
+	// The show authors setting applied by this phase.
+	ShowAuthors *bool `json:"showAuthors,omitempty"`
+
+	// The show notes setting applied by this phase.
+	ShowNotesOfOtherUsers *bool `json:"showNotesOfOtherUsers,omitempty"`
+
+	// The show note reactions setting applied by this phase.
+	ShowNoteReactions *bool `json:"showNoteReactions,omitempty"`
+
+	// The allow stacking setting applied by this phase.
+	AllowStacking *bool `json:"allowStacking,omitempty"`
+
+	// The columns visible during this phase. All other columns are hidden.
+	VisibleColumns *[]uuid.UUID `json:"visibleColumns,omitempty"`
+
+	// The voting opened by this phase.
+	Voting *PhaseVoting `json:"voting,omitempty"`
+
+	// The duration of the timer started by this phase in minutes.
+	TimerMinutes *int `json:"timerMinutes,omitempty"`
+}
+
+func (p *Phase) From(phase database.BoardPhase) *Phase {
+	p.ID = phase.ID
+	p.Name = phase.Name
+	p.Index = phase.Index
+	p.ShowAuthors = phase.ShowAuthors
+	p.ShowNotesOfOtherUsers = phase.ShowNotesOfOtherUsers
+	p.ShowNoteReactions = phase.ShowNoteReactions
+	p.AllowStacking = phase.AllowStacking
+	if phase.VisibleColumns != nil {
+		p.VisibleColumns = &phase.VisibleColumns
+	}
+	if phase.VoteLimit != nil {
+		p.Voting = &PhaseVoting{
+			VoteLimit:          *phase.VoteLimit,
+			AllowMultipleVotes: phase.AllowMultipleVotes,
+			ShowVotesOfOthers:  phase.ShowVotesOfOthers,
+		}
+	}
+	p.TimerMinutes = phase.TimerMinutes
+	return p
+}
+
+func (*Phase) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func Phases(phases []database.BoardPhase) []*Phase {
+	if phases == nil {
+		return nil
+	}
+
+	list := make([]*Phase, len(phases))
+	for index, phase := range phases {
+		list[index] = new(Phase).From(phase)
+	}
+	return list
+}
+
+// PhaseRequest represents a phase within the request to set the agenda of a board.
+type PhaseRequest struct {
+
+	// The phase name.
+	Name string `json:"name"`
+
+	// Set whether authors of notes should be shown to all users during this phase.
+	ShowAuthors *bool `json:"showAuthors"`
+
+	// Set whether notes of other users should be visible for everyone else during this phase.
+	ShowNotesOfOtherUsers *bool `json:"showNotesOfOtherUsers"`
+
+	// Set whether note reactions should be shown to all users during this phase.
+	ShowNoteReactions *bool `json:"showNoteReactions"`
+
+	// Set whether stacking should be allowed to all users during this phase.
+	AllowStacking *bool `json:"allowStacking"`
+
+	// The columns visible during this phase. If set, all other columns are hidden.
+	VisibleColumns *[]uuid.UUID `json:"visibleColumns"`
+
+	// The voting to open once this phase is activated.
+	Voting *PhaseVoting `json:"voting"`
+
+	// The duration of the timer to start once this phase is activated in minutes.
+	TimerMinutes *int `json:"timerMinutes"`
+}
+
+// PhasesRequest represents the request to set the agenda of a board.
+type PhasesRequest struct {
+
+	// The phases of the agenda in their order, which replace all previous phases.
+	Phases []PhaseRequest `json:"phases"`
+
+	Board uuid.UUID `json:"-"`
+}
+
+// PhaseChanged is the payload of the event, which announces the activation of a phase.
+type PhaseChanged struct {
+	Board *Board `json:"board"`
+	Phase *Phase `json:"phase"`
+}
@@ -0,0 +1,188 @@
+package dto
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+)
+
+// ColumnTemplate is the column configuration of a board template.
+type ColumnTemplate struct {
+
+	// The column template id.
+	ID uuid.UUID `json:"id"`
+
+	// The column name.
+	Name string `json:"name"`
+
+	// The column color.
+	Color types.Color `json:"color"`
+
+	// The column visibility.
+	Visible bool `json:"visible"`
+
+	// The column rank.
+	Index int `json:"index"`
+}
+
+func (c *ColumnTemplate) From(column database.ColumnTemplate) *ColumnTemplate {
+	c.ID = column.ID
+	c.Name = column.Name
+	c.Color = column.Color
+	c.Visible = column.Visible
+	c.Index = column.Index
+	return c
+}
+
+func ColumnTemplates(columns []database.ColumnTemplate) []*ColumnTemplate {
+	list := make([]*ColumnTemplate, len(columns))
+	for index, column := range columns {
+		list[index] = new(ColumnTemplate).From(column)
+	}
+	return list
+}
+
+// Template is the response for all board template requests.
+type Template struct {
+	ID uuid.UUID `json:"id"`
+
+	// The creator of the template.
+	Creator uuid.UUID `json:"creator"`
+
+	// The template name.
+	Name string `json:"name"`
+
+	// The template description.
+	Description *string `json:"description,omitempty"`
+
+	// The show authors setting of boards created from this template.
+	ShowAuthors bool `json:"showAuthors"`
+
+	// The show notes setting of boards created from this template.
+	ShowNotesOfOtherUsers bool `json:"showNotesOfOtherUsers"`
+
+	// The show note reactions setting of boards created from this template.
+	ShowNoteReactions bool `json:"showNoteReactions"`
+
+	// The allow stacking setting of boards created from this template.
+	AllowStacking bool `json:"allowStacking"`
+
+	// The columns of boards created from this template.
+	Columns []*ColumnTemplate `json:"columns"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (t *Template) From(template database.BoardTemplate, columns []database.ColumnTemplate) *Template {
+	t.ID = template.ID
+	t.Creator = template.Creator
+	t.Name = template.Name
+	t.Description = template.Description
+	t.ShowAuthors = template.ShowAuthors
+	t.ShowNotesOfOtherUsers = template.ShowNotesOfOtherUsers
+	t.ShowNoteReactions = template.ShowNoteReactions
+	t.AllowStacking = template.AllowStacking
+	t.CreatedAt = template.CreatedAt
+
+	templateColumns := []database.ColumnTemplate{}
+	for _, column := range columns {
+		if column.BoardTemplate == template.ID {
+			templateColumns = append(templateColumns, column)
+		}
+	}
+	t.Columns = ColumnTemplates(templateColumns)
+	return t
+}
+
+func (*Template) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func Templates(templates []database.BoardTemplate, columns []database.ColumnTemplate) []*Template {
+	if templates == nil {
+		return nil
+	}
+
+	list := make([]*Template, len(templates))
+	for index, template := range templates {
+		list[index] = new(Template).From(template, columns)
+	}
+	return list
+}
+
+// ColumnTemplateRequest represents a column within a template request.
+type ColumnTemplateRequest struct {
+
+	// The column name to set.
+	Name string `json:"name"`
+
+	// The column color to set.
+	Color types.Color `json:"color"`
+
+	// Sets whether this column should be visible to regular participants.
+	//
+	// The default value on creation is 'false'.
+	Visible *bool `json:"visible"`
+}
+
+// TemplateCreateRequest represents the request to create a new board template.
+type TemplateCreateRequest struct {
+
+	// The template name.
+	Name string `json:"name"`
+
+	// The template description.
+	Description *string `json:"description"`
+
+	// The board to capture the columns and settings from.
+	//
+	// If set, the columns and settings of this request will be ignored.
+	Board *uuid.UUID `json:"board"`
+
+	// Set whether authors of notes should be shown to all users.
+	ShowAuthors *bool `json:"showAuthors"`
+
+	// Set whether notes of other users should be visible for everyone else.
+	ShowNotesOfOtherUsers *bool `json:"showNotesOfOtherUsers"`
+
+	// Set whether note reactions should be shown to all users.
+	ShowNoteReactions *bool `json:"showNoteReactions"`
+
+	// Set whether stacking should be allowed to all users or only moderators.
+	AllowStacking *bool `json:"allowStacking"`
+
+	// The columns of the template in the order of their index.
+	Columns []ColumnTemplateRequest `json:"columns"`
+
+	Creator uuid.UUID `json:"-"`
+}
+
+// TemplateUpdateRequest represents the request to update a board template.
+type TemplateUpdateRequest struct {
+
+	// The template name.
+	Name *string `json:"name"`
+
+	// The template description.
+	Description *string `json:"description"`
+
+	// Set whether authors of notes should be shown to all users.
+	ShowAuthors *bool `json:"showAuthors"`
+
+	// Set whether notes of other users should be visible for everyone else.
+	ShowNotesOfOtherUsers *bool `json:"showNotesOfOtherUsers"`
+
+	// Set whether note reactions should be shown to all users.
+	ShowNoteReactions *bool `json:"showNoteReactions"`
+
+	// Set whether stacking should be allowed to all users or only moderators.
+	AllowStacking *bool `json:"allowStacking"`
+
+	// The columns replacing all columns of the template, if set.
+	Columns *[]ColumnTemplateRequest `json:"columns"`
+
+	ID uuid.UUID `json:"-"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+)
+
+// UserBoard is a board the user participated in.
+type UserBoard struct {
+	ID uuid.UUID `json:"id"`
+
+	// The board name
+	Name *string `json:"name,omitempty"`
+
+	// The access policy
+	AccessPolicy types.AccessPolicy `json:"accessPolicy"`
+
+	// The role of the user on this board.
+	Role types.SessionRole `json:"role"`
+
+	// The number of notes on this board.
+	NoteCount int `json:"noteCount"`
+
+	// The point in time the user joined this board.
+	JoinedAt time.Time `json:"joinedAt"`
+
+	// The point in time of the latest note or participant added to this board.
+	LastActivity time.Time `json:"lastActivity"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (b *UserBoard) From(board database.UserBoard) *UserBoard {
+	b.ID = board.ID
+	b.Name = board.Name
+	b.AccessPolicy = board.AccessPolicy
+	b.Role = board.Role
+	b.NoteCount = board.NoteCount
+	b.JoinedAt = board.JoinedAt
+	b.LastActivity = board.LastActivity
+	b.CreatedAt = board.CreatedAt
+	return b
+}
+
+// UserBoards is a page of the boards the user participated in.
+type UserBoards struct {
+	Boards []*UserBoard `json:"boards"`
+
+	// The total count of boards matching the request.
+	Total int `json:"total"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func (*UserBoards) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
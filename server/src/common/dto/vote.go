@@ -10,11 +10,19 @@ import (
 type Vote struct {
 	Voting uuid.UUID `json:"voting"`
 	Note   uuid.UUID `json:"note"`
+
+	// The points spent on the note in a weighted voting.
+	Weight int `json:"weight"`
+
+	// The rank of the note in a ranked voting.
+	Rank *int `json:"rank,omitempty"`
 }
 
 func (v *Vote) From(vote database.Vote) *Vote {
 	v.Voting = vote.Voting
 	v.Note = vote.Note
+	v.Weight = vote.Weight
+	v.Rank = vote.Rank
 	return v
 }
 
@@ -36,7 +44,14 @@ func Votes(votes []database.Vote) []*Vote {
 
 // VoteRequest represents the request to add or delete a vote.
 type VoteRequest struct {
-	Note  uuid.UUID `json:"note"`
+	Note uuid.UUID `json:"note"`
+
+	// The points to spend on the note, which may only be set for weighted votings. Defaults to 1.
+	Weight *int `json:"weight"`
+
+	// The rank of the note, which must be set for ranked votings only.
+	Rank *int `json:"rank"`
+
 	Board uuid.UUID `json:"-"`
 	User  uuid.UUID `json:"-"`
 }
@@ -0,0 +1,196 @@
+package dto
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+)
+
+// VotingReportNote is the result of a note within a closed voting, including the votes on all notes stacked on it.
+type VotingReportNote struct {
+
+	// The note at the top of the stack.
+	Note uuid.UUID `json:"note"`
+
+	// The column of the note.
+	Column uuid.UUID `json:"column"`
+
+	// The rank of the note, whereas notes with the same total share the same rank.
+	Rank int `json:"rank"`
+
+	// The points of the note and its stacked notes.
+	Total int `json:"total"`
+
+	// The points per user, if the votes of others are shown.
+	Users *[]VotingResultsPerUser `json:"userVotes,omitempty"`
+}
+
+// VotingReportColumn is the distribution of the points of a closed voting on a column.
+type VotingReportColumn struct {
+	Column uuid.UUID `json:"column"`
+
+	// The points of all notes in the column.
+	Total int `json:"total"`
+
+	// The number of notes in the column, which received at least one vote.
+	Notes int `json:"notes"`
+}
+
+// VotingReportTie is a group of notes sharing the same rank.
+type VotingReportTie struct {
+	Rank  int         `json:"rank"`
+	Total int         `json:"total"`
+	Notes []uuid.UUID `json:"notes"`
+}
+
+// VotingParticipation is the participation of the users of the board in a voting.
+type VotingParticipation struct {
+
+	// The number of users of the board.
+	Participants int `json:"participants"`
+
+	// The number of users, which have cast at least one vote.
+	Voters int `json:"voters"`
+
+	// The number of users, which have used their whole budget of votes.
+	UsedBudget int `json:"usedBudget"`
+
+	// The share of users, which have used their whole budget of votes.
+	Rate float64 `json:"rate"`
+}
+
+// VotingReport is the response for the results of a closed voting.
+type VotingReport struct {
+	Voting        uuid.UUID            `json:"voting"`
+	Total         int                  `json:"total"`
+	Notes         []VotingReportNote   `json:"notes"`
+	Columns       []VotingReportColumn `json:"columns"`
+	Ties          []VotingReportTie    `json:"ties"`
+	Participation VotingParticipation  `json:"participation"`
+}
+
+// From aggregates the votes of the voting per stack, as it is done to rank the notes once a voting is closed.
+func (r *VotingReport) From(voting database.Voting, votes []database.Vote, notes []database.Note, columns []database.Column, sessions []database.BoardSession) *VotingReport {
+	r.Voting = voting.ID
+	r.Total = 0
+	r.Notes = []VotingReportNote{}
+	r.Columns = []VotingReportColumn{}
+	r.Ties = []VotingReportTie{}
+
+	stackOf := map[uuid.UUID]database.Note{}
+	for _, note := range notes {
+		stackOf[note.ID] = note
+	}
+	for _, note := range notes {
+		if note.Stack.Valid {
+			if parent, ok := stackOf[note.Stack.UUID]; ok {
+				stackOf[note.ID] = parent
+			}
+		}
+	}
+
+	totalPerStack := map[uuid.UUID]int{}
+	pointsPerUser := map[uuid.UUID]map[uuid.UUID]int{}
+	spentPerUser := map[uuid.UUID]int{}
+	for _, vote := range votes {
+		if vote.Voting != voting.ID {
+			continue
+		}
+
+		spent := 1
+		if voting.Mode != types.VotingModeRanked && vote.Weight > 1 {
+			spent = vote.Weight
+		}
+		spentPerUser[vote.User] += spent
+
+		stack, ok := stackOf[vote.Note]
+		if !ok {
+			continue
+		}
+		points := votePoints(voting, vote)
+		r.Total += points
+		totalPerStack[stack.ID] += points
+		if _, ok := pointsPerUser[stack.ID]; !ok {
+			pointsPerUser[stack.ID] = map[uuid.UUID]int{}
+		}
+		pointsPerUser[stack.ID][vote.User] += points
+	}
+
+	for stack, total := range totalPerStack {
+		result := VotingReportNote{Note: stack, Column: stackOf[stack].Column, Total: total}
+		if voting.ShowVotesOfOthers && !voting.Anonymous {
+			users := []VotingResultsPerUser{}
+			for user, points := range pointsPerUser[stack] {
+				users = append(users, VotingResultsPerUser{ID: user, Total: points})
+			}
+			sort.Slice(users, func(i, j int) bool {
+				return users[i].Total > users[j].Total || (users[i].Total == users[j].Total && users[i].ID.String() < users[j].ID.String())
+			})
+			result.Users = &users
+		}
+		r.Notes = append(r.Notes, result)
+	}
+
+	// order by the total and keep the order of the notes on the board for equal totals
+	sort.Slice(r.Notes, func(i, j int) bool {
+		a, b := r.Notes[i], r.Notes[j]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		if stackOf[a.Note].Rank != stackOf[b.Note].Rank {
+			return stackOf[a.Note].Rank > stackOf[b.Note].Rank
+		}
+		return a.Note.String() < b.Note.String()
+	})
+	for index := range r.Notes {
+		if index > 0 && r.Notes[index].Total == r.Notes[index-1].Total {
+			r.Notes[index].Rank = r.Notes[index-1].Rank
+		} else {
+			r.Notes[index].Rank = index + 1
+		}
+	}
+
+	for index := 0; index < len(r.Notes); {
+		next := index + 1
+		for next < len(r.Notes) && r.Notes[next].Rank == r.Notes[index].Rank {
+			next++
+		}
+		if next-index > 1 {
+			tie := VotingReportTie{Rank: r.Notes[index].Rank, Total: r.Notes[index].Total, Notes: []uuid.UUID{}}
+			for _, note := range r.Notes[index:next] {
+				tie.Notes = append(tie.Notes, note.Note)
+			}
+			r.Ties = append(r.Ties, tie)
+		}
+		index = next
+	}
+
+	for _, column := range columns {
+		distribution := VotingReportColumn{Column: column.ID}
+		for _, note := range r.Notes {
+			if note.Column == column.ID {
+				distribution.Total += note.Total
+				distribution.Notes++
+			}
+		}
+		r.Columns = append(r.Columns, distribution)
+	}
+
+	r.Participation = VotingParticipation{Participants: len(sessions), Voters: len(spentPerUser)}
+	for _, spent := range spentPerUser {
+		if spent >= voting.VoteLimit {
+			r.Participation.UsedBudget++
+		}
+	}
+	if r.Participation.Participants > 0 {
+		r.Participation.Rate = float64(r.Participation.UsedBudget) / float64(r.Participation.Participants)
+	}
+	return r
+}
+
+func (*VotingReport) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+)
+
+func TestVotingReport(t *testing.T) {
+	wentWell, toImprove := uuid.New(), uuid.New()
+	jack, jay, john := uuid.New(), uuid.New(), uuid.New()
+
+	first := database.Note{ID: uuid.New(), Column: wentWell, Rank: 2}
+	stacked := database.Note{ID: uuid.New(), Column: wentWell, Stack: uuid.NullUUID{UUID: first.ID, Valid: true}}
+	second := database.Note{ID: uuid.New(), Column: toImprove, Rank: 1}
+	third := database.Note{ID: uuid.New(), Column: toImprove, Rank: 0}
+
+	voting := database.Voting{ID: uuid.New(), VoteLimit: 3, ShowVotesOfOthers: true, Status: types.VotingStatusClosed, Mode: types.VotingModeDot}
+	votes := []database.Vote{
+		{Voting: voting.ID, User: jack, Note: first.ID, Weight: 1},
+		{Voting: voting.ID, User: jack, Note: stacked.ID, Weight: 1},
+		{Voting: voting.ID, User: jack, Note: second.ID, Weight: 1},
+		{Voting: voting.ID, User: jay, Note: second.ID, Weight: 1},
+		{Voting: voting.ID, User: jay, Note: third.ID, Weight: 1},
+		{Voting: voting.ID, User: john, Note: third.ID, Weight: 1},
+		{Voting: uuid.New(), User: john, Note: first.ID, Weight: 1},
+	}
+	sessions := []database.BoardSession{{User: jack}, {User: jay}, {User: john}, {User: uuid.New()}}
+
+	report := new(VotingReport).From(voting, votes, []database.Note{first, stacked, second, third}, []database.Column{{ID: wentWell}, {ID: toImprove}}, sessions)
+
+	assert.Equal(t, 6, report.Total)
+	assert.Len(t, report.Notes, 3)
+	assert.Equal(t, VotingReportNote{Note: first.ID, Column: wentWell, Rank: 1, Total: 2, Users: &[]VotingResultsPerUser{{ID: jack, Total: 2}}}, report.Notes[0])
+	assert.Equal(t, second.ID, report.Notes[1].Note)
+	assert.Equal(t, 1, report.Notes[1].Rank)
+	assert.Equal(t, third.ID, report.Notes[2].Note)
+	assert.Equal(t, 1, report.Notes[2].Rank)
+
+	assert.Equal(t, []VotingReportTie{{Rank: 1, Total: 2, Notes: []uuid.UUID{first.ID, second.ID, third.ID}}}, report.Ties)
+	assert.Equal(t, []VotingReportColumn{{Column: wentWell, Total: 2, Notes: 1}, {Column: toImprove, Total: 4, Notes: 2}}, report.Columns)
+	assert.Equal(t, VotingParticipation{Participants: 4, Voters: 3, UsedBudget: 1, Rate: 0.25}, report.Participation)
+}
+
+func TestVotingReportHidesVotesOfOthers(t *testing.T) {
+	note := database.Note{ID: uuid.New(), Column: uuid.New()}
+	voting := database.Voting{ID: uuid.New(), VoteLimit: 5, ShowVotesOfOthers: false, Status: types.VotingStatusClosed, Mode: types.VotingModeWeighted}
+	votes := []database.Vote{
+		{Voting: voting.ID, User: uuid.New(), Note: note.ID, Weight: 3},
+		{Voting: voting.ID, User: uuid.New(), Note: note.ID, Weight: 1},
+	}
+
+	report := new(VotingReport).From(voting, votes, []database.Note{note}, nil, nil)
+
+	assert.Len(t, report.Notes, 1)
+	assert.Equal(t, 4, report.Notes[0].Total)
+	assert.Nil(t, report.Notes[0].Users)
+	assert.Empty(t, report.Ties)
+	assert.Equal(t, VotingParticipation{Voters: 2}, report.Participation)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"scrumlr.io/server/database"
 	"scrumlr.io/server/database/types"
+	"time"
 )
 
 // Voting is the response for all voting requests.
@@ -14,6 +15,12 @@ type Voting struct {
 	AllowMultipleVotes bool               `json:"allowMultipleVotes"`
 	ShowVotesOfOthers  bool               `json:"showVotesOfOthers"`
 	Status             types.VotingStatus `json:"status"`
+	Mode               types.VotingMode   `json:"mode,omitempty"`
+	Anonymous          bool               `json:"anonymous"`
+	Columns            *[]uuid.UUID       `json:"columns,omitempty"`
+	PreventSelfVotes   bool               `json:"preventSelfVotes"`
+	MaxVotesPerNote    *int               `json:"maxVotesPerNote,omitempty"`
+	ClosesAt           *time.Time         `json:"closesAt,omitempty"`
 	VotingResults      *VotingResults     `json:"votes,omitempty"`
 }
 
@@ -38,6 +45,14 @@ func (v *Voting) From(voting database.Voting, votes []database.Vote) *Voting {
 	v.AllowMultipleVotes = voting.AllowMultipleVotes
 	v.ShowVotesOfOthers = voting.ShowVotesOfOthers
 	v.Status = voting.Status
+	v.Mode = voting.Mode
+	v.Anonymous = voting.Anonymous
+	if voting.Columns != nil {
+		v.Columns = &voting.Columns
+	}
+	v.PreventSelfVotes = voting.PreventSelfVotes
+	v.MaxVotesPerNote = voting.MaxVotesPerNote
+	v.ClosesAt = voting.ClosesAt
 	v.VotingResults = getVotingWithResults(voting, votes)
 	return v
 }
@@ -64,6 +79,28 @@ type VotingCreateRequest struct {
 	VoteLimit          int       `json:"voteLimit"`
 	AllowMultipleVotes bool      `json:"allowMultipleVotes"`
 	ShowVotesOfOthers  bool      `json:"showVotesOfOthers"`
+
+	// The voting mode, which defaults to 'DOT'.
+	Mode types.VotingMode `json:"mode"`
+
+	// Set whether the votes per user are hidden from everyone, including moderators, regardless of the setting to show
+	// the votes of others.
+	Anonymous bool `json:"anonymous"`
+
+	// The columns whose notes can be voted on. If not set, the notes of all columns are part of the voting.
+	Columns *[]uuid.UUID `json:"columns"`
+
+	// Set whether participants are prevented from voting on their own notes.
+	PreventSelfVotes bool `json:"preventSelfVotes"`
+
+	// The maximum number of votes a participant can spend on a single note.
+	MaxVotesPerNote *int `json:"maxVotesPerNote"`
+
+	// The duration in seconds after which the voting is closed automatically.
+	DurationSeconds *int `json:"durationSeconds"`
+
+	// The time at which the voting is closed automatically. Can't be set along with the duration.
+	ClosesAt *time.Time `json:"closesAt"`
 }
 
 // VotingUpdateRequest represents the request to update a voting session.
@@ -71,6 +108,24 @@ type VotingUpdateRequest struct {
 	ID     uuid.UUID          `json:"-"`
 	Board  uuid.UUID          `json:"-"`
 	Status types.VotingStatus `json:"status"`
+
+	// The new vote limit of an open or reopened voting, which can only be raised.
+	VoteLimit *int `json:"voteLimit"`
+}
+
+// votePoints returns the points of a vote, which depend on the mode of the voting.
+func votePoints(voting database.Voting, vote database.Vote) int {
+	switch voting.Mode {
+	case types.VotingModeWeighted:
+		return vote.Weight
+	case types.VotingModeRanked:
+		// Borda count, so that the first rank receives as many points as there are ranks
+		if vote.Rank == nil {
+			return 0
+		}
+		return voting.VoteLimit - *vote.Rank + 1
+	}
+	return 1
 }
 
 func getVotingWithResults(voting database.Voting, votes []database.Vote) *VotingResults {
@@ -86,35 +141,26 @@ func getVotingWithResults(voting database.Voting, votes []database.Vote) *Voting
 	}
 
 	if len(votesForVoting) > 0 {
-		votingResult := VotingResults{Total: len(votesForVoting), Votes: map[uuid.UUID]VotingResultsPerNote{}}
+		votingResult := VotingResults{Total: 0, Votes: map[uuid.UUID]VotingResultsPerNote{}}
 		totalVotePerNote := map[uuid.UUID]int{}
-		votesPerUser := map[uuid.UUID][]uuid.UUID{}
+		pointsPerUser := map[uuid.UUID]map[uuid.UUID]int{}
 		for _, vote := range votesForVoting {
-			if _, ok := totalVotePerNote[vote.Note]; ok {
-				totalVotePerNote[vote.Note] = totalVotePerNote[vote.Note] + 1
-				votesPerUser[vote.Note] = append(votesPerUser[vote.Note], vote.User)
-			} else {
-				totalVotePerNote[vote.Note] = 1
-				votesPerUser[vote.Note] = []uuid.UUID{vote.User}
+			points := votePoints(voting, vote)
+			votingResult.Total += points
+			totalVotePerNote[vote.Note] += points
+			if _, ok := pointsPerUser[vote.Note]; !ok {
+				pointsPerUser[vote.Note] = map[uuid.UUID]int{}
 			}
+			pointsPerUser[vote.Note][vote.User] += points
 		}
 
 		for note, total := range totalVotePerNote {
 			result := VotingResultsPerNote{
 				Total: total,
 			}
-			if voting.ShowVotesOfOthers {
-				userVotes := map[uuid.UUID]int{}
-				for _, user := range votesPerUser[note] {
-					if _, ok := userVotes[user]; ok {
-						userVotes[user] = userVotes[user] + 1
-					} else {
-						userVotes[user] = 1
-					}
-				}
-
+			if voting.ShowVotesOfOthers && !voting.Anonymous {
 				var votingResultsPerUser []VotingResultsPerUser
-				for user, total := range userVotes {
+				for user, total := range pointsPerUser[note] {
 					votingResultsPerUser = append(votingResultsPerUser, VotingResultsPerUser{
 						ID:    user,
 						Total: total,
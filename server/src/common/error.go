@@ -40,6 +40,15 @@ func ForbiddenError(err error) *APIError {
 	}
 }
 
+func ConflictError(err error) *APIError {
+	return &APIError{
+		Err:        err,
+		StatusCode: http.StatusConflict,
+		StatusText: "Conflict.",
+		ErrorText:  err.Error(),
+	}
+}
+
 var NotFoundError = &APIError{StatusCode: http.StatusNotFound, StatusText: "Resource not found."}
 var InternalServerError = &APIError{StatusCode: http.StatusInternalServerError, StatusText: "Internal server error."}
 
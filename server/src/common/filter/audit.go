@@ -0,0 +1,16 @@
+package filter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database/types"
+)
+
+type AuditFilter struct {
+	Board      uuid.UUID
+	Actor      *uuid.UUID
+	EntityType *types.AuditEntity
+	From       *time.Time
+	To         *time.Time
+}
@@ -0,0 +1,13 @@
+package filter
+
+import (
+	"github.com/google/uuid"
+	"scrumlr.io/server/database/types"
+)
+
+type UserBoardFilter struct {
+	User   uuid.UUID
+	Role   *types.SessionRole
+	Limit  int
+	Offset int
+}
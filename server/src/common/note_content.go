@@ -0,0 +1,232 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"scrumlr.io/server/database/types"
+)
+
+const (
+	maxNoteLinks             = 10
+	maxLinkURLLength         = 2048
+	maxLinkTitleLength       = 256
+	maxLinkDescriptionLength = 1024
+	maxChecklistItems        = 100
+	maxChecklistItemLength   = 512
+)
+
+var (
+	markdownCodePattern        = regexp.MustCompile("(?s)```.*?(```|$)|`[^`\n]+`")
+	htmlCommentPattern         = regexp.MustCompile(`(?s)<!--.*?(-->|$)`)
+	htmlTagPattern             = regexp.MustCompile(`</?[A-Za-z][A-Za-z0-9-]*(\s[^<>]*)?/?>`)
+	markdownLinkPattern        = regexp.MustCompile(`(\]\(\s*<?)([^)\s>]+)`)
+	markdownReferencePattern   = regexp.MustCompile(`(?m)^( {0,3}\[[^\]\n]+\]:[ \t]*<?)([^\s>]+)`)
+	markdownAutolinkPattern    = regexp.MustCompile(`<([A-Za-z][A-Za-z0-9+.-]*:[^<>\s]*)>`)
+	linkPattern                = regexp.MustCompile("https?://[^\\s<>()\\[\\]\"'`]+")
+	linkTrailingPunctuation    = ".,;:!?*_~"
+	whitespacePattern          = regexp.MustCompile(`\s+`)
+	angleBracketReplacer       = strings.NewReplacer("<", "&lt;", ">", "&gt;")
+	safeMarkdownLinkSchemes    = map[string]bool{"http": true, "https": true, "mailto": true}
+	errTooManyChecklistItems   = fmt.Errorf("a checklist may not have more than %d items", maxChecklistItems)
+	errEmptyChecklistItem      = errors.New("checklist items may not be empty")
+	errChecklistItemTooLong    = fmt.Errorf("checklist items may not be longer than %d characters", maxChecklistItemLength)
+	errChecklistOfNonChecklist = errors.New("only notes of type checklist may have checklist items")
+)
+
+// NoteContent is the text of a note along with its rich content.
+type NoteContent struct {
+	Type      types.NoteContentType
+	Text      string
+	Links     []types.LinkPreview
+	Checklist []types.ChecklistItem
+}
+
+// Sanitize returns the content as it may be stored. Markdown is stripped of raw HTML and unsafe link targets, the link
+// previews are limited to the links within the text and the checklist items are validated.
+//
+// The link previews are unfurled by the clients, so the server only accepts metadata of links that actually occur in
+// the text and fills in previews without metadata for the remaining links.
+func (c NoteContent) Sanitize() (NoteContent, error) {
+	sanitized := NoteContent{Type: c.Type, Text: c.Text}
+	if sanitized.Type == "" {
+		sanitized.Type = types.NoteContentTypePlain
+	}
+
+	if sanitized.Type == types.NoteContentTypeMarkdown {
+		sanitized.Text = SanitizeMarkdown(c.Text)
+	}
+
+	if sanitized.Type == types.NoteContentTypeChecklist {
+		checklist, err := sanitizeChecklist(c.Checklist)
+		if err != nil {
+			return NoteContent{}, err
+		}
+		sanitized.Checklist = checklist
+	} else if len(c.Checklist) > 0 {
+		return NoteContent{}, errChecklistOfNonChecklist
+	}
+
+	sanitized.Links = sanitizeLinks(sanitized.Text, c.Links)
+	return sanitized, nil
+}
+
+// SanitizeMarkdown removes raw HTML from the Markdown text and replaces link targets with schemes other than http,
+// https and mailto. Code spans and code blocks are kept as they are, since they are never rendered as HTML.
+func SanitizeMarkdown(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, code := range markdownCodePattern.FindAllStringIndex(text, -1) {
+		b.WriteString(sanitizeMarkdownSection(text[last:code[0]]))
+		b.WriteString(text[code[0]:code[1]])
+		last = code[1]
+	}
+	b.WriteString(sanitizeMarkdownSection(text[last:]))
+	return b.String()
+}
+
+func sanitizeMarkdownSection(text string) string {
+	text = stripHTML(text)
+	text = markdownLinkPattern.ReplaceAllStringFunc(text, replaceUnsafeLinkTarget(markdownLinkPattern))
+	text = markdownReferencePattern.ReplaceAllStringFunc(text, replaceUnsafeLinkTarget(markdownReferencePattern))
+
+	// the remaining opening angle brackets are escaped, unless they enclose a safe autolink, so that no HTML can be
+	// formed of what is left. Closing angle brackets start block quotes in Markdown and can't form HTML on their own.
+	var b strings.Builder
+	last := 0
+	for _, autolink := range markdownAutolinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(strings.ReplaceAll(text[last:autolink[0]], "<", "&lt;"))
+		target := text[autolink[2]:autolink[3]]
+		if isSafeMarkdownLink(target) {
+			b.WriteString(text[autolink[0]:autolink[1]])
+		} else {
+			// without the angle brackets the target is not rendered as a link anymore
+			b.WriteString(target)
+		}
+		last = autolink[1]
+	}
+	b.WriteString(strings.ReplaceAll(text[last:], "<", "&lt;"))
+	return b.String()
+}
+
+// stripHTML removes HTML comments and tags. Tags are removed until none are left, since the removal of a tag may join
+// the parts of another one, as in <scr<b>ipt>.
+func stripHTML(text string) string {
+	for {
+		stripped := htmlTagPattern.ReplaceAllString(htmlCommentPattern.ReplaceAllString(text, ""), "")
+		if stripped == text {
+			return text
+		}
+		text = stripped
+	}
+}
+
+func replaceUnsafeLinkTarget(pattern *regexp.Regexp) func(string) string {
+	return func(link string) string {
+		match := pattern.FindStringSubmatch(link)
+		if isSafeMarkdownLink(match[2]) {
+			return link
+		}
+		return match[1] + "#"
+	}
+}
+
+// isSafeMarkdownLink reports whether the link is relative or uses one of the safe schemes. Markdown decodes entities
+// within link targets and browsers ignore control characters and whitespace within a scheme, so both are handled here
+// as well.
+func isSafeMarkdownLink(link string) bool {
+	link = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(link))
+
+	end := strings.IndexAny(link, ":/?#")
+	if end < 0 || link[end] != ':' {
+		return true
+	}
+	return safeMarkdownLinkSchemes[strings.ToLower(link[:end])]
+}
+
+// ExtractLinks returns the distinct http and https links within the text in the order of their occurrence.
+func ExtractLinks(text string) []string {
+	links := []string{}
+	seen := map[string]bool{}
+	for _, link := range linkPattern.FindAllString(text, -1) {
+		link = strings.TrimRight(link, linkTrailingPunctuation)
+		if seen[link] || len(link) > maxLinkURLLength {
+			continue
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+	return links
+}
+
+func sanitizeLinks(text string, previews []types.LinkPreview) []types.LinkPreview {
+	metadata := map[string]types.LinkPreview{}
+	for _, preview := range previews {
+		metadata[preview.URL] = preview
+	}
+
+	var links []types.LinkPreview
+	for _, link := range ExtractLinks(text) {
+		if len(links) == maxNoteLinks {
+			break
+		}
+		preview := types.LinkPreview{URL: link}
+		if known, ok := metadata[link]; ok {
+			preview.Title = sanitizePlainText(known.Title, maxLinkTitleLength)
+			preview.Description = sanitizePlainText(known.Description, maxLinkDescriptionLength)
+			if isHTTPURL(known.Image) {
+				preview.Image = known.Image
+			}
+		}
+		links = append(links, preview)
+	}
+	return links
+}
+
+func sanitizeChecklist(items []types.ChecklistItem) ([]types.ChecklistItem, error) {
+	if len(items) > maxChecklistItems {
+		return nil, errTooManyChecklistItems
+	}
+
+	var checklist []types.ChecklistItem
+	for _, item := range items {
+		text := sanitizePlainText(item.Text, -1)
+		if text == "" {
+			return nil, errEmptyChecklistItem
+		}
+		if utf8.RuneCountInString(text) > maxChecklistItemLength {
+			return nil, errChecklistItemTooLong
+		}
+		checklist = append(checklist, types.ChecklistItem{Text: text, Done: item.Done})
+	}
+	return checklist, nil
+}
+
+// sanitizePlainText removes HTML tags and collapses whitespace. If the limit is not negative, the text is truncated to
+// the limit of characters. Angle brackets that are left are escaped, so that no HTML can be formed of them.
+func sanitizePlainText(text string, limit int) string {
+	text = stripHTML(text)
+	text = strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+	if limit >= 0 && utf8.RuneCountInString(text) > limit {
+		text = strings.TrimSpace(string([]rune(text)[:limit]))
+	}
+	return angleBracketReplacer.Replace(text)
+}
+
+func isHTTPURL(link string) bool {
+	if link == "" || len(link) > maxLinkURLLength {
+		return false
+	}
+	u, err := url.Parse(link)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,99 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
+)
+
+func TestSanitizeMarkdownRemovesRawHTML(t *testing.T) {
+	text := "**Bold** <script>alert(1)</script><img src=x onerror=alert(1)> <!-- hidden -->text"
+	assert.Equal(t, "**Bold** alert(1) text", SanitizeMarkdown(text))
+}
+
+func TestSanitizeMarkdownRemovesNestedHTML(t *testing.T) {
+	assert.Equal(t, "alert(1)", SanitizeMarkdown("<scr<b>ipt>alert(1)</scr</b>ipt>"))
+	assert.Equal(t, "", SanitizeMarkdown("<img<b> src=x onerror=alert(1)>"))
+}
+
+func TestSanitizeMarkdownEscapesRemainingAngleBrackets(t *testing.T) {
+	text := "<svg/onload=alert(1)> a < b\n> quote"
+	assert.Equal(t, "&lt;svg/onload=alert(1)> a &lt; b\n> quote", SanitizeMarkdown(text))
+}
+
+func TestSanitizeMarkdownKeepsCode(t *testing.T) {
+	text := "Use `<div>` and\n```\n<b>bold</b>\n```\n<b>done</b>"
+	assert.Equal(t, "Use `<div>` and\n```\n<b>bold</b>\n```\ndone", SanitizeMarkdown(text))
+}
+
+func TestSanitizeMarkdownReplacesUnsafeLinks(t *testing.T) {
+	text := "[a](javascript:void) [b](JaVaScRiPt:void) [c](https://scrumlr.io) [d](/relative) [f](&#106;avascript:void)\n[e]: data:text/html,x"
+	assert.Equal(t, "[a](#) [b](#) [c](https://scrumlr.io) [d](/relative) [f](#)\n[e]: #", SanitizeMarkdown(text))
+}
+
+func TestSanitizeMarkdownAutolinks(t *testing.T) {
+	text := "<https://scrumlr.io> <mailto:team@scrumlr.io> <vbscript:msgbox>"
+	assert.Equal(t, "<https://scrumlr.io> <mailto:team@scrumlr.io> vbscript:msgbox", SanitizeMarkdown(text))
+}
+
+func TestExtractLinks(t *testing.T) {
+	links := ExtractLinks("See https://scrumlr.io, (http://example.com/a?b=c) and https://scrumlr.io again.")
+	assert.Equal(t, []string{"https://scrumlr.io", "http://example.com/a?b=c"}, links)
+}
+
+func TestSanitizeNoteContentKeepsOnlyPreviewsOfLinksInText(t *testing.T) {
+	content, err := NoteContent{
+		Text: "Read https://scrumlr.io and https://example.com",
+		Links: []types.LinkPreview{
+			{URL: "https://scrumlr.io", Title: " <b>Scrumlr</b> ", Image: "javascript:alert(1)"},
+			{URL: "https://unrelated.com", Title: "Unrelated"},
+		},
+	}.Sanitize()
+
+	assert.Nil(t, err)
+	assert.Equal(t, types.NoteContentTypePlain, content.Type)
+	assert.Equal(t, []types.LinkPreview{
+		{URL: "https://scrumlr.io", Title: "Scrumlr"},
+		{URL: "https://example.com"},
+	}, content.Links)
+}
+
+func TestSanitizeNoteContentWithChecklist(t *testing.T) {
+	content, err := NoteContent{
+		Type:      types.NoteContentTypeChecklist,
+		Text:      "Actions",
+		Checklist: []types.ChecklistItem{{Text: "  Book <i>room</i> ", Done: true}, {Text: "Invite team"}},
+	}.Sanitize()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []types.ChecklistItem{{Text: "Book room", Done: true}, {Text: "Invite team"}}, content.Checklist)
+}
+
+func TestSanitizeNoteContentRemovesNestedHTMLOfChecklistAndLinks(t *testing.T) {
+	content, err := NoteContent{
+		Type:      types.NoteContentTypeChecklist,
+		Text:      "Read https://scrumlr.io",
+		Links:     []types.LinkPreview{{URL: "https://scrumlr.io", Title: "<img<b> src=x onerror=alert(1)>Scrumlr"}},
+		Checklist: []types.ChecklistItem{{Text: "<scr<b>ipt>alert(1)</scr</b>ipt>"}, {Text: "<svg/onload=alert(1)>"}},
+	}.Sanitize()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Scrumlr", content.Links[0].Title)
+	assert.Equal(t, []types.ChecklistItem{{Text: "alert(1)"}, {Text: "&lt;svg/onload=alert(1)&gt;"}}, content.Checklist)
+}
+
+func TestSanitizeNoteContentWithInvalidChecklistShouldFail(t *testing.T) {
+	_, err := NoteContent{Type: types.NoteContentTypeChecklist, Text: "Actions", Checklist: []types.ChecklistItem{{Text: " "}}}.Sanitize()
+	assert.NotNil(t, err)
+
+	_, err = NoteContent{Type: types.NoteContentTypeChecklist, Text: "Actions", Checklist: []types.ChecklistItem{{Text: strings.Repeat("a", maxChecklistItemLength+1)}}}.Sanitize()
+	assert.NotNil(t, err)
+
+	_, err = NoteContent{Type: types.NoteContentTypeChecklist, Text: "Actions", Checklist: make([]types.ChecklistItem, maxChecklistItems+1)}.Sanitize()
+	assert.NotNil(t, err)
+
+	_, err = NoteContent{Type: types.NoteContentTypeMarkdown, Text: "Actions", Checklist: []types.ChecklistItem{{Text: "Item"}}}.Sanitize()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"scrumlr.io/server/database/types"
+)
+
+// ActionItem the model for an action item, which outlives the board it originates from
+type ActionItem struct {
+	bun.BaseModel `bun:"table:action_items"`
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	Owner         uuid.UUID
+	Board         uuid.NullUUID
+	Note          uuid.NullUUID
+	Text          string
+	DueDate       *time.Time
+	Status        types.ActionItemStatus
+}
+
+// ActionItemInsert the insert model for a new ActionItem
+type ActionItemInsert struct {
+	bun.BaseModel `bun:"table:action_items"`
+	Owner         uuid.UUID
+	Board         uuid.UUID
+	Note          uuid.NullUUID
+	Text          string
+	DueDate       *time.Time
+}
+
+// ActionItemUpdate the update model for an ActionItem
+type ActionItemUpdate struct {
+	bun.BaseModel `bun:"table:action_items"`
+	ID            uuid.UUID
+	Owner         *uuid.UUID
+	Text          *string
+	DueDate       *time.Time
+	Status        *types.ActionItemStatus
+}
+
+func (d *Database) CreateActionItem(insert ActionItemInsert) (ActionItem, error) {
+	var actionItem ActionItem
+	_, err := d.db.NewInsert().Model(&insert).Returning("*").Exec(context.Background(), &actionItem)
+	return actionItem, err
+}
+
+func (d *Database) UpdateActionItem(update ActionItemUpdate) (ActionItem, error) {
+	query := d.db.NewUpdate().Model(&update)
+	if update.Owner != nil {
+		query.Set("owner = ?", *update.Owner)
+	}
+	if update.Text != nil {
+		query.Set("text = ?", *update.Text)
+	}
+	if update.DueDate != nil {
+		query.Set("due_date = ?", *update.DueDate)
+	}
+	if update.Status != nil {
+		query.Set("status = ?", *update.Status)
+	}
+
+	// keep the statement valid if nothing is updated
+	query.Set("id = id")
+
+	var actionItem ActionItem
+	_, err := query.Where("id = ?", update.ID).Returning("*").Exec(context.Background(), &actionItem)
+	return actionItem, err
+}
+
+func (d *Database) DeleteActionItem(id uuid.UUID) error {
+	_, err := d.db.NewDelete().Model((*ActionItem)(nil)).Where("id = ?", id).Exec(context.Background())
+	return err
+}
+
+func (d *Database) GetActionItem(id uuid.UUID) (ActionItem, error) {
+	var actionItem ActionItem
+	err := d.db.NewSelect().Model(&actionItem).Where("id = ?", id).Scan(context.Background())
+	return actionItem, err
+}
+
+// GetActionItems returns all action items originating from the specified board.
+func (d *Database) GetActionItems(board uuid.UUID) ([]ActionItem, error) {
+	var actionItems []ActionItem
+	err := d.db.NewSelect().Model(&actionItems).Where("board = ?", board).Order("created_at ASC").Scan(context.Background())
+	return actionItems, err
+}
+
+// GetUserActionItems returns all action items of the specified owner across all boards, optionally filtered by
+// their status.
+func (d *Database) GetUserActionItems(owner uuid.UUID, status *types.ActionItemStatus) ([]ActionItem, error) {
+	query := d.db.NewSelect().Model((*ActionItem)(nil)).Where("owner = ?", owner)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	var actionItems []ActionItem
+	err := query.OrderExpr("due_date ASC NULLS LAST, created_at ASC").Scan(context.Background(), &actionItems)
+	return actionItems, err
+}
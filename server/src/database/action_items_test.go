@@ -0,0 +1,89 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
+)
+
+func TestRunnerForActionItems(t *testing.T) {
+	t.Run("Create=0", testCreateActionItem)
+	t.Run("Update=0", testUpdateActionItemStatus)
+	t.Run("Get=0", testGetUserActionItemsAcrossBoards)
+	t.Run("Delete=0", testActionItemOutlivesBoard)
+}
+
+func createBoardForActionItems(t *testing.T, owner uuid.UUID) Board {
+	board, err := testDb.CreateBoard(owner, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{{Name: "Column", Color: types.ColorBacklogBlue}})
+	assert.Nil(t, err)
+	return board
+}
+
+func testCreateActionItem(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board := createBoardForActionItems(t, user.ID)
+	dueDate := time.Now().Add(14 * 24 * time.Hour).Truncate(time.Second)
+
+	actionItem, err := testDb.CreateActionItem(ActionItemInsert{Owner: user.ID, Board: board.ID, Text: "Fix the build", DueDate: &dueDate})
+	assert.Nil(t, err)
+	assert.Equal(t, user.ID, actionItem.Owner)
+	assert.Equal(t, board.ID, actionItem.Board.UUID)
+	assert.False(t, actionItem.Note.Valid)
+	assert.Equal(t, "Fix the build", actionItem.Text)
+	assert.True(t, dueDate.Equal(*actionItem.DueDate))
+	assert.Equal(t, types.ActionItemStatusOpen, actionItem.Status)
+}
+
+func testUpdateActionItemStatus(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board := createBoardForActionItems(t, user.ID)
+	actionItem, _ := testDb.CreateActionItem(ActionItemInsert{Owner: user.ID, Board: board.ID, Text: "Update docs"})
+
+	status := types.ActionItemStatusInProgress
+	updated, err := testDb.UpdateActionItem(ActionItemUpdate{ID: actionItem.ID, Status: &status})
+	assert.Nil(t, err)
+	assert.Equal(t, types.ActionItemStatusInProgress, updated.Status)
+	assert.Equal(t, "Update docs", updated.Text)
+}
+
+func testGetUserActionItemsAcrossBoards(t *testing.T) {
+	user := fixture.MustRow("User.jennifer").(*User)
+	firstBoard := createBoardForActionItems(t, user.ID)
+	secondBoard := createBoardForActionItems(t, user.ID)
+
+	first, _ := testDb.CreateActionItem(ActionItemInsert{Owner: user.ID, Board: firstBoard.ID, Text: "First"})
+	second, _ := testDb.CreateActionItem(ActionItemInsert{Owner: user.ID, Board: secondBoard.ID, Text: "Second"})
+	done := types.ActionItemStatusDone
+	_, _ = testDb.UpdateActionItem(ActionItemUpdate{ID: second.ID, Status: &done})
+
+	actionItems, err := testDb.GetUserActionItems(user.ID, nil)
+	assert.Nil(t, err)
+	assert.Len(t, actionItems, 2)
+
+	open := types.ActionItemStatusOpen
+	openActionItems, err := testDb.GetUserActionItems(user.ID, &open)
+	assert.Nil(t, err)
+	assert.Len(t, openActionItems, 1)
+	assert.Equal(t, first.ID, openActionItems[0].ID)
+}
+
+func testActionItemOutlivesBoard(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board := createBoardForActionItems(t, user.ID)
+	columns, _ := testDb.GetColumns(board.ID)
+	note, _ := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "Note"})
+
+	actionItem, _ := testDb.CreateActionItem(ActionItemInsert{Owner: user.ID, Board: board.ID, Note: uuid.NullUUID{UUID: note.ID, Valid: true}, Text: "Carry over"})
+
+	err := testDb.DeleteBoard(board.ID)
+	assert.Nil(t, err)
+
+	remaining, err := testDb.GetActionItem(actionItem.ID)
+	assert.Nil(t, err)
+	assert.False(t, remaining.Board.Valid)
+	assert.False(t, remaining.Note.Valid)
+	assert.Equal(t, "Carry over", remaining.Text)
+}
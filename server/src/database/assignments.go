@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -24,8 +25,17 @@ type AssignmentInsert struct {
 }
 
 func (d *Database) CreateAssignment(insert AssignmentInsert) (Assignment, error) {
+  // assignments can only be made on notes of the board, which are not deleted
+  exists, err := d.db.NewSelect().Model((*Note)(nil)).Where("id = ?", insert.Note).Where("board = ?", insert.Board).Exists(context.Background())
+  if err != nil {
+    return Assignment{}, err
+  }
+  if !exists {
+    return Assignment{}, sql.ErrNoRows
+  }
+
   var assignment Assignment
-  _, err := d.db.NewInsert().Model(&insert).Returning("*").Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", insert.Board), &assignment)
+  _, err = d.db.NewInsert().Model(&insert).Returning("*").Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", insert.Board), &assignment)
   return assignment, err
 }
 
@@ -35,7 +45,11 @@ func (d *Database) DeleteAssignment(board, assignment uuid.UUID) (error) {
 
 }
 func (d *Database) GetAssignments(board uuid.UUID) ([]Assignment, error) {
-  assignmentQuery := d.db.NewSelect().Model((*Assignment)(nil)).Where("board = ?", board)
+  // assignments on deleted notes are left out
+  assignmentQuery := d.db.NewSelect().
+    Model((*Assignment)(nil)).
+    Where("board = ?", board).
+    Where("note IN (?)", d.db.NewSelect().Model((*Note)(nil)).Column("id").Where("board = ?", board))
 
   var assignments []Assignment
   err := assignmentQuery.Scan(context.Background(), &assignments)
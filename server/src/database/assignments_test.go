@@ -1,7 +1,9 @@
 package database
 
 import (
+  "database/sql"
   "github.com/stretchr/testify/assert"
+  "scrumlr.io/server/database/types"
   "testing"
 )
 
@@ -9,6 +11,7 @@ func TestRunnerForAssignments(t *testing.T) {
   t.Run("Get=0", testGetAssignments)
   t.Run("Delete=0", testDeleteAssignment)
   t.Run("Create=0", testCreateAssignment)
+  t.Run("Create=1", testCreateAssignmentOnDeletedNoteShouldFail)
 }
 
 func testGetAssignments(t *testing.T) {
@@ -48,3 +51,12 @@ func testDeleteAssignment (t *testing.T) {
   assert.Equal(t, 1, len(assignments))
 }
 
+func testCreateAssignmentOnDeletedNoteShouldFail(t *testing.T) {
+  user := fixture.MustRow("User.jack").(*User)
+  board, note, _ := createVotingModeTestBoard(t, types.VotingModeDot, 2)
+  err := testDb.DeleteNote(user.ID, board.ID, note.ID, false)
+  assert.Nil(t, err)
+
+  _, err = testDb.CreateAssignment(AssignmentInsert{Board: board.ID, Note: note.ID, Name: "Test Assignment Name"})
+  assert.Equal(t, sql.ErrNoRows, err)
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
+)
+
+// AuditEntry the model for an entry of the append-only audit log of a board.
+type AuditEntry struct {
+	bun.BaseModel `bun:"table:audit_log"`
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	Board         uuid.UUID
+	Actor         uuid.NullUUID
+	EntityType    types.AuditEntity
+	Entity        uuid.UUID
+	Action        string
+	Payload       json.RawMessage `bun:"type:jsonb"`
+}
+
+// AuditEntryInsert the insert model for a new AuditEntry
+type AuditEntryInsert struct {
+	bun.BaseModel `bun:"table:audit_log"`
+	Board         uuid.UUID
+	Actor         uuid.NullUUID
+	EntityType    types.AuditEntity
+	Entity        uuid.UUID
+	Action        string
+	Payload       json.RawMessage `bun:"type:jsonb"`
+}
+
+func (d *Database) CreateAuditEntry(insert AuditEntryInsert) (AuditEntry, error) {
+	var entry AuditEntry
+	_, err := d.db.NewInsert().Model(&insert).Returning("*").Exec(context.Background(), &entry)
+	return entry, err
+}
+
+// GetAuditEntries returns the entries of the audit log of a board, starting with the most recent one.
+func (d *Database) GetAuditEntries(f filter.AuditFilter) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	query := d.db.NewSelect().Model(&entries).Where("board = ?", f.Board)
+	if f.Actor != nil {
+		query = query.Where("actor = ?", *f.Actor)
+	}
+	if f.EntityType != nil {
+		query = query.Where("entity_type = ?", *f.EntityType)
+	}
+	if f.From != nil {
+		query = query.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("created_at <= ?", *f.To)
+	}
+	err := query.Order("created_at DESC").Scan(context.Background())
+	return entries, err
+}
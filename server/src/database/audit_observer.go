@@ -0,0 +1,92 @@
+package database
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/database/types"
+	"scrumlr.io/server/logger"
+)
+
+type AuditObserver interface {
+	Observer
+
+	// AuditedChange will be called for every change of a board, which is subject to the audit log. The actor of the
+	// change is set, if the change was made through a database obtained by WithActor.
+	AuditedChange(entry AuditEntryInsert)
+}
+
+// notifyAudit announces the change of the entity to the audit observers, whereas the payload is the state of the
+// entity after the change.
+func (d *Database) notifyAudit(board uuid.UUID, entityType types.AuditEntity, entity uuid.UUID, action string, payload interface{}) {
+	entry := AuditEntryInsert{
+		Board:      board,
+		Actor:      d.actor,
+		EntityType: entityType,
+		Entity:     entity,
+		Action:     action,
+	}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Get().Errorw("unable to marshal payload of audit entry", "entity", entity, "err", err)
+		} else {
+			entry.Payload = data
+		}
+	}
+
+	for _, observer := range d.observer {
+		if o, ok := observer.(AuditObserver); ok {
+			o.AuditedChange(entry)
+			return
+		}
+	}
+}
+
+// boardAuditPayload is the payload of the audit entry for an update of the board settings. The passphrase and the salt
+// are left out on purpose, so that the audit log does not disclose them.
+type boardAuditPayload struct {
+	Name                  *string             `json:"name,omitempty"`
+	AccessPolicy          *types.AccessPolicy `json:"accessPolicy,omitempty"`
+	ShowAuthors           *bool               `json:"showAuthors,omitempty"`
+	ShowNotesOfOtherUsers *bool               `json:"showNotesOfOtherUsers,omitempty"`
+	ShowNoteReactions     *bool               `json:"showNoteReactions,omitempty"`
+	AllowStacking         *bool               `json:"allowStacking,omitempty"`
+	SharedNote            *uuid.UUID          `json:"sharedNote,omitempty"`
+	ShowVoting            *uuid.UUID          `json:"showVoting,omitempty"`
+	Archived              *bool               `json:"archived,omitempty"`
+	TimerPresets          []int               `json:"timerPresets,omitempty"`
+	Phase                 *uuid.UUID          `json:"phase,omitempty"`
+}
+
+func newBoardAuditPayload(update BoardUpdate) boardAuditPayload {
+	payload := boardAuditPayload{
+		Name:                  update.Name,
+		AccessPolicy:          update.AccessPolicy,
+		ShowAuthors:           update.ShowAuthors,
+		ShowNotesOfOtherUsers: update.ShowNotesOfOtherUsers,
+		ShowNoteReactions:     update.ShowNoteReactions,
+		AllowStacking:         update.AllowStacking,
+		Archived:              update.Archived,
+		TimerPresets:          update.TimerPresets,
+		Phase:                 update.Phase,
+	}
+	if update.SharedNote.Valid {
+		payload.SharedNote = &update.SharedNote.UUID
+	}
+	if update.ShowVoting.Valid {
+		payload.ShowVoting = &update.ShowVoting.UUID
+	}
+	return payload
+}
+
+// votingAuditPayload is the payload of the audit entries for votings.
+type votingAuditPayload struct {
+	Status    types.VotingStatus `json:"status"`
+	Mode      types.VotingMode   `json:"mode"`
+	VoteLimit int                `json:"voteLimit"`
+}
+
+func newVotingAuditPayload(voting Voting) votingAuditPayload {
+	return votingAuditPayload{Status: voting.Status, Mode: voting.Mode, VoteLimit: voting.VoteLimit}
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
+)
+
+type AuditObserverForTests struct {
+	t       *testing.T
+	entries []AuditEntryInsert
+}
+
+func (o *AuditObserverForTests) AuditedChange(entry AuditEntryInsert) {
+	o.entries = append(o.entries, entry)
+	_, err := testDb.CreateAuditEntry(entry)
+	assert.Nil(o.t, err)
+}
+
+func (o *AuditObserverForTests) Reset() {
+	o.entries = nil
+}
+
+var auditObserver AuditObserverForTests
+
+func TestAuditObserver(t *testing.T) {
+	auditObserver = AuditObserverForTests{t: t}
+	testDb.AttachObserver(&auditObserver)
+
+	t.Run("Test=1", testAuditObserverOnBoardUpdate)
+	auditObserver.Reset()
+	t.Run("Test=2", testAuditObserverOnRoleChange)
+	auditObserver.Reset()
+	t.Run("Test=3", testAuditObserverOnVoting)
+	auditObserver.Reset()
+	t.Run("Test=4", testGetAuditEntriesWithFilter)
+
+	_, _ = testDb.DetachObserver(&auditObserver)
+}
+
+func createAuditTestBoard(t *testing.T) (Board, *User) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{{Name: "Column", Color: types.ColorBacklogBlue}})
+	assert.Nil(t, err)
+	return board, user
+}
+
+func testAuditObserverOnBoardUpdate(t *testing.T) {
+	board, user := createAuditTestBoard(t)
+	name := "Audited board"
+	passphrase, salt := "passphrase", "salt"
+	accessPolicy := types.AccessPolicyByPassphrase
+
+	ctx := context.WithValue(context.Background(), "User", user.ID)
+	_, err := testDb.WithActor(ctx).UpdateBoard(BoardUpdate{ID: board.ID, Name: &name, AccessPolicy: &accessPolicy, Passphrase: &passphrase, Salt: &salt})
+	assert.Nil(t, err)
+
+	assert.Len(t, auditObserver.entries, 1)
+	entry := auditObserver.entries[0]
+	assert.Equal(t, board.ID, entry.Board)
+	assert.Equal(t, uuid.NullUUID{UUID: user.ID, Valid: true}, entry.Actor)
+	assert.Equal(t, types.AuditEntityBoard, entry.EntityType)
+	assert.Equal(t, "UPDATED", entry.Action)
+
+	var payload map[string]interface{}
+	assert.Nil(t, json.Unmarshal(entry.Payload, &payload))
+	assert.Equal(t, name, payload["name"])
+	assert.Equal(t, string(accessPolicy), payload["accessPolicy"])
+	assert.NotContains(t, string(entry.Payload), passphrase)
+	assert.NotContains(t, string(entry.Payload), salt)
+}
+
+func testAuditObserverOnRoleChange(t *testing.T) {
+	board, _ := createAuditTestBoard(t)
+	participant := fixture.MustRow("User.jay").(*User)
+	_, err := testDb.CreateBoardSession(BoardSessionInsert{Board: board.ID, User: participant.ID, Role: types.SessionRoleParticipant})
+	assert.Nil(t, err)
+
+	ready := true
+	_, err = testDb.UpdateBoardSession(BoardSessionUpdate{Board: board.ID, User: participant.ID, Ready: &ready})
+	assert.Nil(t, err)
+	assert.Empty(t, auditObserver.entries)
+
+	role := types.SessionRoleModerator
+	_, err = testDb.UpdateBoardSession(BoardSessionUpdate{Board: board.ID, User: participant.ID, Role: &role})
+	assert.Nil(t, err)
+	assert.Len(t, auditObserver.entries, 1)
+	assert.Equal(t, types.AuditEntitySession, auditObserver.entries[0].EntityType)
+	assert.Equal(t, participant.ID, auditObserver.entries[0].Entity)
+	assert.Equal(t, "ROLE_CHANGED", auditObserver.entries[0].Action)
+	assert.False(t, auditObserver.entries[0].Actor.Valid)
+}
+
+func testAuditObserverOnVoting(t *testing.T) {
+	board, _ := createAuditTestBoard(t)
+
+	voting, err := testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: 5, Status: types.VotingStatusOpen})
+	assert.Nil(t, err)
+	_, err = testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusClosed})
+	assert.Nil(t, err)
+
+	assert.Len(t, auditObserver.entries, 2)
+	assert.Equal(t, "CREATED", auditObserver.entries[0].Action)
+	assert.Equal(t, string(types.VotingStatusClosed), auditObserver.entries[1].Action)
+	for _, entry := range auditObserver.entries {
+		assert.Equal(t, types.AuditEntityVoting, entry.EntityType)
+		assert.Equal(t, voting.ID, entry.Entity)
+	}
+}
+
+func testGetAuditEntriesWithFilter(t *testing.T) {
+	board, user := createAuditTestBoard(t)
+	name := "Filtered board"
+	ctx := context.WithValue(context.Background(), "User", user.ID)
+	_, err := testDb.WithActor(ctx).UpdateBoard(BoardUpdate{ID: board.ID, Name: &name})
+	assert.Nil(t, err)
+	_, err = testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: 5, Status: types.VotingStatusOpen})
+	assert.Nil(t, err)
+
+	entries, err := testDb.GetAuditEntries(filter.AuditFilter{Board: board.ID})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+
+	entityType := types.AuditEntityBoard
+	entries, err = testDb.GetAuditEntries(filter.AuditFilter{Board: board.ID, EntityType: &entityType})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, board.ID, entries[0].Entity)
+
+	entries, err = testDb.GetAuditEntries(filter.AuditFilter{Board: board.ID, Actor: &user.ID})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, types.AuditEntityBoard, entries[0].EntityType)
+}
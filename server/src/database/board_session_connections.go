@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BoardSessionConnection the model for the open connections of a session to an instance of the server. The connections
+// are counted per instance along with a heartbeat of the instance, so that the connections of an instance which stopped
+// without closing them expire.
+type BoardSessionConnection struct {
+	bun.BaseModel `bun:"table:board_session_connections"`
+	Board         uuid.UUID
+	User          uuid.UUID
+	Instance      uuid.UUID
+	Connections   int
+	Heartbeat     time.Time
+}
+
+// ConnectBoardSession counts an opened connection of the session to this instance and marks the session as connected.
+func (d *Database) ConnectBoardSession(board, user uuid.UUID) (BoardSession, error) {
+	return d.updateBoardSessionConnections(board, user, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(&BoardSessionConnection{Board: board, User: user, Instance: d.instance, Connections: 1}).
+			Column("board", "user", "instance", "connections").
+			On("CONFLICT (\"user\", board, instance) DO UPDATE").
+			Set("connections = board_session_connection.connections + 1").
+			Set("heartbeat = now()").
+			Exec(ctx)
+		return err
+	})
+}
+
+// DisconnectBoardSession counts a closed connection of the session to this instance and marks the session as
+// disconnected as soon as the last connection of the session to any instance has been closed.
+func (d *Database) DisconnectBoardSession(board, user uuid.UUID) (BoardSession, error) {
+	return d.updateBoardSessionConnections(board, user, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model((*BoardSessionConnection)(nil)).
+			Set("connections = connections - 1").
+			Where("board = ?", board).
+			Where("\"user\" = ?", user).
+			Where("instance = ?", d.instance).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.NewDelete().
+			Model((*BoardSessionConnection)(nil)).
+			Where("board = ?", board).
+			Where("\"user\" = ?", user).
+			Where("connections <= 0").
+			Exec(ctx)
+		return err
+	})
+}
+
+// RefreshBoardSessionConnections renews the heartbeat of the connections to this instance.
+func (d *Database) RefreshBoardSessionConnections() error {
+	_, err := d.db.NewUpdate().
+		Model((*BoardSessionConnection)(nil)).
+		Set("heartbeat = now()").
+		Where("instance = ?", d.instance).
+		Exec(context.Background())
+	return err
+}
+
+// ExpireBoardSessionConnections removes the connections whose heartbeat hasn't been renewed within the timeout, since
+// the instance holding them has stopped. It returns the sessions whose connected state has been derived anew from their
+// remaining connections.
+func (d *Database) ExpireBoardSessionConnections(timeout time.Duration) ([]BoardSession, error) {
+	var expired []BoardSessionConnection
+	_, err := d.db.NewDelete().
+		Model(&expired).
+		Where("heartbeat < now() - make_interval(secs => ?)", timeout.Seconds()).
+		Returning("board, \"user\"").
+		Exec(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]BoardSession, 0, len(expired))
+	updated := make(map[BoardSessionConnection]bool)
+	for _, connection := range expired {
+		key := BoardSessionConnection{Board: connection.Board, User: connection.User}
+		if updated[key] {
+			continue
+		}
+		updated[key] = true
+
+		session, err := d.updateBoardSessionConnections(connection.Board, connection.User, nil)
+		if err == sql.ErrNoRows {
+			// the session has been deleted in the meantime
+			continue
+		}
+		if err != nil {
+			return sessions, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// updateBoardSessionConnections changes the connections of the session and derives the connected state of the session
+// from its connections to all instances. The session is locked meanwhile, so that concurrent changes of the connections
+// on other instances are taken into account.
+func (d *Database) updateBoardSessionConnections(board, user uuid.UUID, change func(ctx context.Context, tx bun.Tx) error) (BoardSession, error) {
+	var session BoardSession
+	err := d.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
+		var locked BoardSession
+		err := tx.NewSelect().
+			Model(&locked).
+			Column("board").
+			Where("board = ?", board).
+			Where("\"user\" = ?", user).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if change != nil {
+			if err := change(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		connections := tx.NewSelect().
+			Model((*BoardSessionConnection)(nil)).
+			ColumnExpr("1").
+			Where("board = ?", board).
+			Where("\"user\" = ?", user).
+			Where("connections > 0")
+		updateQuery := tx.NewUpdate().
+			Model((*BoardSession)(nil)).
+			Set("connected = EXISTS (?)", connections).
+			Where("\"board\" = ?", board).
+			Where("\"user\" = ?", user).
+			Returning("*")
+
+		return tx.NewSelect().
+			With("updateQuery", updateQuery).
+			Model((*BoardSession)(nil)).
+			ModelTableExpr("\"updateQuery\" AS s").
+			ColumnExpr("s.board, s.user, u.avatar, u.name, s.connected, s.show_hidden_columns, s.ready, s.raised_hand, s.role").
+			Where("s.board = ?", board).
+			Where("s.user = ?", user).
+			Join("INNER JOIN users AS u ON u.id = s.user").
+			Scan(ctx, &session)
+	})
+	if err != nil {
+		return session, err
+	}
+
+	// send update to observers once the transaction has been committed
+	for _, observer := range d.observer {
+		if o, ok := observer.(BoardSessionsObserver); ok {
+			o.UpdatedSession(board, session)
+			break
+		}
+	}
+	return session, nil
+}
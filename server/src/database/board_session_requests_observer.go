@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
+	"scrumlr.io/server/database/types"
 )
 
 type BoardSessionRequestsObserver interface {
@@ -39,6 +40,7 @@ func (*BoardSessionRequest) AfterScanRow(ctx context.Context) error {
 			}
 		case "UPDATE":
 			request := ctx.Value("Result").(*BoardSessionRequest)
+			d.notifyAudit(board, types.AuditEntitySessionRequest, request.User, string(request.Status), nil)
 			for _, observer := range d.observer {
 				if o, ok := observer.(BoardSessionRequestsObserver); ok {
 					o.UpdatedSessionRequest(board, *request)
@@ -134,6 +134,7 @@ func (d *Database) UpdateBoardSession(update BoardSessionUpdate) (BoardSession,
 			"Database", d,
 			"Operation", "UPDATE",
 			"Board", update.Board,
+			"RoleChanged", update.Role != nil,
 			"Result", &session,
 		), &session)
 
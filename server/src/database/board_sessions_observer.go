@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
+	"scrumlr.io/server/database/types"
 )
 
 type BoardSessionsObserver interface {
@@ -41,6 +42,9 @@ func (*BoardSession) AfterScanRow(ctx context.Context) error {
 			}
 		case "UPDATE":
 			session := ctx.Value("Result").(*BoardSession)
+			if roleChanged, _ := ctx.Value("RoleChanged").(bool); roleChanged {
+				d.notifyAudit(board, types.AuditEntitySession, session.User, "ROLE_CHANGED", map[string]interface{}{"role": session.Role})
+			}
 			for _, observer := range d.observer {
 				if o, ok := observer.(BoardSessionsObserver); ok {
 					o.UpdatedSession(board, *session)
@@ -1,8 +1,11 @@
 package database
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"scrumlr.io/server/common/filter"
 	"scrumlr.io/server/database/types"
@@ -21,6 +24,8 @@ func TestRunnerForBoardSessions(t *testing.T) {
 	t.Run("Update=4", testUpdateOfParticipantToOwnerShouldFail)
 	t.Run("Update=5", testUpdateOfModeratorToOwnerShouldFail)
 	t.Run("Update=6", testUpdateBoardSessions)
+	t.Run("Update=7", testConnectAndDisconnectBoardSession)
+	t.Run("Update=8", testExpireConnectionsOfStoppedInstance)
 
 	t.Run("Exists=0", testBoardSessionExistsForParticipant)
 	t.Run("Exists=1", testBoardSessionExistsForModerator)
@@ -104,6 +109,60 @@ func testUpdateOfConnectedState(t *testing.T) {
 	assert.Equal(t, connected, session.Connected)
 }
 
+func testConnectAndDisconnectBoardSession(t *testing.T) {
+	board := fixture.MustRow("Board.boardSessionsTestBoard").(*Board)
+	user := fixture.MustRow("User.jennifer").(*User)
+
+	session, err := testDb.ConnectBoardSession(board.ID, user.ID)
+	assert.Nil(t, err)
+	assert.True(t, session.Connected)
+
+	session, err = testDb.ConnectBoardSession(board.ID, user.ID)
+	assert.Nil(t, err)
+	assert.True(t, session.Connected)
+
+	session, err = testDb.DisconnectBoardSession(board.ID, user.ID)
+	assert.Nil(t, err)
+	assert.True(t, session.Connected)
+
+	session, err = testDb.DisconnectBoardSession(board.ID, user.ID)
+	assert.Nil(t, err)
+	assert.False(t, session.Connected)
+
+	session, err = testDb.DisconnectBoardSession(board.ID, user.ID)
+	assert.Nil(t, err)
+	assert.False(t, session.Connected)
+}
+
+func testExpireConnectionsOfStoppedInstance(t *testing.T) {
+	board := fixture.MustRow("Board.boardSessionsTestBoard").(*Board)
+	user := fixture.MustRow("User.jennifer").(*User)
+
+	// another instance of the server stops without closing the connection of the session
+	stopped := *testDb
+	stopped.instance = uuid.New()
+	session, err := stopped.ConnectBoardSession(board.ID, user.ID)
+	assert.Nil(t, err)
+	assert.True(t, session.Connected)
+
+	_, err = testDb.db.NewUpdate().
+		Model((*BoardSessionConnection)(nil)).
+		Set("heartbeat = now() - interval '1 hour'").
+		Where("instance = ?", stopped.instance).
+		Exec(context.Background())
+	assert.Nil(t, err)
+
+	sessions, err := testDb.ExpireBoardSessionConnections(time.Minute)
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, user.ID, sessions[0].User)
+	assert.False(t, sessions[0].Connected)
+
+	session, err = testDb.GetBoardSession(board.ID, user.ID)
+	assert.Nil(t, err)
+	assert.False(t, session.Connected)
+}
+
 func testUpdateOfReadyState(t *testing.T) {
 	board := fixture.MustRow("Board.boardSessionsTestBoard").(*Board)
 	user := fixture.MustRow("User.jennifer").(*User)
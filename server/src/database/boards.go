@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 	"scrumlr.io/server/common"
 	"scrumlr.io/server/database/types"
 	"time"
@@ -26,21 +27,33 @@ type Board struct {
 	TimerEnd              *time.Time
 	SharedNote            uuid.NullUUID
 	ShowVoting            uuid.NullUUID
+	Archived              bool
+	TimerRemaining        *int64
+	TimerExpired          bool
+	TimerPresets          []int `bun:",array"`
+	Phase                 uuid.NullUUID
 }
 
 type BoardInsert struct {
-	bun.BaseModel `bun:"table:boards"`
-	Name          *string
-	AccessPolicy  types.AccessPolicy
-	Passphrase    *string
-	Salt          *string
+	bun.BaseModel         `bun:"table:boards"`
+	Name                  *string
+	AccessPolicy          types.AccessPolicy
+	Passphrase            *string
+	Salt                  *string
+	ShowAuthors           *bool
+	ShowNotesOfOtherUsers *bool
+	ShowNoteReactions     *bool
+	AllowStacking         *bool
+	TimerPresets          []int `bun:",array,nullzero"`
 }
 
 type BoardTimerUpdate struct {
-	bun.BaseModel `bun:"table:boards"`
-	ID            uuid.UUID
-	TimerStart    *time.Time
-	TimerEnd      *time.Time
+	bun.BaseModel  `bun:"table:boards"`
+	ID             uuid.UUID
+	TimerStart     *time.Time
+	TimerEnd       *time.Time
+	TimerRemaining *int64
+	TimerExpired   bool
 }
 
 type BoardUpdate struct {
@@ -58,6 +71,20 @@ type BoardUpdate struct {
 	TimerEnd              *time.Time
 	SharedNote            uuid.NullUUID
 	ShowVoting            uuid.NullUUID
+	Archived              *bool
+	TimerPresets          []int `bun:",array"`
+	TimerRemaining        *int64
+	TimerExpired          bool
+	Phase                 *uuid.UUID
+
+	// RestartTimer clears the paused or expired state of the timer, so that the timer start and end are announced again.
+	RestartTimer bool `bun:"-"`
+
+	// VisibleColumns sets the visibility of all columns of the board, if not nil. Columns not in this list are hidden.
+	VisibleColumns []uuid.UUID `bun:"-"`
+
+	// OpenVoting creates a new voting, unless there is an open voting on the board already.
+	OpenVoting *VotingInsert `bun:"-"`
 }
 
 func (d *Database) CreateBoard(creator uuid.UUID, board BoardInsert, columns []ColumnInsert) (Board, error) {
@@ -96,10 +123,56 @@ func (d *Database) CreateBoard(creator uuid.UUID, board BoardInsert, columns []C
 
 func (d *Database) UpdateBoardTimer(update BoardTimerUpdate) (Board, error) {
 	var board Board
-	_, err := d.db.NewUpdate().Model(&update).Column("timer_start", "timer_end").Where("id = ?", update.ID).Returning("*").Exec(common.ContextWithValues(context.Background(), "Database", d, "Result", &board), &board)
+	_, err := d.db.NewUpdate().Model(&update).Column("timer_start", "timer_end", "timer_remaining", "timer_expired").Where("id = ?", update.ID).Returning("*").Exec(common.ContextWithValues(context.Background(), "Database", d, "Result", &board), &board)
+	return board, err
+}
+
+// PauseBoardTimer stores the remaining duration of the running timer of the board in milliseconds. The timer start and
+// end are kept, so that the total duration of the timer is preserved on resume.
+func (d *Database) PauseBoardTimer(id uuid.UUID) (Board, error) {
+	var board Board
+	_, err := d.db.NewUpdate().
+		Model(&BoardTimerUpdate{ID: id}).
+		Set("timer_remaining = (EXTRACT(EPOCH FROM timer_end - now()) * 1000)::bigint").
+		Where("id = ?", id).
+		Where("timer_end > now()").
+		Where("timer_remaining IS NULL").
+		Returning("*").
+		Exec(common.ContextWithValues(context.Background(), "Database", d, "Result", &board), &board)
 	return board, err
 }
 
+// ResumeBoardTimer continues the paused timer of the board with its remaining duration.
+func (d *Database) ResumeBoardTimer(id uuid.UUID) (Board, error) {
+	var board Board
+	_, err := d.db.NewUpdate().
+		Model(&BoardTimerUpdate{ID: id}).
+		Set("timer_start = now() + timer_remaining * interval '1 millisecond' - (timer_end - timer_start)").
+		Set("timer_end = now() + timer_remaining * interval '1 millisecond'").
+		Set("timer_remaining = NULL").
+		Set("timer_expired = false").
+		Where("id = ?", id).
+		Where("timer_remaining IS NOT NULL").
+		Returning("*").
+		Exec(common.ContextWithValues(context.Background(), "Database", d, "Result", &board), &board)
+	return board, err
+}
+
+// ExpireBoardTimers marks all running timers whose end has passed as expired and returns the affected boards. Each
+// timer is only returned once, even if multiple instances of the server expire timers concurrently.
+func (d *Database) ExpireBoardTimers() ([]Board, error) {
+	var boards []Board
+	_, err := d.db.NewUpdate().
+		Model((*Board)(nil)).
+		Set("timer_expired = true").
+		Where("timer_end <= now()").
+		Where("timer_remaining IS NULL").
+		Where("timer_expired = false").
+		Returning("*").
+		Exec(context.Background(), &boards)
+	return boards, err
+}
+
 func (d *Database) UpdateBoard(update BoardUpdate) (Board, error) {
 	query := d.db.NewUpdate().Model(&update).Column("timer_start", "timer_end", "shared_note")
 
@@ -133,6 +206,47 @@ func (d *Database) UpdateBoard(update BoardUpdate) (Board, error) {
 	if update.AllowStacking != nil {
 		query.Column("allow_stacking")
 	}
+	if update.Archived != nil {
+		query.Column("archived")
+	}
+	if update.TimerPresets != nil {
+		query.Column("timer_presets")
+	}
+	if update.RestartTimer {
+		query.Column("timer_remaining", "timer_expired")
+	}
+	if update.Phase != nil {
+		query.Column("phase")
+	}
+	if update.VisibleColumns != nil {
+		query.With("columnVisibility", d.db.NewUpdate().
+			Model((*Column)(nil)).
+			Set("visible = (id = ANY(?))", pgdialect.Array(update.VisibleColumns)).
+			Where("board = ?", update.ID))
+	}
+	if update.OpenVoting != nil {
+		if update.OpenVoting.VoteLimit < 0 {
+			return Board{}, errors.New("vote limit shall not be a negative number")
+		} else if update.OpenVoting.VoteLimit >= 100 {
+			return Board{}, errors.New("vote limit shall not be greater than 99")
+		}
+
+		openVotings := d.db.NewSelect().Model((*Voting)(nil)).Where("board = ?", update.ID).Where("status = ?", types.VotingStatusOpen)
+		values := d.db.NewSelect().
+			ColumnExpr("uuid(?) as board", update.ID).
+			ColumnExpr("? as vote_limit", update.OpenVoting.VoteLimit).
+			ColumnExpr("? as show_votes_of_others", update.OpenVoting.ShowVotesOfOthers).
+			ColumnExpr("? as allow_multiple_votes", update.OpenVoting.AllowMultipleVotes).
+			ColumnExpr("?::voting_status as status", types.VotingStatusOpen).
+			Where("NOT EXISTS (?)", openVotings)
+
+		query.With("openedVoting", d.db.NewInsert().
+			Model(update.OpenVoting).
+			TableExpr("(?) AS _values", values).
+			Column("board", "vote_limit", "show_votes_of_others", "allow_multiple_votes", "status"))
+		// the results of previous votings are hidden once a new voting is opened
+		query.Column("show_voting")
+	}
 
 	var board Board
 	var err error
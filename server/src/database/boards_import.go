@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"scrumlr.io/server/database/types"
+)
+
+// ColumnImport the import model for a column with a predefined id
+type ColumnImport struct {
+	bun.BaseModel `bun:"table:columns"`
+	ID            uuid.UUID
+	Board         uuid.UUID
+	Name          string
+	Color         types.Color
+	Visible       bool
+	Index         int
+}
+
+// NoteImport the import model for a note with a predefined id and position
+type NoteImport struct {
+	bun.BaseModel `bun:"table:notes"`
+	ID            uuid.UUID
+	Author        uuid.UUID
+	Board         uuid.UUID
+	Column        uuid.UUID
+	Text          string
+	ContentType   types.NoteContentType `bun:",nullzero"`
+	Links         []types.LinkPreview   `bun:"type:jsonb,nullzero"`
+	Checklist     []types.ChecklistItem `bun:"type:jsonb,nullzero"`
+	Stack         uuid.NullUUID
+	Rank          int
+}
+
+// VotingImport the import model for a voting with a predefined id
+type VotingImport struct {
+	bun.BaseModel      `bun:"table:votings"`
+	ID                 uuid.UUID
+	Board              uuid.UUID
+	VoteLimit          int
+	AllowMultipleVotes bool
+	ShowVotesOfOthers  bool
+	Status             types.VotingStatus
+	Mode               types.VotingMode `bun:",nullzero"`
+	Anonymous          bool
+}
+
+// BoardImport the content of a board to import, with predefined ids to reference the entities among each other
+type BoardImport struct {
+	Board       BoardInsert
+	Sessions    []BoardSessionInsert
+	Columns     []ColumnImport
+	Notes       []NoteImport
+	Votings     []VotingImport
+	Votes       []Vote
+	Assignments []AssignmentInsert
+}
+
+// ImportBoard creates a new board with the specified columns, notes, votings, votes and assignments in a single
+// statement. The creator will be the owner of the board. Sessions, authors and voters of users unknown to this
+// instance are dropped or replaced by the creator.
+func (d *Database) ImportBoard(creator uuid.UUID, data BoardImport) (Board, error) {
+	if data.Board.AccessPolicy == types.AccessPolicyByPassphrase && (data.Board.Passphrase == nil || data.Board.Salt == nil) {
+		return Board{}, errors.New("passphrase or salt may not be empty")
+	} else if data.Board.AccessPolicy != types.AccessPolicyByPassphrase && (data.Board.Passphrase != nil || data.Board.Salt != nil) {
+		return Board{}, errors.New("passphrase or salt should not be set for policies except 'BY_PASSPHRASE'")
+	}
+
+	users := []uuid.UUID{}
+	for _, session := range data.Sessions {
+		users = append(users, session.User)
+	}
+	for _, note := range data.Notes {
+		users = append(users, note.Author)
+	}
+	for _, vote := range data.Votes {
+		users = append(users, vote.User)
+	}
+	knownUsers, err := d.getKnownUsers(users)
+	if err != nil {
+		return Board{}, err
+	}
+
+	importedSessions := []BoardSessionInsert{{User: creator, Role: types.SessionRoleOwner}}
+	for _, session := range data.Sessions {
+		if session.User == creator || !knownUsers[session.User] {
+			continue
+		}
+		if session.Role == types.SessionRoleOwner {
+			session.Role = types.SessionRoleModerator
+		}
+		importedSessions = append(importedSessions, session)
+	}
+	for index := range data.Notes {
+		if !knownUsers[data.Notes[index].Author] {
+			data.Notes[index].Author = creator
+		}
+	}
+	for index := range data.Votes {
+		if !knownUsers[data.Votes[index].User] {
+			data.Votes[index].User = creator
+		}
+	}
+
+	query := d.db.NewSelect().
+		With("createdBoard", d.db.NewInsert().Model(&data.Board).Returning("*")).
+		With("createdSessions", d.db.NewInsert().
+			Model(&importedSessions).
+			Value("board", "(SELECT id FROM \"createdBoard\")"))
+	if len(data.Columns) > 0 {
+		query = query.With("createdColumns", d.db.NewInsert().
+			Model(&data.Columns).
+			Value("board", "(SELECT id FROM \"createdBoard\")"))
+	}
+	if len(data.Notes) > 0 {
+		query = query.With("createdNotes", d.db.NewInsert().
+			Model(&data.Notes).
+			Value("board", "(SELECT id FROM \"createdBoard\")"))
+	}
+	if len(data.Votings) > 0 {
+		query = query.With("createdVotings", d.db.NewInsert().
+			Model(&data.Votings).
+			Value("board", "(SELECT id FROM \"createdBoard\")"))
+	}
+	if len(data.Votes) > 0 {
+		query = query.With("createdVotes", d.db.NewInsert().
+			Model(&data.Votes).
+			Value("board", "(SELECT id FROM \"createdBoard\")"))
+	}
+	if len(data.Assignments) > 0 {
+		query = query.With("createdAssignments", d.db.NewInsert().
+			Model(&data.Assignments).
+			Value("board", "(SELECT id FROM \"createdBoard\")"))
+	}
+
+	var b Board
+	err = query.
+		Table("createdBoard").
+		Column("*").
+		Scan(context.Background(), &b)
+	return b, err
+}
+
+func (d *Database) getKnownUsers(ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	known := map[uuid.UUID]bool{}
+	if len(ids) == 0 {
+		return known, nil
+	}
+
+	var users []uuid.UUID
+	err := d.db.NewSelect().
+		Model((*User)(nil)).
+		Column("id").
+		Where("id IN (?)", bun.In(ids)).
+		Scan(context.Background(), &users)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		known[user] = true
+	}
+	return known, nil
+}
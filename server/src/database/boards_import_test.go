@@ -0,0 +1,92 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
+)
+
+func TestRunnerForBoardImport(t *testing.T) {
+	t.Run("Import=0", testImportBoard)
+	t.Run("Import=1", testImportBoardReplacesUnknownUsers)
+}
+
+func testImportBoard(t *testing.T) {
+	owner := fixture.MustRow("User.jack").(*User)
+	participant := fixture.MustRow("User.jane").(*User)
+
+	column := ColumnImport{ID: uuid.New(), Name: "Column", Color: types.ColorBacklogBlue, Visible: true}
+	parent := NoteImport{ID: uuid.New(), Author: participant.ID, Column: column.ID, Text: "Parent", Rank: 1}
+	child := NoteImport{ID: uuid.New(), Author: owner.ID, Column: column.ID, Text: "Child", Stack: uuid.NullUUID{UUID: parent.ID, Valid: true}}
+	voting := VotingImport{ID: uuid.New(), VoteLimit: 5, AllowMultipleVotes: true, Status: types.VotingStatusClosed}
+
+	board, err := testDb.ImportBoard(owner.ID, BoardImport{
+		Board:       BoardInsert{AccessPolicy: types.AccessPolicyPublic},
+		Sessions:    []BoardSessionInsert{{User: participant.ID, Role: types.SessionRoleOwner}},
+		Columns:     []ColumnImport{column},
+		Notes:       []NoteImport{parent, child},
+		Votings:     []VotingImport{voting},
+		Votes:       []Vote{{Voting: voting.ID, User: participant.ID, Note: parent.ID}, {Voting: voting.ID, User: owner.ID, Note: parent.ID}},
+		Assignments: []AssignmentInsert{{Note: parent.ID, Name: "Jane"}},
+	})
+	assert.Nil(t, err)
+
+	ownerSession, err := testDb.GetBoardSession(board.ID, owner.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, types.SessionRoleOwner, ownerSession.Role)
+
+	participantSession, err := testDb.GetBoardSession(board.ID, participant.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, types.SessionRoleModerator, participantSession.Role)
+
+	notes, err := testDb.GetNotes(board.ID)
+	assert.Nil(t, err)
+	assert.Len(t, notes, 2)
+	for _, note := range notes {
+		assert.Equal(t, column.ID, note.Column)
+		if note.ID == child.ID {
+			assert.Equal(t, parent.ID, note.Stack.UUID)
+		} else {
+			assert.Equal(t, participant.ID, note.Author)
+			assert.Equal(t, 1, note.Rank)
+		}
+	}
+
+	votings, votes, err := testDb.GetVotings(board.ID)
+	assert.Nil(t, err)
+	assert.Len(t, votings, 1)
+	assert.Equal(t, types.VotingStatusClosed, votings[0].Status)
+	assert.Len(t, votes, 2)
+
+	assignments, err := testDb.GetAssignments(board.ID)
+	assert.Nil(t, err)
+	assert.Len(t, assignments, 1)
+	assert.Equal(t, parent.ID, assignments[0].Note)
+}
+
+func testImportBoardReplacesUnknownUsers(t *testing.T) {
+	owner := fixture.MustRow("User.jack").(*User)
+	unknownUser := uuid.New()
+
+	column := ColumnImport{ID: uuid.New(), Name: "Column", Color: types.ColorBacklogBlue, Visible: true}
+	note := NoteImport{ID: uuid.New(), Author: unknownUser, Column: column.ID, Text: "Note"}
+
+	board, err := testDb.ImportBoard(owner.ID, BoardImport{
+		Board:    BoardInsert{AccessPolicy: types.AccessPolicyPublic},
+		Sessions: []BoardSessionInsert{{User: unknownUser, Role: types.SessionRoleParticipant}},
+		Columns:  []ColumnImport{column},
+		Notes:    []NoteImport{note},
+	})
+	assert.Nil(t, err)
+
+	sessions, err := testDb.GetBoardSessions(board.ID)
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 1)
+
+	notes, err := testDb.GetNotes(board.ID)
+	assert.Nil(t, err)
+	assert.Len(t, notes, 1)
+	assert.Equal(t, owner.ID, notes[0].Author)
+}
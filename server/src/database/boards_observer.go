@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
+	"scrumlr.io/server/database/types"
 )
 
 type BoardObserver interface {
@@ -23,13 +24,16 @@ var _ bun.AfterUpdateHook = (*BoardUpdate)(nil)
 var _ bun.AfterUpdateHook = (*BoardTimerUpdate)(nil)
 var _ bun.AfterDeleteHook = (*Board)(nil)
 
-func (*BoardUpdate) AfterUpdate(ctx context.Context, _ *bun.UpdateQuery) error {
+func (update *BoardUpdate) AfterUpdate(ctx context.Context, _ *bun.UpdateQuery) error {
 	if ctx.Value("Database") == nil {
 		return nil
 	}
 	d := ctx.Value("Database").(*Database)
 	if len(d.observer) > 0 {
 		board := ctx.Value("Result").(*Board)
+		if board.ID != uuid.Nil {
+			d.notifyAudit(board.ID, types.AuditEntityBoard, board.ID, "UPDATED", newBoardAuditPayload(*update))
+		}
 		for _, observer := range d.observer {
 			if o, ok := observer.(BoardObserver); ok {
 				o.UpdatedBoard(*board)
@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"scrumlr.io/server/database/types"
 	"testing"
+	"time"
 )
 
 func TestRunnerForBoards(t *testing.T) {
@@ -28,6 +29,12 @@ func TestRunnerForBoards(t *testing.T) {
 	t.Run("Update=8", testChangeInviteBoardToPublicBoardShouldFail)
 	t.Run("Update=9", testUpdateBoardName)
 	t.Run("Update=10", testUpdateBoardSettings)
+	t.Run("Update=11", testArchiveBoard)
+	t.Run("Update=12", testUpdateBoardTimerPresets)
+
+	t.Run("Timer=0", testPauseAndResumeBoardTimer)
+	t.Run("Timer=1", testPauseBoardWithoutRunningTimerShouldFail)
+	t.Run("Timer=2", testExpireBoardTimers)
 
 	t.Run("Get=0", testGetBoard)
 
@@ -447,6 +454,106 @@ func testUpdateBoardSettings(t *testing.T) {
 	assert.Equal(t, showNotesOfOtherUsers, updatedBoard.ShowNotesOfOtherUsers)
 }
 
+func testArchiveBoard(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{
+		Name:         nil,
+		AccessPolicy: types.AccessPolicyPublic,
+		Passphrase:   nil,
+		Salt:         nil,
+	}, []ColumnInsert{})
+
+	assert.Nil(t, err)
+	assert.False(t, board.Archived)
+
+	archived := true
+	updatedBoard, err := testDb.UpdateBoard(BoardUpdate{ID: board.ID, Archived: &archived})
+
+	assert.Nil(t, err)
+	assert.True(t, updatedBoard.Archived)
+}
+
+func testUpdateBoardTimerPresets(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{})
+	assert.Nil(t, err)
+	assert.Empty(t, board.TimerPresets)
+
+	updatedBoard, err := testDb.UpdateBoard(BoardUpdate{ID: board.ID, TimerPresets: []int{5, 10, 15}})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{5, 10, 15}, updatedBoard.TimerPresets)
+}
+
+func testPauseAndResumeBoardTimer(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{})
+	assert.Nil(t, err)
+
+	timerStart := time.Now()
+	timerEnd := timerStart.Add(10 * time.Minute)
+	_, err = testDb.UpdateBoardTimer(BoardTimerUpdate{ID: board.ID, TimerStart: &timerStart, TimerEnd: &timerEnd})
+	assert.Nil(t, err)
+
+	pausedBoard, err := testDb.PauseBoardTimer(board.ID)
+	assert.Nil(t, err)
+	assert.NotNil(t, pausedBoard.TimerRemaining)
+	assert.InDelta(t, (10 * time.Minute).Milliseconds(), *pausedBoard.TimerRemaining, float64(time.Minute.Milliseconds()))
+
+	_, err = testDb.PauseBoardTimer(board.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	resumedBoard, err := testDb.ResumeBoardTimer(board.ID)
+	assert.Nil(t, err)
+	assert.Nil(t, resumedBoard.TimerRemaining)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), *resumedBoard.TimerEnd, time.Minute)
+	assert.Equal(t, 10*time.Minute, resumedBoard.TimerEnd.Sub(*resumedBoard.TimerStart).Round(time.Second))
+}
+
+func testPauseBoardWithoutRunningTimerShouldFail(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{})
+	assert.Nil(t, err)
+
+	_, err = testDb.PauseBoardTimer(board.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	_, err = testDb.ResumeBoardTimer(board.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func testExpireBoardTimers(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{})
+	assert.Nil(t, err)
+
+	timerStart := time.Now().Add(-10 * time.Minute)
+	timerEnd := time.Now().Add(-time.Minute)
+	_, err = testDb.UpdateBoardTimer(BoardTimerUpdate{ID: board.ID, TimerStart: &timerStart, TimerEnd: &timerEnd})
+	assert.Nil(t, err)
+
+	expiredBoards, err := testDb.ExpireBoardTimers()
+	assert.Nil(t, err)
+	expired := false
+	for _, expiredBoard := range expiredBoards {
+		if expiredBoard.ID == board.ID {
+			expired = expiredBoard.TimerExpired
+		}
+	}
+	assert.True(t, expired)
+
+	// expired timers are only returned once
+	expiredBoards, err = testDb.ExpireBoardTimers()
+	assert.Nil(t, err)
+	for _, expiredBoard := range expiredBoards {
+		assert.NotEqual(t, board.ID, expiredBoard.ID)
+	}
+}
+
 func testGetBoard(t *testing.T) {
 	board := fixture.MustRow("Board.boardTestBoard").(*Board)
 
@@ -2,12 +2,14 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 	"math"
 	"scrumlr.io/server/common"
 	"scrumlr.io/server/database/types"
+	"time"
 )
 
 // Column the model for a column of a board
@@ -19,6 +21,15 @@ type Column struct {
 	Color         types.Color
 	Visible       bool
 	Index         int
+	DeletedAt     *time.Time `bun:",soft_delete"`
+}
+
+// ColumnDeletion the model to soft delete a column. Deleted columns keep their index, so that they can be restored until
+// they are purged.
+type ColumnDeletion struct {
+	bun.BaseModel `bun:"table:columns"`
+	ID            uuid.UUID
+	Board         uuid.UUID
 }
 
 // ColumnInsert the insert model for a new Column
@@ -108,13 +119,14 @@ func (d *Database) UpdateColumn(column ColumnUpdate) (Column, error) {
 		Model(&column).
 		Value("index", fmt.Sprintf("LEAST((SELECT COUNT(*) FROM \"maxIndexSelect\")-1, %d)", newIndex)).
 		Where("id = ?", column.ID).
+		Where("deleted_at IS NULL").
 		Returning("*").
 		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", column.Board), &c)
 
 	return c, err
 }
 
-// DeleteColumn deletes a column and adapts all indices of the other columns.
+// DeleteColumn deletes a column together with its notes and adapts all indices of the other columns.
 func (d *Database) DeleteColumn(board, column, user uuid.UUID) error {
 	var columns []Column
 	selectPreviousIndex := d.db.NewSelect().Model((*Column)(nil)).Column("index", "board").Where("id = ?", column)
@@ -127,11 +139,20 @@ func (d *Database) DeleteColumn(board, column, user uuid.UUID) error {
 		Model((*Board)(nil)).
 		Set("shared_note = null").
 		Where("id = ? AND (SELECT \"column\" FROM notes WHERE id = (SELECT shared_note FROM boards WHERE id = ?)) = ?", board, board, column)
-	_, err := d.db.NewDelete().
+	// the notes are deleted together with the column, so that they are restored together as well
+	notesDeletion := d.db.NewUpdate().
+		Model((*Note)(nil)).
+		Set("deleted_at = now()").
+		Where("board = ?", board).
+		Where("\"column\" = ?", column)
+	_, err := d.db.NewUpdate().
 		With("boardUpdate", boardUpdate).
 		With("indexUpdate", indexUpdate).
-		Model((*Column)(nil)).
+		With("notesDeletion", notesDeletion).
+		Model((*ColumnDeletion)(nil)).
+		Set("deleted_at = now()").
 		Where("id = ?", column).
+		Where("deleted_at IS NULL").
 		Returning("*").
 		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", board, "Column", column, "User", user, "Result", &columns), &columns)
 
@@ -151,3 +172,60 @@ func (d *Database) GetColumns(board uuid.UUID) ([]Column, error) {
 	err := d.db.NewSelect().Model(&columns).Where("board = ?", board).Order("index ASC").Scan(context.Background())
 	return columns, err
 }
+
+// RestoreColumn restores a deleted column at its previous index together with the notes, which were deleted along with
+// it.
+func (d *Database) RestoreColumn(board, column uuid.UUID) (Column, error) {
+	selectPrevious := d.db.NewSelect().Model((*Column)(nil)).WhereDeleted().Where("id = ?", column).Where("board = ?", board)
+	maxIndexSelect := d.db.NewSelect().Model((*Column)(nil)).ColumnExpr("COUNT(*) as index").Where("board = ?", board)
+	indexSelection := d.db.NewSelect().ColumnExpr("LEAST((SELECT index FROM \"selectPrevious\"), (SELECT index FROM \"maxIndexSelect\")) as index")
+	indexUpdate := d.db.NewUpdate().
+		Model((*Column)(nil)).
+		Set("index = index+1").
+		Where("board = ?", board).
+		Where("EXISTS (SELECT 1 FROM \"selectPrevious\")").
+		Where("index >= (SELECT index FROM \"indexSelection\")")
+	notesRestoration := d.db.NewUpdate().
+		Model((*Note)(nil)).
+		WhereDeleted().
+		Set("deleted_at = NULL").
+		Where("board = ?", board).
+		Where("\"column\" = ?", column).
+		Where("deleted_at = (SELECT deleted_at FROM \"selectPrevious\")")
+
+	var columns []Column
+	_, err := d.db.NewUpdate().
+		With("selectPrevious", selectPrevious).
+		With("maxIndexSelect", maxIndexSelect).
+		With("indexSelection", indexSelection).
+		With("indexUpdate", indexUpdate).
+		With("notesRestoration", notesRestoration).
+		Model((*ColumnUpdate)(nil)).
+		Set("deleted_at = NULL").
+		Set("index = (SELECT index FROM \"indexSelection\")").
+		Where("id = (SELECT id FROM \"selectPrevious\")").
+		Returning("*").
+		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", board), &columns)
+	if err != nil {
+		return Column{}, err
+	}
+	if len(columns) == 0 {
+		return Column{}, sql.ErrNoRows
+	}
+	return columns[0], nil
+}
+
+// PurgeDeletedColumns removes all columns, which have been deleted before the specified time, for good. The notes of
+// these columns are removed as well.
+func (d *Database) PurgeDeletedColumns(before time.Time) (int64, error) {
+	result, err := d.db.NewDelete().
+		Model((*Column)(nil)).
+		WhereDeleted().
+		Where("deleted_at < ?", before).
+		ForceDelete().
+		Exec(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
 )
 
 type ColumnsObserver interface {
@@ -17,7 +18,7 @@ type ColumnsObserver interface {
 
 var _ bun.AfterInsertHook = (*ColumnInsert)(nil)
 var _ bun.AfterUpdateHook = (*ColumnUpdate)(nil)
-var _ bun.AfterDeleteHook = (*Column)(nil)
+var _ bun.AfterUpdateHook = (*ColumnDeletion)(nil)
 
 func (*ColumnInsert) AfterInsert(ctx context.Context, _ *bun.InsertQuery) error {
 	return notifyColumnsUpdated(ctx)
@@ -27,7 +28,7 @@ func (*ColumnUpdate) AfterUpdate(ctx context.Context, _ *bun.UpdateQuery) error
 	return notifyColumnsUpdated(ctx)
 }
 
-func (*Column) AfterDelete(ctx context.Context, _ *bun.DeleteQuery) error {
+func (*ColumnDeletion) AfterUpdate(ctx context.Context, _ *bun.UpdateQuery) error {
 	result := ctx.Value("Result").(*[]Column)
 	if len(*result) > 0 {
 		return notifyColumnDeleted(ctx)
@@ -70,6 +71,7 @@ func notifyColumnDeleted(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		d.notifyAudit(board, types.AuditEntityColumn, column, "DELETED", nil)
 		for _, observer := range d.observer {
 			if o, ok := observer.(ColumnsObserver); ok {
 				o.DeletedColumn(user, board, column, notes, votes)
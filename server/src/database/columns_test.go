@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"scrumlr.io/server/database/types"
@@ -42,6 +43,10 @@ func TestRunnerForColumns(t *testing.T) {
 	t.Run("Delete=4", testDeleteColumnContainingSharedNote)
 	t.Run("Delete=5", testDeleteOthers)
 
+	t.Run("Restore=0", testRestoreColumnOnFirstIndex)
+	t.Run("Restore=1", testRestoreColumnWithNotes)
+	t.Run("Restore=2", testRestoreColumnNotDeletedShouldFail)
+
 	t.Run("Update=0", testUpdateName)
 	t.Run("Update=1", testUpdateColor)
 	t.Run("Update=2", testUpdateVisibility)
@@ -234,6 +239,39 @@ func testDeleteOthers(t *testing.T) {
 	verifyOrder(t, firstColumn.ID, secondColumn.ID, thirdColumn.ID)
 }
 
+func testRestoreColumnOnFirstIndex(t *testing.T) {
+	column, err := testDb.RestoreColumn(boardForColumnsTest, columnInsertedFirst.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, column.Index)
+
+	verifyOrder(t, columnInsertedFirst.ID, firstColumn.ID, secondColumn.ID, thirdColumn.ID)
+
+	_ = testDb.DeleteColumn(boardForColumnsTest, columnInsertedFirst.ID, columnTestUser.ID)
+	verifyOrder(t, firstColumn.ID, secondColumn.ID, thirdColumn.ID)
+}
+
+func testRestoreColumnWithNotes(t *testing.T) {
+	notes, _ := testDb.GetNotes(boardForColumnsTest, columnInsertedSecond.ID)
+	assert.Equal(t, 0, len(notes))
+
+	column, err := testDb.RestoreColumn(boardForColumnsTest, columnInsertedSecond.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, column.Index)
+
+	verifyOrder(t, firstColumn.ID, secondColumn.ID, thirdColumn.ID, columnInsertedSecond.ID)
+
+	notes, _ = testDb.GetNotes(boardForColumnsTest, columnInsertedSecond.ID)
+	assert.Equal(t, 1, len(notes))
+
+	_ = testDb.DeleteColumn(boardForColumnsTest, columnInsertedSecond.ID, columnTestUser.ID)
+	verifyOrder(t, firstColumn.ID, secondColumn.ID, thirdColumn.ID)
+}
+
+func testRestoreColumnNotDeletedShouldFail(t *testing.T) {
+	_, err := testDb.RestoreColumn(boardForColumnsTest, firstColumn.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
 func testUpdateName(t *testing.T) {
 	column, err := testDb.UpdateColumn(ColumnUpdate{
 		ID:      firstColumn.ID,
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"scrumlr.io/server/common"
+)
+
+// Comment the model for a remark on a note
+type Comment struct {
+	bun.BaseModel `bun:"table:comments"`
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	Note          uuid.UUID
+	Board         uuid.UUID
+	Author        uuid.UUID
+	Text          string
+	Edited        bool
+}
+
+// CommentInsert the insert model for a new Comment
+type CommentInsert struct {
+	bun.BaseModel `bun:"table:comments"`
+	Note          uuid.UUID
+	Board         uuid.UUID
+	Author        uuid.UUID
+	Text          string
+}
+
+// CommentUpdate the update model for the text of a Comment
+type CommentUpdate struct {
+	bun.BaseModel `bun:"table:comments"`
+	ID            uuid.UUID
+	Board         uuid.UUID
+	Text          string
+}
+
+// CreateComment creates a new comment on a note.
+func (d *Database) CreateComment(insert CommentInsert) (Comment, error) {
+	var comment Comment
+	_, err := d.db.NewInsert().
+		Model(&insert).
+		Returning("*").
+		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", insert.Board), &comment)
+	return comment, err
+}
+
+// UpdateComment updates the text of the comment and marks it as edited.
+func (d *Database) UpdateComment(update CommentUpdate) (Comment, error) {
+	var comment Comment
+	_, err := d.db.NewUpdate().
+		Model(&update).
+		Set("text = ?", update.Text).
+		Set("edited = true").
+		Where("id = ?", update.ID).
+		Where("board = ?", update.Board).
+		Returning("*").
+		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", update.Board), &comment)
+	return comment, err
+}
+
+// DeleteComment deletes the comment.
+func (d *Database) DeleteComment(board, id uuid.UUID) error {
+	_, err := d.db.NewDelete().
+		Model((*Comment)(nil)).
+		Where("id = ?", id).
+		Where("board = ?", board).
+		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", board))
+	return err
+}
+
+// GetComment returns the comment for the specified id.
+func (d *Database) GetComment(board, id uuid.UUID) (Comment, error) {
+	var comment Comment
+	err := d.db.NewSelect().Model(&comment).Where("board = ?", board).Where("id = ?", id).Scan(context.Background())
+	return comment, err
+}
+
+// GetComments returns the comments of the board in the order of their creation, optionally limited to the specified
+// notes. Comments of deleted notes are omitted.
+func (d *Database) GetComments(board uuid.UUID, notes ...uuid.UUID) ([]Comment, error) {
+	query := d.db.NewSelect().
+		Model((*Comment)(nil)).
+		Where("board = ?", board).
+		Where("note IN (?)", d.db.NewSelect().Model((*Note)(nil)).Column("id").Where("board = ?", board))
+	if len(notes) > 0 {
+		query = query.Where("note IN (?)", bun.In(notes))
+	}
+
+	var comments []Comment
+	err := query.Order("created_at ASC").Scan(context.Background(), &comments)
+	return comments, err
+}
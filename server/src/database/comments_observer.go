@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type CommentsObserver interface {
+	Observer
+
+	// UpdatedComments will be called if the comments of the board with the specified id were updated.
+	UpdatedComments(board uuid.UUID, comments []Comment)
+}
+
+var _ bun.AfterInsertHook = (*CommentInsert)(nil)
+var _ bun.AfterUpdateHook = (*CommentUpdate)(nil)
+var _ bun.AfterDeleteHook = (*Comment)(nil)
+
+func (*CommentInsert) AfterInsert(ctx context.Context, _ *bun.InsertQuery) error {
+	return notifyCommentsUpdated(ctx)
+}
+
+func (*CommentUpdate) AfterUpdate(ctx context.Context, _ *bun.UpdateQuery) error {
+	return notifyCommentsUpdated(ctx)
+}
+
+func (*Comment) AfterDelete(ctx context.Context, _ *bun.DeleteQuery) error {
+	return notifyCommentsUpdated(ctx)
+}
+
+func notifyCommentsUpdated(ctx context.Context) error {
+	if ctx.Value("Database") == nil {
+		return nil
+	}
+	d := ctx.Value("Database").(*Database)
+	if len(d.observer) > 0 {
+		board := ctx.Value("Board").(uuid.UUID)
+		comments, err := d.GetComments(board)
+		if err != nil {
+			return err
+		}
+		for _, observer := range d.observer {
+			if o, ok := observer.(CommentsObserver); ok {
+				o.UpdatedComments(board, comments)
+				return nil
+			}
+		}
+	}
+	return nil
+}
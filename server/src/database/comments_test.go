@@ -0,0 +1,100 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
+)
+
+func TestRunnerForComments(t *testing.T) {
+	t.Run("Create=0", testCreateComment)
+	t.Run("Create=1", testCreateCommentWithEmptyTextShouldFail)
+	t.Run("Update=0", testUpdateComment)
+	t.Run("Delete=0", testDeleteComment)
+	t.Run("Get=0", testGetCommentsOmitsDeletedNotes)
+}
+
+func createCommentTestBoard(t *testing.T) (Board, Note) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{
+		{Name: "Went well", Color: types.ColorBacklogBlue},
+	})
+	assert.Nil(t, err)
+	columns, err := testDb.GetColumns(board.ID)
+	assert.Nil(t, err)
+
+	note, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "Discuss me"})
+	assert.Nil(t, err)
+	return board, note
+}
+
+func testCreateComment(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, note := createCommentTestBoard(t)
+
+	comment, err := testDb.CreateComment(CommentInsert{Note: note.ID, Board: board.ID, Author: user.ID, Text: "A remark"})
+	assert.Nil(t, err)
+	assert.Equal(t, "A remark", comment.Text)
+	assert.False(t, comment.Edited)
+
+	second, err := testDb.CreateComment(CommentInsert{Note: note.ID, Board: board.ID, Author: user.ID, Text: "Another remark"})
+	assert.Nil(t, err)
+
+	comments, err := testDb.GetComments(board.ID, note.ID)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, comment.ID, comments[0].ID)
+	assert.Equal(t, second.ID, comments[1].ID)
+}
+
+func testCreateCommentWithEmptyTextShouldFail(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, note := createCommentTestBoard(t)
+
+	_, err := testDb.CreateComment(CommentInsert{Note: note.ID, Board: board.ID, Author: user.ID, Text: ""})
+	assert.NotNil(t, err)
+}
+
+func testUpdateComment(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, note := createCommentTestBoard(t)
+
+	comment, err := testDb.CreateComment(CommentInsert{Note: note.ID, Board: board.ID, Author: user.ID, Text: "A remark"})
+	assert.Nil(t, err)
+
+	updated, err := testDb.UpdateComment(CommentUpdate{ID: comment.ID, Board: board.ID, Text: "A changed remark"})
+	assert.Nil(t, err)
+	assert.Equal(t, "A changed remark", updated.Text)
+	assert.True(t, updated.Edited)
+}
+
+func testDeleteComment(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, note := createCommentTestBoard(t)
+
+	comment, err := testDb.CreateComment(CommentInsert{Note: note.ID, Board: board.ID, Author: user.ID, Text: "A remark"})
+	assert.Nil(t, err)
+
+	err = testDb.DeleteComment(board.ID, comment.ID)
+	assert.Nil(t, err)
+
+	comments, err := testDb.GetComments(board.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, comments)
+}
+
+func testGetCommentsOmitsDeletedNotes(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, note := createCommentTestBoard(t)
+
+	_, err := testDb.CreateComment(CommentInsert{Note: note.ID, Board: board.ID, Author: user.ID, Text: "A remark"})
+	assert.Nil(t, err)
+
+	err = testDb.DeleteNote(user.ID, board.ID, note.ID, false)
+	assert.Nil(t, err)
+
+	comments, err := testDb.GetComments(board.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, comments)
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"runtime"
 
@@ -14,6 +15,12 @@ import (
 type Database struct {
 	db       *bun.DB
 	observer []Observer
+
+	// actor is the user reported as actor of changes to the audit observers
+	actor uuid.NullUUID
+
+	// instance identifies this instance of the server, to which the connections of the board sessions are counted
+	instance uuid.UUID
 }
 
 // New creates a new instance of Database
@@ -21,6 +28,7 @@ func New(db *sql.DB, verbose bool) *Database {
 	d := new(Database)
 	d.db = bun.NewDB(db, pgdialect.New())
 	d.observer = []Observer{}
+	d.instance = uuid.New()
 
 	// configuration of database
 	maxOpenConnections := 4 * runtime.GOMAXPROCS(0)
@@ -34,7 +42,20 @@ func New(db *sql.DB, verbose bool) *Database {
 	return d
 }
 
-func (d *Database) Get(id uuid.UUID) (Board, []BoardSessionRequest, []BoardSession, []Column, []Note, []Reaction, []Voting, []Vote, []Assignment, error) {
+// WithActor returns a copy of the database, which reports the user of the request context as actor of all changes to
+// the audit observers. Changes made without a user in the context are reported without an actor.
+func (d *Database) WithActor(ctx context.Context) *Database {
+	actor, ok := ctx.Value("User").(uuid.UUID)
+	if !ok {
+		return d
+	}
+
+	c := *d
+	c.actor = uuid.NullUUID{UUID: actor, Valid: true}
+	return &c
+}
+
+func (d *Database) Get(id uuid.UUID) (Board, []BoardSessionRequest, []BoardSession, []Column, []Note, []Reaction, []Voting, []Vote, []Assignment, []Comment, error) {
 	var board Board
 	var sessions []BoardSession
 	var requests []BoardSessionRequest
@@ -44,47 +65,53 @@ func (d *Database) Get(id uuid.UUID) (Board, []BoardSessionRequest, []BoardSessi
 	var votings []Voting
 	var votes []Vote
 	var assignments []Assignment
+	var comments []Comment
 	var err error
 
 	board, err = d.GetBoard(id)
 	if err != nil {
-		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	requests, err = d.GetBoardSessionRequests(id)
 	if err != nil {
-		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	sessions, err = d.GetBoardSessions(id)
 	if err != nil {
-		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	columns, err = d.GetColumns(id)
 	if err != nil {
-		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	notes, err = d.GetNotes(id)
 	if err != nil {
-		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	reactions, err = d.GetReactions(id)
 	if err != nil {
-		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	votings, votes, err = d.GetVotings(id)
 	if err != nil {
-		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	assignments, err = d.GetAssignments(id)
 	if err != nil {
-		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+
+	comments, err = d.GetComments(id)
+	if err != nil {
+		return Board{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
-	return board, requests, sessions, columns, notes, reactions, votings, votes, assignments, err
+	return board, requests, sessions, columns, notes, reactions, votings, votes, assignments, comments, err
 }
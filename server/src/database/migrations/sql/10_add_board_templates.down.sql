@@ -0,0 +1,2 @@
+drop table if exists column_templates;
+drop table if exists board_templates;
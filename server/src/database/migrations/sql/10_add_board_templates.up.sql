@@ -0,0 +1,26 @@
+create table board_templates
+(
+    id                        uuid                 default gen_random_uuid() not null primary key,
+    created_at                timestamptz not null default now(),
+    "creator"                 uuid        not null references users ON DELETE CASCADE,
+    "name"                    varchar(128) not null,
+    check ("name" <> ''),
+    description               varchar(256),
+    show_authors              boolean     not null DEFAULT true,
+    show_notes_of_other_users boolean     not null DEFAULT true,
+    show_note_reactions       boolean     not null DEFAULT true,
+    allow_stacking            boolean     not null DEFAULT true
+);
+create index board_templates_creator_index on board_templates (creator);
+
+create table column_templates
+(
+    id               uuid                  default gen_random_uuid() not null primary key,
+    "board_template" uuid         not null references board_templates ON DELETE CASCADE,
+    name             varchar(128) not null,
+    check (name <> ''),
+    color            color        not null default 'backlog-blue',
+    "visible"        boolean      not null DEFAULT false,
+    "index"          int          not null DEFAULT 0
+);
+create index column_templates_board_template_index on column_templates (board_template);
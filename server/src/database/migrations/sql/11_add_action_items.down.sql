@@ -0,0 +1,2 @@
+drop table if exists action_items;
+drop type if exists action_item_status;
@@ -0,0 +1,16 @@
+create type action_item_status as enum ('OPEN', 'IN_PROGRESS', 'DONE');
+
+create table action_items
+(
+    id         uuid                        default gen_random_uuid() not null primary key,
+    created_at timestamptz        not null default now(),
+    "owner"    uuid               not null references users ON DELETE CASCADE,
+    "board"    uuid               references boards ON DELETE SET NULL,
+    "note"     uuid               references notes ON DELETE SET NULL,
+    text       varchar(2048)      not null,
+    check (text <> ''),
+    due_date   timestamptz,
+    "status"   action_item_status not null default 'OPEN'
+);
+create index action_items_owner_index on action_items ("owner");
+create index action_items_board_index on action_items (board);
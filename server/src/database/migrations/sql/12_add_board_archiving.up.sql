@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS boards ADD COLUMN IF NOT EXISTS archived boolean NOT NULL DEFAULT false;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS boards_timer_end_index;
+
+ALTER TABLE IF EXISTS boards DROP COLUMN IF EXISTS timer_presets;
+ALTER TABLE IF EXISTS boards DROP COLUMN IF EXISTS timer_expired;
+ALTER TABLE IF EXISTS boards DROP COLUMN IF EXISTS timer_remaining;
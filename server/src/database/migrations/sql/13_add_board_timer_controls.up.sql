@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS boards ADD COLUMN IF NOT EXISTS timer_remaining bigint;
+ALTER TABLE IF EXISTS boards ADD COLUMN IF NOT EXISTS timer_expired boolean NOT NULL DEFAULT false;
+ALTER TABLE IF EXISTS boards ADD COLUMN IF NOT EXISTS timer_presets integer[] NOT NULL DEFAULT '{}';
+
+-- timers which ended before the expiry event was introduced are not announced anymore
+UPDATE boards SET timer_expired = true WHERE timer_end <= now();
+
+CREATE INDEX IF NOT EXISTS boards_timer_end_index ON boards(timer_end) WHERE timer_end IS NOT NULL AND timer_expired = false;
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS boards DROP COLUMN IF EXISTS phase;
+
+drop table if exists board_phases;
@@ -0,0 +1,20 @@
+create table board_phases
+(
+    id                        uuid                  default gen_random_uuid() not null primary key,
+    "board"                   uuid         not null references boards ON DELETE CASCADE,
+    "index"                   int          not null DEFAULT 0,
+    "name"                    varchar(64)  not null,
+    check ("name" <> ''),
+    show_authors              boolean,
+    show_notes_of_other_users boolean,
+    show_note_reactions       boolean,
+    allow_stacking            boolean,
+    visible_columns           uuid[],
+    vote_limit                int,
+    allow_multiple_votes      boolean      not null DEFAULT false,
+    show_votes_of_others      boolean      not null DEFAULT false,
+    timer_minutes             int
+);
+create index board_phases_board_index on board_phases (board);
+
+ALTER TABLE IF EXISTS boards ADD COLUMN IF NOT EXISTS phase uuid references board_phases ON DELETE SET NULL;
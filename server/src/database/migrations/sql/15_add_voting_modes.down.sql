@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS votes DROP COLUMN IF EXISTS "rank";
+ALTER TABLE IF EXISTS votes DROP COLUMN IF EXISTS weight;
+
+ALTER TABLE IF EXISTS votings DROP COLUMN IF EXISTS anonymous;
+ALTER TABLE IF EXISTS votings DROP COLUMN IF EXISTS "mode";
+
+drop type if exists voting_mode;
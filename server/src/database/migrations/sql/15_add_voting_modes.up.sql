@@ -0,0 +1,7 @@
+create type voting_mode as enum ('DOT', 'WEIGHTED', 'RANKED');
+
+ALTER TABLE IF EXISTS votings ADD COLUMN IF NOT EXISTS "mode" voting_mode NOT NULL DEFAULT 'DOT';
+ALTER TABLE IF EXISTS votings ADD COLUMN IF NOT EXISTS anonymous boolean NOT NULL DEFAULT false;
+
+ALTER TABLE IF EXISTS votes ADD COLUMN IF NOT EXISTS weight int NOT NULL DEFAULT 1 CHECK (weight > 0);
+ALTER TABLE IF EXISTS votes ADD COLUMN IF NOT EXISTS "rank" int;
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS votings DROP COLUMN IF EXISTS max_votes_per_note;
+ALTER TABLE IF EXISTS votings DROP COLUMN IF EXISTS prevent_self_votes;
+ALTER TABLE IF EXISTS votings DROP COLUMN IF EXISTS "columns";
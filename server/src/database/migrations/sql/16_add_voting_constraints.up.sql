@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS votings ADD COLUMN IF NOT EXISTS "columns" uuid[];
+ALTER TABLE IF EXISTS votings ADD COLUMN IF NOT EXISTS prevent_self_votes boolean NOT NULL DEFAULT false;
+ALTER TABLE IF EXISTS votings ADD COLUMN IF NOT EXISTS max_votes_per_note int CHECK (max_votes_per_note > 0);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS votings_closes_at_index;
+
+ALTER TABLE IF EXISTS votings DROP COLUMN IF EXISTS closes_at;
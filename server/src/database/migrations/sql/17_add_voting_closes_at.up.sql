@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS votings ADD COLUMN IF NOT EXISTS closes_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS votings_closes_at_index ON votings(closes_at) WHERE closes_at IS NOT NULL AND status = 'OPEN';
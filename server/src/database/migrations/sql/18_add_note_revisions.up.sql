@@ -0,0 +1,13 @@
+create table note_revisions
+(
+    id         uuid                   default gen_random_uuid() not null primary key,
+    created_at timestamptz   not null DEFAULT now(),
+    "note"     uuid          not null references notes ON DELETE CASCADE,
+    "board"    uuid          not null references boards ON DELETE CASCADE,
+    "editor"   uuid          references users ON DELETE SET NULL,
+    text       varchar(2048) not null,
+    "column"   uuid          not null,
+    "stack"    uuid,
+    "rank"     int           not null
+);
+create index note_revisions_note_index on note_revisions (note, created_at);
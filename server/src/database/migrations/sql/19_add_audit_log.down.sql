@@ -0,0 +1,3 @@
+drop table if exists audit_log;
+
+drop type if exists audit_entity;
@@ -0,0 +1,14 @@
+create type audit_entity as enum ('BOARD', 'SESSION', 'SESSION_REQUEST', 'COLUMN', 'VOTING');
+
+create table audit_log
+(
+    id          uuid                  default gen_random_uuid() not null primary key,
+    created_at  timestamptz  not null DEFAULT now(),
+    "board"     uuid         not null references boards ON DELETE CASCADE,
+    "actor"     uuid         references users ON DELETE SET NULL,
+    entity_type audit_entity not null,
+    "entity"    uuid         not null,
+    "action"    varchar(32)  not null,
+    payload     jsonb
+);
+create index audit_log_board_index on audit_log (board, created_at);
@@ -0,0 +1,8 @@
+DELETE FROM notes WHERE deleted_at IS NOT NULL;
+DELETE FROM columns WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS notes_deleted_at_index;
+DROP INDEX IF EXISTS columns_deleted_at_index;
+
+ALTER TABLE IF EXISTS notes DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE IF EXISTS columns DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS notes ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE IF EXISTS columns ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS notes_deleted_at_index ON notes(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS columns_deleted_at_index ON columns(deleted_at) WHERE deleted_at IS NOT NULL;
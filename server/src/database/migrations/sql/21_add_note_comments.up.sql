@@ -0,0 +1,13 @@
+create table comments
+(
+    id         uuid                   default gen_random_uuid() not null primary key,
+    created_at timestamptz   not null DEFAULT now(),
+    "note"     uuid          not null references notes ON DELETE CASCADE,
+    "board"    uuid          not null references boards ON DELETE CASCADE,
+    "author"   uuid          not null references users ON DELETE CASCADE,
+    text       varchar(2048) not null,
+    edited     boolean       not null DEFAULT false,
+    check (text <> '')
+);
+create index comments_note_index on comments (note, created_at);
+create index comments_board_index on comments (board);
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS notes DROP COLUMN IF EXISTS checklist;
+ALTER TABLE IF EXISTS notes DROP COLUMN IF EXISTS links;
+ALTER TABLE IF EXISTS notes DROP COLUMN IF EXISTS content_type;
+
+drop type if exists note_content_type;
@@ -0,0 +1,5 @@
+create type note_content_type as enum ('PLAIN', 'MARKDOWN', 'CHECKLIST');
+
+ALTER TABLE IF EXISTS notes ADD COLUMN IF NOT EXISTS content_type note_content_type NOT NULL DEFAULT 'PLAIN';
+ALTER TABLE IF EXISTS notes ADD COLUMN IF NOT EXISTS links jsonb;
+ALTER TABLE IF EXISTS notes ADD COLUMN IF NOT EXISTS checklist jsonb;
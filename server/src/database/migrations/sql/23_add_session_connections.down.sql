@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS board_sessions DROP COLUMN IF EXISTS connections;
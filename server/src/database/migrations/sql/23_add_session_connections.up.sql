@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS board_sessions ADD COLUMN IF NOT EXISTS connections int NOT NULL DEFAULT 0;
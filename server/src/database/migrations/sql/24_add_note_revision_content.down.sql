@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS note_revisions DROP COLUMN IF EXISTS checklist;
+ALTER TABLE IF EXISTS note_revisions DROP COLUMN IF EXISTS links;
+ALTER TABLE IF EXISTS note_revisions DROP COLUMN IF EXISTS content_type;
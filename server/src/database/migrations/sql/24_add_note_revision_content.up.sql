@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS note_revisions ADD COLUMN IF NOT EXISTS content_type note_content_type NOT NULL DEFAULT 'PLAIN';
+ALTER TABLE IF EXISTS note_revisions ADD COLUMN IF NOT EXISTS links jsonb;
+ALTER TABLE IF EXISTS note_revisions ADD COLUMN IF NOT EXISTS checklist jsonb;
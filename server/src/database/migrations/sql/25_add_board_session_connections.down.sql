@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS board_session_connections;
+
+ALTER TABLE IF EXISTS board_sessions ADD COLUMN IF NOT EXISTS connections int NOT NULL DEFAULT 0;
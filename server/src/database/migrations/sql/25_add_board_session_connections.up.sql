@@ -0,0 +1,15 @@
+ALTER TABLE IF EXISTS board_sessions DROP COLUMN IF EXISTS connections;
+
+create table board_session_connections
+(
+    "user"        uuid        not null,
+    "board"       uuid        not null,
+    "instance"    uuid        not null,
+    "connections" int         not null DEFAULT 0,
+    "heartbeat"   timestamptz not null DEFAULT now(),
+    PRIMARY KEY ("user", board, instance),
+    FOREIGN KEY ("user", board) REFERENCES board_sessions ON DELETE CASCADE
+);
+create index board_session_connections_heartbeat_index on board_session_connections (heartbeat);
+
+UPDATE board_sessions SET connected = false WHERE connected;
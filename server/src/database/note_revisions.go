@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"scrumlr.io/server/database/types"
+)
+
+// NoteRevision the model for a previous state of a note. A revision is written whenever the text, the content or the
+// position of a note is changed, whereas the editor is the user who replaced this state.
+type NoteRevision struct {
+	bun.BaseModel `bun:"table:note_revisions"`
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	Note          uuid.UUID
+	Board         uuid.UUID
+	Editor        uuid.NullUUID
+	Text          string
+	ContentType   types.NoteContentType
+	Links         []types.LinkPreview   `bun:"type:jsonb,nullzero"`
+	Checklist     []types.ChecklistItem `bun:"type:jsonb,nullzero"`
+	Column        uuid.UUID
+	Stack         uuid.NullUUID
+	Rank          int
+}
+
+// newNoteRevisionQuery returns the insert of a revision for the current state of the note, which is meant to be used
+// as common table expression along with the update of the note. The condition has to be met for the revision to be
+// written.
+func (d *Database) newNoteRevisionQuery(caller, board, note uuid.UUID, condition ...string) *bun.InsertQuery {
+	values := d.db.NewSelect().
+		Model((*Note)(nil)).
+		ColumnExpr("id AS note, board, uuid(?) AS editor, text, content_type, links, checklist, \"column\", stack, rank", caller).
+		Where("id = ?", note).
+		Where("board = ?", board)
+	for _, c := range condition {
+		values = values.Where(c)
+	}
+
+	return d.db.NewInsert().
+		Model((*NoteRevision)(nil)).
+		TableExpr("(?) AS _values", values).
+		Column("note", "board", "editor", "text", "content_type", "links", "checklist", "column", "stack", "rank")
+}
+
+// GetNoteRevisions returns the revisions of the note, starting with the most recent one.
+func (d *Database) GetNoteRevisions(board, note uuid.UUID) ([]NoteRevision, error) {
+	var revisions []NoteRevision
+	err := d.db.NewSelect().
+		Model(&revisions).
+		Where("board = ?", board).
+		Where("note = ?", note).
+		Order("created_at DESC").
+		Scan(context.Background())
+	return revisions, err
+}
+
+// GetNoteRevision returns the revision for the specified id, if it's a revision of the note.
+func (d *Database) GetNoteRevision(board, note, id uuid.UUID) (NoteRevision, error) {
+	var revision NoteRevision
+	err := d.db.NewSelect().
+		Model(&revision).
+		Where("board = ?", board).
+		Where("note = ?", note).
+		Where("id = ?", id).
+		Scan(context.Background())
+	return revision, err
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
+)
+
+func TestRunnerForNoteRevisions(t *testing.T) {
+	t.Run("Write=0", testNoteRevisionOnTextUpdate)
+	t.Run("Write=1", testNoteRevisionOnMove)
+	t.Run("Write=2", testNoteRevisionOnContentUpdate)
+	t.Run("Get=0", testGetNoteRevisionOfOtherNoteShouldFail)
+}
+
+func createNoteRevisionTestBoard(t *testing.T) (Board, []Column, Note) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{
+		{Name: "Went well", Color: types.ColorBacklogBlue},
+		{Name: "To improve", Color: types.ColorBacklogBlue},
+	})
+	assert.Nil(t, err)
+	columns, err := testDb.GetColumns(board.ID)
+	assert.Nil(t, err)
+
+	note, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "First draft"})
+	assert.Nil(t, err)
+	return board, columns, note
+}
+
+func testNoteRevisionOnTextUpdate(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, columns, note := createNoteRevisionTestBoard(t)
+
+	revisions, err := testDb.GetNoteRevisions(board.ID, note.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, revisions)
+
+	secondDraft := "Second draft"
+	_, err = testDb.UpdateNote(user.ID, NoteUpdate{ID: note.ID, Board: board.ID, Text: &secondDraft})
+	assert.Nil(t, err)
+	finalDraft := "Final draft"
+	_, err = testDb.UpdateNote(user.ID, NoteUpdate{ID: note.ID, Board: board.ID, Text: &finalDraft})
+	assert.Nil(t, err)
+
+	revisions, err = testDb.GetNoteRevisions(board.ID, note.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "Second draft", revisions[0].Text)
+	assert.Equal(t, "First draft", revisions[1].Text)
+	assert.Equal(t, user.ID, revisions[1].Editor.UUID)
+	assert.Equal(t, columns[0].ID, revisions[1].Column)
+
+	revision, err := testDb.GetNoteRevision(board.ID, note.ID, revisions[1].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, revisions[1].ID, revision.ID)
+}
+
+func testNoteRevisionOnMove(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, columns, note := createNoteRevisionTestBoard(t)
+
+	_, err := testDb.UpdateNote(user.ID, NoteUpdate{ID: note.ID, Board: board.ID, Position: &NoteUpdatePosition{Column: columns[1].ID}})
+	assert.Nil(t, err)
+
+	revisions, err := testDb.GetNoteRevisions(board.ID, note.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, columns[0].ID, revisions[0].Column)
+	assert.Equal(t, "First draft", revisions[0].Text)
+}
+
+func testNoteRevisionOnContentUpdate(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, _, note := createNoteRevisionTestBoard(t)
+
+	checklist := []types.ChecklistItem{{Text: "First draft"}}
+	_, err := testDb.UpdateNote(user.ID, NoteUpdate{ID: note.ID, Board: board.ID, Content: &NoteUpdateContent{ContentType: types.NoteContentTypeChecklist, Checklist: checklist}})
+	assert.Nil(t, err)
+	checklist[0].Done = true
+	_, err = testDb.UpdateNote(user.ID, NoteUpdate{ID: note.ID, Board: board.ID, Content: &NoteUpdateContent{ContentType: types.NoteContentTypeChecklist, Checklist: checklist}})
+	assert.Nil(t, err)
+
+	revisions, err := testDb.GetNoteRevisions(board.ID, note.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, types.NoteContentTypeChecklist, revisions[0].ContentType)
+	assert.Equal(t, []types.ChecklistItem{{Text: "First draft"}}, revisions[0].Checklist)
+	assert.Equal(t, types.NoteContentTypePlain, revisions[1].ContentType)
+	assert.Nil(t, revisions[1].Checklist)
+}
+
+func testGetNoteRevisionOfOtherNoteShouldFail(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, _, note := createNoteRevisionTestBoard(t)
+	_, _, otherNote := createNoteRevisionTestBoard(t)
+
+	text := "Changed"
+	_, err := testDb.UpdateNote(user.ID, NoteUpdate{ID: note.ID, Board: board.ID, Text: &text})
+	assert.Nil(t, err)
+	revisions, err := testDb.GetNoteRevisions(board.ID, note.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 1)
+
+	_, err = testDb.GetNoteRevision(otherNote.Board, otherNote.ID, revisions[0].ID)
+	assert.NotNil(t, err)
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -19,8 +21,12 @@ type Note struct {
 	Board         uuid.UUID
 	Column        uuid.UUID
 	Text          string
+	ContentType   types.NoteContentType
+	Links         []types.LinkPreview   `bun:"type:jsonb,nullzero"`
+	Checklist     []types.ChecklistItem `bun:"type:jsonb,nullzero"`
 	Stack         uuid.NullUUID
 	Rank          int
+	DeletedAt     *time.Time `bun:",soft_delete"`
 }
 
 type NoteInsert struct {
@@ -29,6 +35,17 @@ type NoteInsert struct {
 	Board         uuid.UUID
 	Column        uuid.UUID
 	Text          string
+	ContentType   types.NoteContentType `bun:",nullzero"`
+	Links         []types.LinkPreview   `bun:"type:jsonb,nullzero"`
+	Checklist     []types.ChecklistItem `bun:"type:jsonb,nullzero"`
+}
+
+// NoteDeletion the model to soft delete a note. Deleted notes keep their column, stack and rank, so that they can be
+// restored until they are purged.
+type NoteDeletion struct {
+	bun.BaseModel `bun:"table:notes"`
+	ID            uuid.UUID
+	Board         uuid.UUID
 }
 
 type NoteUpdatePosition struct {
@@ -37,11 +54,19 @@ type NoteUpdatePosition struct {
 	Stack  uuid.NullUUID
 }
 
+// NoteUpdateContent the rich content of a note to update. The content is replaced as a whole.
+type NoteUpdateContent struct {
+	ContentType types.NoteContentType
+	Links       []types.LinkPreview   `bun:"type:jsonb,nullzero"`
+	Checklist   []types.ChecklistItem `bun:"type:jsonb,nullzero"`
+}
+
 type NoteUpdate struct {
 	bun.BaseModel `bun:"table:notes"`
 	ID            uuid.UUID
 	Board         uuid.UUID
 	Text          *string
+	Content       *NoteUpdateContent  `bun:"embed"`
 	Position      *NoteUpdatePosition `bun:"embed"`
 }
 
@@ -49,9 +74,9 @@ func (d *Database) CreateNote(insert NoteInsert) (Note, error) {
 	var note Note
 	_, err := d.db.NewInsert().
 		Model(&insert).
-		Value("rank", "coalesce((SELECT COUNT(*) as rank FROM notes WHERE board = ? AND \"column\" = ? AND stack IS NULL), 0)", insert.Board, insert.Column).
+		Value("rank", "coalesce((SELECT COUNT(*) as rank FROM notes WHERE board = ? AND \"column\" = ? AND stack IS NULL AND deleted_at IS NULL), 0)", insert.Board, insert.Column).
 		Returning("*").
-		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", insert.Board), &note)
+		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", insert.Board, "Result", &note), &note)
 	return note, err
 }
 
@@ -75,30 +100,40 @@ func (d *Database) UpdateNote(caller uuid.UUID, update NoteUpdate) (Note, error)
 	boardSelect := d.db.NewSelect().Model((*Board)(nil)).Column("allow_stacking").Where("id = ?", update.Board)
 	sessionSelect := d.db.NewSelect().Model((*BoardSession)(nil)).Column("role").Where("\"user\" = ?", caller).Where("board = ?", update.Board)
 	noteSelect := d.db.NewSelect().Model((*Note)(nil)).Column("author").Where("id = ?", update.ID).Where("board = ?", update.Board)
+	columnSelect := d.db.NewSelect().Model((*Note)(nil)).Column("column").Where("id = ?", update.ID).Where("board = ?", update.Board)
 
 	var precondition struct {
 		StackingAllowed bool
 		CallerRole      types.SessionRole
 		Author          uuid.UUID
+		Column          uuid.UUID
 	}
 	err := d.db.NewSelect().
 		ColumnExpr("(?) AS stacking_allowed", boardSelect).
 		ColumnExpr("(?) AS caller_role", sessionSelect).
 		ColumnExpr("(?) as author", noteSelect).
+		ColumnExpr("(?) as \"column\"", columnSelect).
 		Scan(context.Background(), &precondition)
 	if err != nil {
 		return Note{}, err
 	}
+	if precondition.Author == uuid.Nil {
+		// the note does not exist or has been deleted
+		return Note{}, sql.ErrNoRows
+	}
+
+	// the rich content of a note is changed along with its text
+	textChanged := update.Text != nil || update.Content != nil
 
 	var note Note
-	if update.Text != nil && update.Position == nil {
+	if textChanged && update.Position == nil {
 		if caller == precondition.Author || precondition.CallerRole == types.SessionRoleModerator || precondition.CallerRole == types.SessionRoleOwner {
-			note, err = d.updateNoteText(update)
+			note, err = d.updateNoteText(caller, update)
 		} else {
 			err = errors.New("not permitted to change text of note")
 		}
 	} else if update.Position != nil {
-		if update.Text != nil && (caller != precondition.Author || precondition.CallerRole == types.SessionRoleParticipant) {
+		if textChanged && (caller != precondition.Author || precondition.CallerRole == types.SessionRoleParticipant) {
 			return Note{}, errors.New("not permitted to change text of note")
 		}
 
@@ -107,11 +142,16 @@ func (d *Database) UpdateNote(caller uuid.UUID, update NoteUpdate) (Note, error)
 		}
 
 		if precondition.CallerRole == types.SessionRoleModerator || precondition.CallerRole == types.SessionRoleOwner || precondition.StackingAllowed {
+			ctx, err := d.noteMoveContext(update, precondition.Column)
+			if err != nil {
+				return Note{}, err
+			}
 			if !update.Position.Stack.Valid {
-				note, err = d.updateNoteWithoutStack(update)
+				note, err = d.updateNoteWithoutStack(ctx, caller, update)
 			} else {
-				note, err = d.updateNoteWithStack(update)
+				note, err = d.updateNoteWithStack(ctx, caller, update)
 			}
+			return note, err
 		} else {
 			err = errors.New("not permitted to change position of note")
 		}
@@ -120,16 +160,43 @@ func (d *Database) UpdateNote(caller uuid.UUID, update NoteUpdate) (Note, error)
 	return note, err
 }
 
-func (d *Database) updateNoteText(update NoteUpdate) (Note, error) {
+func (d *Database) updateNoteText(caller uuid.UUID, update NoteUpdate) (Note, error) {
 	var note Note
-	_, err := d.db.NewUpdate().Model(&update).Column("text").Where("id = ?", update.ID).Where("board = ?", update.Board).Where("id = ?", update.ID).Returning("*").Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", update.Board), &note)
+	columns := []string{}
+	if update.Text != nil {
+		columns = append(columns, "text")
+	}
+	if update.Content != nil {
+		columns = append(columns, "content_type", "links", "checklist")
+	}
+	_, err := d.db.NewUpdate().With("revision", d.newNoteRevisionQuery(caller, update.Board, update.ID)).Model(&update).Column(columns...).Where("id = ?", update.ID).Where("board = ?", update.Board).Where("id = ?", update.ID).Returning("*").Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", update.Board, "Result", &note), &note)
 	if err != nil {
 		return note, err
 	}
 	return note, nil
 }
 
-func (d *Database) updateNoteWithoutStack(update NoteUpdate) (Note, error) {
+// noteMoveContext returns the context of the query moving a note. The notes of the previous and the new column of the
+// note are kept, so that the observers are only notified about the notes that actually changed.
+func (d *Database) noteMoveContext(update NoteUpdate, previousColumn uuid.UUID) (context.Context, error) {
+	var previous []Note
+	if len(d.observer) > 0 {
+		var err error
+		previous, err = d.GetNotes(update.Board, previousColumn, update.Position.Column)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return common.ContextWithValues(context.Background(),
+		"Database", d,
+		"Board", update.Board,
+		"Note", update.ID,
+		"Columns", []uuid.UUID{previousColumn, update.Position.Column},
+		"Previous", previous,
+	), nil
+}
+
+func (d *Database) updateNoteWithoutStack(ctx context.Context, caller uuid.UUID, update NoteUpdate) (Note, error) {
 	newRank := update.Position.Rank
 	if update.Position.Rank < 0 {
 		newRank = 0
@@ -156,6 +223,7 @@ func (d *Database) updateNoteWithoutStack(update NoteUpdate) (Note, error) {
 
 	query := d.db.NewUpdate().Model(&update).
 		With("previous", previous).
+		With("revision", d.newNoteRevisionQuery(caller, update.Board, update.ID)).
 		With("rank_addition", rankAddition).
 		With("rank_range", rankRange).
 		With("rank_selection", rankSelection).
@@ -173,13 +241,16 @@ func (d *Database) updateNoteWithoutStack(update NoteUpdate) (Note, error) {
 	if update.Text != nil {
 		query = query.Set("text = ?", &update.Text)
 	}
+	if update.Content != nil {
+		query = setNoteContent(query, update.Content)
+	}
 
 	var note []Note
-	_, err := query.Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", update.Board), &note)
+	_, err := query.Exec(ctx, &note)
 	return note[0], err
 }
 
-func (d *Database) updateNoteWithStack(update NoteUpdate) (Note, error) {
+func (d *Database) updateNoteWithStack(ctx context.Context, caller uuid.UUID, update NoteUpdate) (Note, error) {
 	newRank := update.Position.Rank
 	if update.Position.Rank < 0 {
 		newRank = 0
@@ -197,7 +268,7 @@ func (d *Database) updateNoteWithStack(update NoteUpdate) (Note, error) {
 		ColumnExpr("CASE WHEN (SELECT \"stack\" FROM previous) IS NOT NULL AND (SELECT \"stack\" FROM previous) <> ? THEN true WHEN (SELECT \"stack\" FROM previous) IS NULL THEN true ELSE false END as is_new_in_stack", update.Position.Stack).
 		ColumnExpr("CASE WHEN (SELECT \"stack\" FROM previous) = ? AND (SELECT \"rank\" FROM previous) <> ? THEN true ELSE false END as is_same_stack", update.Position.Stack, update.Position.Rank).
 		ColumnExpr("CASE WHEN (SELECT \"stack\" FROM stack_target) = ? THEN true ELSE false END as is_stack_swap", update.ID).
-		ColumnExpr("CASE WHEN (SELECT \"column\" FROM notes WHERE id = ?) = ? THEN true ELSE false END as valid_update", update.Position.Stack, update.Position.Column)
+		ColumnExpr("CASE WHEN (SELECT \"column\" FROM notes WHERE id = ? AND deleted_at IS NULL) = ? THEN true ELSE false END as valid_update", update.Position.Stack, update.Position.Column)
 
 	// select the children of the note to update
 	children := d.db.NewSelect().Model((*Note)(nil)).Column("*").ColumnExpr("row_number() over (ORDER BY rank DESC) as index").Where("stack = ?", update.ID)
@@ -206,7 +277,7 @@ func (d *Database) updateNoteWithStack(update NoteUpdate) (Note, error) {
 	rankSelection := d.db.NewSelect().Model((*Note)(nil)).
 		ColumnExpr("CASE "+
 			"WHEN (SELECT is_stack_swap FROM update_check) THEN (SELECT rank FROM stack_target) "+
-			"WHEN (SELECT is_same_stack FROM update_check) THEN LEAST((SELECT COUNT(*) FROM notes WHERE \"stack\" = ?)-1, ?) "+
+			"WHEN (SELECT is_same_stack FROM update_check) THEN LEAST((SELECT COUNT(*) FROM notes WHERE \"stack\" = ? AND deleted_at IS NULL)-1, ?) "+
 			"WHEN (SELECT is_new_in_stack FROM update_check) THEN COUNT(*) + (SELECT COUNT(*) FROM children) "+
 			"ELSE (SELECT rank FROM previous) END as new_rank", update.Position.Stack, newRank).
 		Where("\"column\" = ?", update.Position.Column).
@@ -277,6 +348,7 @@ func (d *Database) updateNoteWithStack(update NoteUpdate) (Note, error) {
 		With("previous", previous).
 		With("stack_target", stackTarget).
 		With("update_check", updateCheck).
+		With("revision", d.newNoteRevisionQuery(caller, update.Board, update.ID, "(SELECT valid_update FROM update_check)")).
 		With("children", children).
 		With("rank_selection", rankSelection).
 		With("update_lower", updateWhenNewIsLower).
@@ -295,12 +367,31 @@ func (d *Database) updateNoteWithStack(update NoteUpdate) (Note, error) {
 	if update.Text != nil {
 		query = query.Set("text = ?", &update.Text)
 	}
+	if update.Content != nil {
+		query = setNoteContent(query, update.Content)
+	}
 
 	var note []Note
-	_, err := query.Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", update.Board), &note)
+	_, err := query.Exec(ctx, &note)
 	return note[0], err
 }
 
+// setNoteContent adds the rich content of a note to an update query with explicit set clauses
+func setNoteContent(query *bun.UpdateQuery, content *NoteUpdateContent) *bun.UpdateQuery {
+	query = query.Set("content_type = ?", content.ContentType)
+	query = query.Set("links = ?::jsonb", jsonbValue(content.Links, len(content.Links)))
+	return query.Set("checklist = ?::jsonb", jsonbValue(content.Checklist, len(content.Checklist)))
+}
+
+func jsonbValue(value interface{}, length int) *string {
+	if length == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(value)
+	s := string(b)
+	return &s
+}
+
 func (d *Database) DeleteNote(caller uuid.UUID, board uuid.UUID, id uuid.UUID, deleteStack bool) error {
 	sessionSelect := d.db.NewSelect().Model((*BoardSession)(nil)).Column("role").Where("\"user\" = ?", caller).Where("board = ?", board)
 	noteSelect := d.db.NewSelect().Model((*Note)(nil)).Column("author").Where("id = ?", id).Where("board = ?", board)
@@ -363,16 +454,26 @@ func (d *Database) DeleteNote(caller uuid.UUID, board uuid.UUID, id uuid.UUID, d
 		var notes []Note
 
 		if deleteStack {
-			_, err := d.db.NewDelete().
+			// the notes of the stack are deleted together with the note, so that they are restored together as well
+			deleteChildren := d.db.NewUpdate().
+				Model((*Note)(nil)).
+				Set("deleted_at = now()").
+				Where("board = ?", board).
+				Where("stack = ?", id)
+
+			_, err := d.db.NewUpdate().
 				With("update_board", updateBoard).
 				With("update_ranks", updateRanks).
-				Model((*Note)(nil)).Where("id = ?", id).Where("board = ?", board).Returning("*").
+				With("delete_children", deleteChildren).
+				Model((*NoteDeletion)(nil)).
+				Set("deleted_at = now()").
+				Where("id = ?", id).Where("board = ?", board).Where("deleted_at IS NULL").Returning("*").
 				Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", board, "Note", id, "User", caller, "DeleteStack", deleteStack, "Result", &notes), &notes)
 
 			return err
 		}
 
-		nextParentSelect := d.db.NewSelect().Model((*Note)(nil)).Where("stack = ?", id).Where("rank = (SELECT MAX(rank) FROM notes WHERE stack = ?)", id).Limit((1))
+		nextParentSelect := d.db.NewSelect().Model((*Note)(nil)).Where("stack = ?", id).Where("rank = (SELECT MAX(rank) FROM notes WHERE stack = ? AND deleted_at IS NULL)", id).Limit((1))
 
 		updateStackRefs := d.db.NewUpdate().
 			With("next_parent", nextParentSelect).
@@ -388,15 +489,125 @@ func (d *Database) DeleteNote(caller uuid.UUID, board uuid.UUID, id uuid.UUID, d
 			Set("rank = (SELECT rank FROM previous)").
 			Where("id = (SELECT id FROM next_parent)")
 
-		_, err := d.db.NewDelete().
+		_, err := d.db.NewUpdate().
 			With("update_board", updateBoard).
 			With("update_ranks", updateRanks).
 			With("update_stackrefs", updateStackRefs).
 			With("update_parentStackId", updateNextParentStackId).
-			Model((*Note)(nil)).Where("id = ?", id).Where("board = ?", board).Returning("*").
+			Model((*NoteDeletion)(nil)).
+			Set("deleted_at = now()").
+			Where("id = ?", id).Where("board = ?", board).Where("deleted_at IS NULL").Returning("*").
 			Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", board, "Note", id, "User", caller, "DeleteStack", deleteStack, "Result", &notes), &notes)
 
 		return err
 	}
 	return errors.New("not permitted to delete note")
 }
+
+// GetDeletedNote returns the deleted note with the specified id, as long as it has not been purged.
+func (d *Database) GetDeletedNote(board, id uuid.UUID) (Note, error) {
+	var note Note
+	err := d.db.NewSelect().Model((*Note)(nil)).WhereDeleted().Where("id = ?", id).Where("board = ?", board).Scan(context.Background(), &note)
+	return note, err
+}
+
+// RestoreNote restores a deleted note together with the notes of its stack, which were deleted along with it. The note
+// is put back at its previous rank within its column or stack. If the stack has been deleted in the meantime, the note
+// is restored as a note of its own. Notes of deleted columns can only be restored by restoring the column.
+func (d *Database) RestoreNote(board, id uuid.UUID) (Note, error) {
+	// the votes on the restored notes count towards the vote limit again, so they have to be known after the restore
+	var restoredNotes []uuid.UUID
+	err := d.db.NewSelect().
+		Model((*Note)(nil)).
+		WhereDeleted().
+		Column("id").
+		Where("board = ?", board).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("id = ?", id).
+				WhereOr("stack = ? AND deleted_at = (?)", id, d.db.NewSelect().Model((*Note)(nil)).WhereDeleted().Column("deleted_at").Where("id = ?", id))
+		}).
+		Scan(context.Background(), &restoredNotes)
+	if err != nil {
+		return Note{}, err
+	}
+
+	// select the deleted note, as long as its column has not been deleted as well
+	previous := d.db.NewSelect().
+		Model((*Note)(nil)).
+		WhereDeleted().
+		Where("id = ?", id).
+		Where("board = ?", board).
+		Where("\"column\" IN (?)", d.db.NewSelect().Model((*Column)(nil)).Column("id").Where("board = ?", board))
+	// select the stack the note belonged to, unless it has been deleted or moved to another column
+	parent := d.db.NewSelect().
+		Model((*Note)(nil)).
+		Column("id").
+		Where("id = (SELECT stack FROM previous)").
+		Where("\"column\" = (SELECT \"column\" FROM previous)")
+	// select the max rank allowed within the column or stack of the note
+	rankRange := d.db.NewSelect().
+		Model((*Note)(nil)).
+		ColumnExpr("COUNT(*) as max_rank").
+		Where("board = ?", board).
+		Where("\"column\" = (SELECT \"column\" FROM previous)").
+		Where("stack IS NOT DISTINCT FROM (SELECT id FROM parent)")
+	rankSelection := d.db.NewSelect().ColumnExpr("LEAST((SELECT rank FROM previous), (SELECT max_rank FROM rank_range)) as new_rank")
+	// make room for the note (shift notes by +1 above the new rank)
+	updateRanks := d.db.NewUpdate().
+		Model((*Note)(nil)).
+		Set("rank = rank+1").
+		Where("board = ?", board).
+		Where("\"column\" = (SELECT \"column\" FROM previous)").
+		Where("stack IS NOT DISTINCT FROM (SELECT id FROM parent)").
+		Where("rank >= (SELECT new_rank FROM rank_selection)")
+	// restore the notes of the stack, which were deleted along with the note
+	restoreChildren := d.db.NewUpdate().
+		Model((*Note)(nil)).
+		WhereDeleted().
+		Set("deleted_at = NULL").
+		Where("board = ?", board).
+		Where("stack = ?", id).
+		Where("deleted_at = (SELECT deleted_at FROM previous)")
+
+	var notes []Note
+	_, err = d.db.NewUpdate().
+		With("previous", previous).
+		With("parent", parent).
+		With("rank_range", rankRange).
+		With("rank_selection", rankSelection).
+		With("update_ranks", updateRanks).
+		With("restore_children", restoreChildren).
+		Model((*NoteUpdate)(nil)).
+		Set("deleted_at = NULL").
+		Set("stack = (SELECT id FROM parent)").
+		Set("rank = (SELECT new_rank FROM rank_selection)").
+		Where("id = (SELECT id FROM previous)").
+		Returning("*").
+		Exec(common.ContextWithValues(context.Background(), "Database", d, "Board", board), &notes)
+	if err != nil {
+		return Note{}, err
+	}
+	if len(notes) == 0 {
+		return Note{}, sql.ErrNoRows
+	}
+
+	if err := d.removeVotesAboveLimit(board, restoredNotes); err != nil {
+		return Note{}, err
+	}
+	return notes[0], nil
+}
+
+// PurgeDeletedNotes removes all notes, which have been deleted before the specified time, for good.
+func (d *Database) PurgeDeletedNotes(before time.Time) (int64, error) {
+	result, err := d.db.NewDelete().
+		Model((*Note)(nil)).
+		WhereDeleted().
+		Where("deleted_at < ?", before).
+		ForceDelete().
+		Exec(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
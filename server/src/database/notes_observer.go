@@ -14,23 +14,42 @@ type NotesObserver interface {
 	// UpdatedNotes will be called if the notes of the board with the specified id were updated.
 	UpdatedNotes(board uuid.UUID, notes []Note)
 
+	// CreatedNote will be called if a note has been created.
+	CreatedNote(board uuid.UUID, note Note)
+
+	// MovedNotes will be called if a note has been moved. The notes are the moved note along with all notes whose
+	// column, stack or rank changed because of the move.
+	MovedNotes(board uuid.UUID, notes []Note)
+
+	// UpdatedNoteText will be called if the text or the content of a note has been updated.
+	UpdatedNoteText(board uuid.UUID, note Note)
+
 	// DeletedNote will be called if a note has been deleted.
 	DeletedNote(user, board, note uuid.UUID, votes []Vote, deleteStack bool)
 }
 
 var _ bun.AfterInsertHook = (*NoteInsert)(nil)
 var _ bun.AfterUpdateHook = (*NoteUpdate)(nil)
-var _ bun.AfterDeleteHook = (*Note)(nil)
+var _ bun.AfterUpdateHook = (*NoteDeletion)(nil)
 
 func (*NoteInsert) AfterInsert(ctx context.Context, _ *bun.InsertQuery) error {
+	if ctx.Value("Result") != nil {
+		return notifyNoteCreated(ctx)
+	}
 	return notifyNotesUpdated(ctx)
 }
 
 func (*NoteUpdate) AfterUpdate(ctx context.Context, _ *bun.UpdateQuery) error {
+	if ctx.Value("Previous") != nil {
+		return notifyNotesMoved(ctx)
+	}
+	if ctx.Value("Result") != nil {
+		return notifyNoteTextUpdated(ctx)
+	}
 	return notifyNotesUpdated(ctx)
 }
 
-func (*Note) AfterDelete(ctx context.Context, _ *bun.DeleteQuery) error {
+func (*NoteDeletion) AfterUpdate(ctx context.Context, _ *bun.UpdateQuery) error {
 	result := ctx.Value("Result").(*[]Note)
 	if len(*result) > 0 {
 		return notifyNoteDeleted(ctx)
@@ -59,6 +78,87 @@ func notifyNotesUpdated(ctx context.Context) error {
 	return nil
 }
 
+func notifyNoteCreated(ctx context.Context) error {
+	if ctx.Value("Database") == nil {
+		return nil
+	}
+	d := ctx.Value("Database").(*Database)
+	if len(d.observer) > 0 {
+		board := ctx.Value("Board").(uuid.UUID)
+		note := ctx.Value("Result").(*Note)
+		for _, observer := range d.observer {
+			if o, ok := observer.(NotesObserver); ok {
+				o.CreatedNote(board, *note)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func notifyNoteTextUpdated(ctx context.Context) error {
+	if ctx.Value("Database") == nil {
+		return nil
+	}
+	d := ctx.Value("Database").(*Database)
+	if len(d.observer) > 0 {
+		board := ctx.Value("Board").(uuid.UUID)
+		note := ctx.Value("Result").(*Note)
+		for _, observer := range d.observer {
+			if o, ok := observer.(NotesObserver); ok {
+				o.UpdatedNoteText(board, *note)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// notifyNotesMoved compares the notes of the columns affected by a move with their state before the move, so that
+// only the notes that changed are passed to the observers.
+func notifyNotesMoved(ctx context.Context) error {
+	if ctx.Value("Database") == nil {
+		return nil
+	}
+	d := ctx.Value("Database").(*Database)
+	if len(d.observer) > 0 {
+		board := ctx.Value("Board").(uuid.UUID)
+		note := ctx.Value("Note").(uuid.UUID)
+		columns := ctx.Value("Columns").([]uuid.UUID)
+		previous := ctx.Value("Previous").([]Note)
+		notes, err := d.GetNotes(board, columns...)
+		if err != nil {
+			return err
+		}
+		moved := movedNotes(note, previous, notes)
+		for _, observer := range d.observer {
+			if o, ok := observer.(NotesObserver); ok {
+				o.MovedNotes(board, moved)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// movedNotes returns the specified note along with the notes whose column, stack or rank differ from their previous
+// state.
+func movedNotes(note uuid.UUID, previous, notes []Note) []Note {
+	before := make(map[uuid.UUID]Note, len(previous))
+	for _, n := range previous {
+		before[n.ID] = n
+	}
+
+	moved := []Note{}
+	for _, n := range notes {
+		p, ok := before[n.ID]
+		if n.ID == note || !ok || p.Column != n.Column || p.Stack != n.Stack || p.Rank != n.Rank {
+			moved = append(moved, n)
+		}
+	}
+	return moved
+}
+
 func notifyNoteDeleted(ctx context.Context) error {
 	if ctx.Value("Database") == nil {
 		return nil
@@ -11,6 +11,9 @@ type NotesObserverForTests struct {
 	t           *testing.T
 	board       *uuid.UUID
 	notes       *[]Note
+	createdNote *Note
+	movedNotes  *[]Note
+	updatedNote *Note
 	deletedNote *uuid.UUID
 }
 
@@ -19,6 +22,21 @@ func (o *NotesObserverForTests) UpdatedNotes(board uuid.UUID, notes []Note) {
 	o.notes = &notes
 }
 
+func (o *NotesObserverForTests) CreatedNote(board uuid.UUID, note Note) {
+	o.board = &board
+	o.createdNote = &note
+}
+
+func (o *NotesObserverForTests) MovedNotes(board uuid.UUID, notes []Note) {
+	o.board = &board
+	o.movedNotes = &notes
+}
+
+func (o *NotesObserverForTests) UpdatedNoteText(board uuid.UUID, note Note) {
+	o.board = &board
+	o.updatedNote = &note
+}
+
 func (o *NotesObserverForTests) DeletedNote(user, board, note uuid.UUID, votes []Vote, deleteStack bool) {
 	o.board = &board
 	o.deletedNote = &note
@@ -27,6 +45,9 @@ func (o *NotesObserverForTests) DeletedNote(user, board, note uuid.UUID, votes [
 func (o *NotesObserverForTests) Reset() {
 	o.board = nil
 	o.notes = nil
+	o.createdNote = nil
+	o.movedNotes = nil
+	o.updatedNote = nil
 	o.deletedNote = nil
 }
 
@@ -41,9 +62,11 @@ func TestNotesObserver(t *testing.T) {
 	notesObserver.Reset()
 	t.Run("Test=2", testNotesObserverOnUpdate)
 	notesObserver.Reset()
-	t.Run("Test=3", testNotesObserverOnDelete)
+	t.Run("Test=3", testNotesObserverOnMove)
 	notesObserver.Reset()
-	t.Run("Test=4", testNotesObserverOnDeleteNotExisting)
+	t.Run("Test=4", testNotesObserverOnDelete)
+	notesObserver.Reset()
+	t.Run("Test=5", testNotesObserverOnDeleteNotExisting)
 
 	_, _ = testDb.DetachObserver(notesObserver)
 }
@@ -62,11 +85,10 @@ func testNotesObserverOnCreate(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.NotNil(t, notesObserver.board)
-	assert.NotNil(t, notesObserver.notes)
+	assert.Nil(t, notesObserver.notes)
+	assert.NotNil(t, notesObserver.createdNote)
 
-	assert.Equal(t, 1, len(*notesObserver.notes))
-	assert.Equal(t, note.Board, (*notesObserver.notes)[0].Board)
-	assert.Equal(t, note.Column, (*notesObserver.notes)[0].Column)
+	assert.Equal(t, note, *notesObserver.createdNote)
 
 	notesObserverForTestNote = note
 }
@@ -82,11 +104,41 @@ func testNotesObserverOnUpdate(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.NotNil(t, notesObserver.board)
-	assert.NotNil(t, notesObserver.notes)
+	assert.Nil(t, notesObserver.notes)
+	assert.NotNil(t, notesObserver.updatedNote)
 
-	assert.Equal(t, 1, len(*notesObserver.notes))
-	assert.Equal(t, note.Board, (*notesObserver.notes)[0].Board)
-	assert.Equal(t, note.Column, (*notesObserver.notes)[0].Column)
+	assert.Equal(t, note.ID, notesObserver.updatedNote.ID)
+	assert.Equal(t, textUpdate, notesObserver.updatedNote.Text)
+}
+func testNotesObserverOnMove(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+
+	other, err := testDb.CreateNote(NoteInsert{
+		Author: user.ID,
+		Board:  notesObserverForTestNote.Board,
+		Column: notesObserverForTestNote.Column,
+		Text:   "I will be shifted",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, other.Rank)
+	notesObserver.Reset()
+
+	note, err := testDb.UpdateNote(user.ID, NoteUpdate{
+		ID:       notesObserverForTestNote.ID,
+		Board:    notesObserverForTestNote.Board,
+		Position: &NoteUpdatePosition{Column: notesObserverForTestNote.Column, Rank: 1},
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, notesObserver.board)
+	assert.Nil(t, notesObserver.notes)
+	assert.NotNil(t, notesObserver.movedNotes)
+
+	ranks := map[uuid.UUID]int{}
+	for _, moved := range *notesObserver.movedNotes {
+		ranks[moved.ID] = moved.Rank
+	}
+	assert.Equal(t, map[uuid.UUID]int{note.ID: 1, other.ID: 0}, ranks)
 }
 func testNotesObserverOnDelete(t *testing.T) {
 	user := fixture.MustRow("User.jack").(*User)
@@ -1,10 +1,14 @@
 package database
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
 )
 
 func TestRunnerForNotes(t *testing.T) {
@@ -16,6 +20,7 @@ func TestRunnerForNotes(t *testing.T) {
 
 	t.Run("Create=0", testCreateNote)
 	t.Run("Create=1", testCreateNoteWithEmptyTextShouldFail)
+	t.Run("Create=2", testCreateNoteWithChecklist)
 
 	t.Run("Update=0", testUpdateOfNoteText)
 	t.Run("Update=1", testOrderOnRaiseRankOfNote)
@@ -35,11 +40,19 @@ func TestRunnerForNotes(t *testing.T) {
 	t.Run("Update=14", testChangeOrderWhenMoveWithinStackToNegative)
 	t.Run("Update=15", testChangeOrderWhenMoveWithinStackToLargeRank)
 	t.Run("Update=16", testOrderWhenChangeStackParent)
+	t.Run("Update=17", testUpdateOfNoteContent)
 
 	t.Run("Delete=0", testDeleteNote)
 	t.Run("Delete=1", testDeleteSharedNote)
 	t.Run("Delete=2", testDeleteStackParent)
 	t.Run("Delete=3", testDeleteStack)
+
+	t.Run("Restore=0", testRestoreNote)
+	t.Run("Restore=1", testRestoreStack)
+	t.Run("Restore=2", testRestoreNoteNotDeletedShouldFail)
+	t.Run("Restore=3", testRestoreNoteRemovesVotesAboveLimit)
+
+	t.Run("Purge=0", testPurgeDeletedNotes)
 }
 
 var notesTestBoard *Board
@@ -164,6 +177,73 @@ func testCreateNoteWithEmptyTextShouldFail(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func createContentTestBoard(t *testing.T) (Board, Column) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{
+		{Name: "Actions", Color: types.ColorBacklogBlue},
+	})
+	assert.Nil(t, err)
+	columns, err := testDb.GetColumns(board.ID)
+	assert.Nil(t, err)
+	return board, columns[0]
+}
+
+func testCreateNoteWithChecklist(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, column := createContentTestBoard(t)
+
+	checklist := []types.ChecklistItem{{Text: "Book room", Done: true}, {Text: "Invite team"}}
+	note, err := testDb.CreateNote(NoteInsert{
+		Author:      user.ID,
+		Board:       board.ID,
+		Column:      column.ID,
+		Text:        "Offsite",
+		ContentType: types.NoteContentTypeChecklist,
+		Checklist:   checklist,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, types.NoteContentTypeChecklist, note.ContentType)
+	assert.Equal(t, checklist, note.Checklist)
+	assert.Nil(t, note.Links)
+
+	plain, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: column.ID, Text: "Plain"})
+	assert.Nil(t, err)
+	assert.Equal(t, types.NoteContentTypePlain, plain.ContentType)
+	assert.Nil(t, plain.Checklist)
+}
+
+func testUpdateOfNoteContent(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, column := createContentTestBoard(t)
+	note, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: column.ID, Text: "Read https://scrumlr.io"})
+	assert.Nil(t, err)
+
+	links := []types.LinkPreview{{URL: "https://scrumlr.io", Title: "scrumlr.io"}}
+	updated, err := testDb.UpdateNote(user.ID, NoteUpdate{
+		ID:      note.ID,
+		Board:   board.ID,
+		Content: &NoteUpdateContent{ContentType: types.NoteContentTypeMarkdown, Links: links},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Read https://scrumlr.io", updated.Text)
+	assert.Equal(t, types.NoteContentTypeMarkdown, updated.ContentType)
+	assert.Equal(t, links, updated.Links)
+
+	updated, err = testDb.UpdateNote(user.ID, NoteUpdate{
+		ID:      note.ID,
+		Board:   board.ID,
+		Content: &NoteUpdateContent{ContentType: types.NoteContentTypeChecklist, Checklist: []types.ChecklistItem{{Text: "Read it"}}},
+		Position: &NoteUpdatePosition{
+			Column: column.ID,
+			Rank:   0,
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, types.NoteContentTypeChecklist, updated.ContentType)
+	assert.Nil(t, updated.Links)
+	assert.Equal(t, []types.ChecklistItem{{Text: "Read it"}}, updated.Checklist)
+}
+
 func testUpdateOfNoteText(t *testing.T) {
 	newText := "I update the text and I like it"
 
@@ -557,3 +637,70 @@ func testDeleteStack(t *testing.T) {
 	notesInStack, _ = testDb.GetNotes(stackTestBoard.ID, stackTestColumnB.ID)
 	assert.Equal(t, 0, len(notesInStack))
 }
+
+func testRestoreNote(t *testing.T) {
+	deleted, err := testDb.GetDeletedNote(notesTestBoard.ID, noteB1.ID)
+	assert.Nil(t, err)
+	assert.NotNil(t, deleted.DeletedAt)
+
+	note, err := testDb.RestoreNote(notesTestBoard.ID, noteB1.ID)
+	assert.Nil(t, err)
+	assert.Nil(t, note.DeletedAt)
+	assert.Equal(t, noteB1.Rank, note.Rank)
+
+	notes, _ := testDb.GetNotes(notesTestBoard.ID, columnB.ID)
+	verifyNoteOrder(t, notes, noteB1, noteB2, noteB3)
+}
+
+func testRestoreStack(t *testing.T) {
+	stackF = fixture.MustRow("Note.stackTestNote6").(*Note)
+	stackG = fixture.MustRow("Note.stackTestNote7").(*Note)
+
+	_, err := testDb.RestoreNote(stackTestBoard.ID, stackH.ID)
+	assert.Nil(t, err)
+
+	notesInStack, _ := testDb.GetNotes(stackTestBoard.ID, stackTestColumnB.ID)
+	assert.Equal(t, 3, len(notesInStack))
+	verifyNoteOrder(t, notesInStack, stackH, stackG, stackF)
+}
+
+func testRestoreNoteNotDeletedShouldFail(t *testing.T) {
+	_, err := testDb.RestoreNote(notesTestBoard.ID, noteB2.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func testRestoreNoteRemovesVotesAboveLimit(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, first, second := createVotingModeTestBoard(t, types.VotingModeDot, 2)
+
+	_, err := testDb.AddVote(board.ID, user.ID, first.ID, 1, nil)
+	assert.Nil(t, err)
+	_, err = testDb.AddVote(board.ID, user.ID, first.ID, 1, nil)
+	assert.Nil(t, err)
+	err = testDb.DeleteNote(user.ID, board.ID, first.ID, false)
+	assert.Nil(t, err)
+
+	// the votes on the deleted note don't count, so they can be spent on other notes
+	_, err = testDb.AddVote(board.ID, user.ID, second.ID, 1, nil)
+	assert.Nil(t, err)
+
+	_, err = testDb.RestoreNote(board.ID, first.ID)
+	assert.Nil(t, err)
+
+	votes, err := testDb.GetVotes(filter.VoteFilter{Board: board.ID, User: &user.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(votes))
+	assert.Equal(t, second.ID, votes[0].Note)
+}
+
+func testPurgeDeletedNotes(t *testing.T) {
+	_, err := testDb.GetDeletedNote(notesTestBoard.ID, noteC1.ID)
+	assert.Nil(t, err)
+
+	purged, err := testDb.PurgeDeletedNotes(time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, purged, int64(1))
+
+	_, err = testDb.GetDeletedNote(notesTestBoard.ID, noteC1.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BoardPhase the model for a phase of the agenda of a board. The settings of a phase are applied to the board once the
+// phase is activated, whereas unset settings are kept as they are.
+type BoardPhase struct {
+	bun.BaseModel         `bun:"table:board_phases"`
+	ID                    uuid.UUID
+	Board                 uuid.UUID
+	Index                 int
+	Name                  string
+	ShowAuthors           *bool
+	ShowNotesOfOtherUsers *bool
+	ShowNoteReactions     *bool
+	AllowStacking         *bool
+	VisibleColumns        []uuid.UUID `bun:",array"`
+	VoteLimit             *int
+	AllowMultipleVotes    bool
+	ShowVotesOfOthers     bool
+	TimerMinutes          *int
+}
+
+// BoardPhaseInsert the insert model for a new BoardPhase
+type BoardPhaseInsert struct {
+	bun.BaseModel         `bun:"table:board_phases"`
+	Board                 uuid.UUID
+	Index                 int
+	Name                  string
+	ShowAuthors           *bool
+	ShowNotesOfOtherUsers *bool
+	ShowNoteReactions     *bool
+	AllowStacking         *bool
+	VisibleColumns        []uuid.UUID `bun:",array"`
+	VoteLimit             *int
+	AllowMultipleVotes    bool
+	ShowVotesOfOthers     bool
+	TimerMinutes          *int
+}
+
+// SetBoardPhases replaces the agenda of the board by the specified phases. The indices of the phases will be set by
+// their order in the slice. If the current phase of the board is replaced, the board is left without a current phase.
+func (d *Database) SetBoardPhases(board uuid.UUID, phases []BoardPhaseInsert) ([]BoardPhase, error) {
+	for index := range phases {
+		if phases[index].Name == "" {
+			return nil, errors.New("phase name may not be empty")
+		}
+		phases[index].Board = board
+		phases[index].Index = index
+	}
+
+	deletePhases := d.db.NewDelete().Model((*BoardPhase)(nil)).Where("board = ?", board)
+	if len(phases) == 0 {
+		_, err := deletePhases.Exec(context.Background())
+		return []BoardPhase{}, err
+	}
+
+	var p []BoardPhase
+	err := d.db.NewSelect().
+		With("deletedPhases", deletePhases).
+		With("createdPhases", d.db.NewInsert().Model(&phases).Returning("*")).
+		Table("createdPhases").
+		Column("*").
+		Order("index ASC").
+		Scan(context.Background(), &p)
+	return p, err
+}
+
+// GetBoardPhase returns the phase for the specified id, if it's part of the agenda of the board.
+func (d *Database) GetBoardPhase(board, id uuid.UUID) (BoardPhase, error) {
+	var phase BoardPhase
+	err := d.db.NewSelect().Model(&phase).Where("board = ?", board).Where("id = ?", id).Scan(context.Background())
+	return phase, err
+}
+
+// GetBoardPhases returns the agenda of the board in the order of the phases.
+func (d *Database) GetBoardPhases(board uuid.UUID) ([]BoardPhase, error) {
+	var phases []BoardPhase
+	err := d.db.NewSelect().Model(&phases).Where("board = ?", board).Order("index ASC").Scan(context.Background())
+	return phases, err
+}
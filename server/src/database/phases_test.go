@@ -0,0 +1,151 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
+)
+
+func TestRunnerForPhases(t *testing.T) {
+	t.Run("Set=0", testSetBoardPhases)
+	t.Run("Set=1", testSetBoardPhasesReplacesAgenda)
+	t.Run("Set=2", testSetBoardPhasesWithEmptyName)
+
+	t.Run("Activate=0", testActivatePhaseAppliesSettings)
+	t.Run("Activate=1", testActivatePhaseKeepsOpenVoting)
+}
+
+func createPhaseTestBoard(t *testing.T) (Board, []Column) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{
+		{Name: "Went well", Color: types.ColorBacklogBlue},
+		{Name: "To improve", Color: types.ColorBacklogBlue},
+	})
+	assert.Nil(t, err)
+
+	columns, err := testDb.GetColumns(board.ID)
+	assert.Nil(t, err)
+	return board, columns
+}
+
+func testSetBoardPhases(t *testing.T) {
+	board, columns := createPhaseTestBoard(t)
+	showNotes := false
+	voteLimit := 5
+	minutes := 10
+
+	phases, err := testDb.SetBoardPhases(board.ID, []BoardPhaseInsert{
+		{Name: "Gather", ShowNotesOfOtherUsers: &showNotes, VisibleColumns: []uuid.UUID{columns[0].ID}, TimerMinutes: &minutes},
+		{Name: "Vote", VoteLimit: &voteLimit, AllowMultipleVotes: true},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, phases, 2)
+	assert.Equal(t, "Gather", phases[0].Name)
+	assert.Equal(t, 0, phases[0].Index)
+	assert.False(t, *phases[0].ShowNotesOfOtherUsers)
+	assert.Nil(t, phases[0].ShowAuthors)
+	assert.Equal(t, []uuid.UUID{columns[0].ID}, phases[0].VisibleColumns)
+	assert.Equal(t, 10, *phases[0].TimerMinutes)
+	assert.Equal(t, "Vote", phases[1].Name)
+	assert.Equal(t, 1, phases[1].Index)
+	assert.Nil(t, phases[1].VisibleColumns)
+	assert.Equal(t, 5, *phases[1].VoteLimit)
+	assert.True(t, phases[1].AllowMultipleVotes)
+}
+
+func testSetBoardPhasesReplacesAgenda(t *testing.T) {
+	board, _ := createPhaseTestBoard(t)
+
+	phases, err := testDb.SetBoardPhases(board.ID, []BoardPhaseInsert{{Name: "Gather"}, {Name: "Discuss"}})
+	assert.Nil(t, err)
+	assert.Len(t, phases, 2)
+
+	_, err = testDb.UpdateBoard(BoardUpdate{ID: board.ID, Phase: &phases[0].ID})
+	assert.Nil(t, err)
+
+	phases, err = testDb.SetBoardPhases(board.ID, []BoardPhaseInsert{{Name: "Actions"}})
+	assert.Nil(t, err)
+	assert.Len(t, phases, 1)
+
+	gotPhases, err := testDb.GetBoardPhases(board.ID)
+	assert.Nil(t, err)
+	assert.Len(t, gotPhases, 1)
+	assert.Equal(t, "Actions", gotPhases[0].Name)
+
+	gotBoard, err := testDb.GetBoard(board.ID)
+	assert.Nil(t, err)
+	assert.False(t, gotBoard.Phase.Valid)
+
+	phases, err = testDb.SetBoardPhases(board.ID, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, phases)
+}
+
+func testSetBoardPhasesWithEmptyName(t *testing.T) {
+	board, _ := createPhaseTestBoard(t)
+
+	_, err := testDb.SetBoardPhases(board.ID, []BoardPhaseInsert{{Name: ""}})
+	assert.NotNil(t, err)
+}
+
+func testActivatePhaseAppliesSettings(t *testing.T) {
+	board, columns := createPhaseTestBoard(t)
+	allowStacking := false
+
+	phases, err := testDb.SetBoardPhases(board.ID, []BoardPhaseInsert{{Name: "Vote"}})
+	assert.Nil(t, err)
+
+	timerStart := time.Now()
+	timerEnd := timerStart.Add(5 * time.Minute)
+	updatedBoard, err := testDb.UpdateBoard(BoardUpdate{
+		ID:             board.ID,
+		Phase:          &phases[0].ID,
+		AllowStacking:  &allowStacking,
+		TimerStart:     &timerStart,
+		TimerEnd:       &timerEnd,
+		RestartTimer:   true,
+		VisibleColumns: []uuid.UUID{columns[1].ID},
+		OpenVoting:     &VotingInsert{Board: board.ID, VoteLimit: 3, Status: types.VotingStatusOpen},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, phases[0].ID, updatedBoard.Phase.UUID)
+	assert.False(t, updatedBoard.AllowStacking)
+	assert.NotNil(t, updatedBoard.TimerEnd)
+
+	gotColumns, err := testDb.GetColumns(board.ID)
+	assert.Nil(t, err)
+	for _, column := range gotColumns {
+		assert.Equal(t, column.ID == columns[1].ID, column.Visible)
+	}
+
+	votings, _, err := testDb.GetVotings(board.ID)
+	assert.Nil(t, err)
+	assert.Len(t, votings, 1)
+	assert.Equal(t, types.VotingStatusOpen, votings[0].Status)
+	assert.Equal(t, 3, votings[0].VoteLimit)
+}
+
+func testActivatePhaseKeepsOpenVoting(t *testing.T) {
+	board, _ := createPhaseTestBoard(t)
+
+	voting, err := testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: 7, Status: types.VotingStatusOpen})
+	assert.Nil(t, err)
+
+	phases, err := testDb.SetBoardPhases(board.ID, []BoardPhaseInsert{{Name: "Vote"}})
+	assert.Nil(t, err)
+
+	_, err = testDb.UpdateBoard(BoardUpdate{
+		ID:         board.ID,
+		Phase:      &phases[0].ID,
+		OpenVoting: &VotingInsert{Board: board.ID, VoteLimit: 3, Status: types.VotingStatusOpen},
+	})
+	assert.Nil(t, err)
+
+	votings, _, err := testDb.GetVotings(board.ID)
+	assert.Nil(t, err)
+	assert.Len(t, votings, 1)
+	assert.Equal(t, voting.ID, votings[0].ID)
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -36,6 +37,7 @@ func (d *Database) GetReaction(id uuid.UUID) (Reaction, error) {
 		NewSelect().
 		Model((*Reaction)(nil)).
 		Where("id = ?", id).
+		Where("note IN (?)", d.db.NewSelect().Model((*Note)(nil)).Column("id")).
 		Scan(context.Background(), &reaction)
 	return reaction, err
 }
@@ -50,6 +52,7 @@ func (d *Database) GetReactions(board uuid.UUID) ([]Reaction, error) {
 		Model(&reactions).
 		Join("JOIN notes ON notes.id = reaction.note"). // important: 'reaction.note' instead of 'reactions.note'
 		Where("notes.board = ?", board).
+		Where("notes.deleted_at IS NULL").
 		Scan(context.Background())
 	return reactions, err
 }
@@ -68,7 +71,16 @@ func (d *Database) GetReactionsForNote(note uuid.UUID) ([]Reaction, error) {
 
 // CreateReaction inserts a new reaction
 func (d *Database) CreateReaction(board uuid.UUID, insert ReactionInsert) (Reaction, error) {
-	var currentNoteReactions, err = d.GetReactionsForNote(insert.Note)
+	// reactions can only be made on notes of the board, which are not deleted
+	exists, err := d.db.NewSelect().Model((*Note)(nil)).Where("id = ?", insert.Note).Where("board = ?", board).Exists(context.Background())
+	if err != nil {
+		return Reaction{}, err
+	}
+	if !exists {
+		return Reaction{}, sql.ErrNoRows
+	}
+
+	currentNoteReactions, err := d.GetReactionsForNote(insert.Note)
 	if err != nil {
 		return Reaction{}, err
 	}
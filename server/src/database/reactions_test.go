@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"github.com/stretchr/testify/assert"
 	"scrumlr.io/server/database/types"
 	"testing"
@@ -13,6 +14,7 @@ func TestRunnerForReactions(t *testing.T) {
 
 	t.Run("Create=0", testCreateReaction)
 	t.Run("Create=1", testCreateReactionFailsBecauseUserAlreadyReactedOnThatNote)
+	t.Run("Create=2", testCreateReactionOnDeletedNoteShouldFail)
 
 	t.Run("Update=0", testUpdateReaction)
 	t.Run("Update=1", testUpdateReactionFailsBecauseForbidden)
@@ -85,6 +87,16 @@ func testCreateReactionFailsBecauseUserAlreadyReactedOnThatNote(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func testCreateReactionOnDeletedNoteShouldFail(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, note, _ := createVotingModeTestBoard(t, types.VotingModeDot, 2)
+	err := testDb.DeleteNote(user.ID, board.ID, note.ID, false)
+	assert.Nil(t, err)
+
+	_, err = testDb.CreateReaction(board.ID, ReactionInsert{Note: note.ID, User: user.ID, ReactionType: types.ReactionLike})
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
 func testUpdateReaction(t *testing.T) {
 	newReactionType := types.ReactionCelebration
 	board := fixture.MustRow("Board.notesTestBoard").(*Board) // cannot reuse vars here
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// lastActivityExpr is the point in time of the latest note or session created on board 'b'
+const lastActivityExpr = "GREATEST(b.created_at, (SELECT MAX(n.created_at) FROM notes AS n WHERE n.board = b.id), (SELECT MAX(bs.created_at) FROM board_sessions AS bs WHERE bs.board = b.id))"
+
+// InactiveBoard the model for a board without recent activity
+type InactiveBoard struct {
+	bun.BaseModel `bun:"table:boards"`
+	ID            uuid.UUID
+	Name          *string
+	Archived      bool
+	CreatedAt     time.Time
+	LastActivity  time.Time
+}
+
+// GetInactiveBoards returns all boards without any note or session activity since the specified point in time.
+// Archived boards are only included if specified.
+func (d *Database) GetInactiveBoards(since time.Time, includeArchived bool) ([]InactiveBoard, error) {
+	query := d.db.NewSelect().
+		TableExpr("boards AS b").
+		ColumnExpr("b.id, b.name, b.archived, b.created_at").
+		ColumnExpr(lastActivityExpr+" AS last_activity").
+		Where(lastActivityExpr+" < ?", since)
+	if !includeArchived {
+		query = query.Where("b.archived = false")
+	}
+
+	var boards []InactiveBoard
+	err := query.OrderExpr("last_activity ASC").Scan(context.Background(), &boards)
+	return boards, err
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
+)
+
+func TestRunnerForRetention(t *testing.T) {
+	t.Run("Get=0", testGetInactiveBoards)
+	t.Run("Get=1", testGetInactiveBoardsExcludesArchivedBoards)
+}
+
+func testGetInactiveBoards(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{})
+	assert.Nil(t, err)
+
+	boards, err := testDb.GetInactiveBoards(time.Now().Add(-time.Hour), true)
+	assert.Nil(t, err)
+	assert.NotContains(t, inactiveBoardIDs(boards), board.ID.String())
+
+	boards, err = testDb.GetInactiveBoards(time.Now().Add(time.Hour), true)
+	assert.Nil(t, err)
+	assert.Contains(t, inactiveBoardIDs(boards), board.ID.String())
+}
+
+func testGetInactiveBoardsExcludesArchivedBoards(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, _ := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{})
+	archived := true
+	_, err := testDb.UpdateBoard(BoardUpdate{ID: board.ID, Archived: &archived})
+	assert.Nil(t, err)
+
+	boards, err := testDb.GetInactiveBoards(time.Now().Add(time.Hour), false)
+	assert.Nil(t, err)
+	assert.NotContains(t, inactiveBoardIDs(boards), board.ID.String())
+
+	boards, err = testDb.GetInactiveBoards(time.Now().Add(time.Hour), true)
+	assert.Nil(t, err)
+	assert.Contains(t, inactiveBoardIDs(boards), board.ID.String())
+}
+
+func inactiveBoardIDs(boards []InactiveBoard) []string {
+	ids := make([]string, len(boards))
+	for index, board := range boards {
+		ids[index] = board.ID.String()
+	}
+	return ids
+}
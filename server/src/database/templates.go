@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"scrumlr.io/server/database/types"
+)
+
+// BoardTemplate the model for a reusable board configuration
+type BoardTemplate struct {
+	bun.BaseModel         `bun:"table:board_templates"`
+	ID                    uuid.UUID
+	CreatedAt             time.Time
+	Creator               uuid.UUID
+	Name                  string
+	Description           *string
+	ShowAuthors           bool
+	ShowNotesOfOtherUsers bool
+	ShowNoteReactions     bool
+	AllowStacking         bool
+}
+
+// BoardTemplateInsert the insert model for a new BoardTemplate
+type BoardTemplateInsert struct {
+	bun.BaseModel         `bun:"table:board_templates"`
+	Creator               uuid.UUID
+	Name                  string
+	Description           *string
+	ShowAuthors           *bool
+	ShowNotesOfOtherUsers *bool
+	ShowNoteReactions     *bool
+	AllowStacking         *bool
+}
+
+// BoardTemplateUpdate the update model for a BoardTemplate
+type BoardTemplateUpdate struct {
+	bun.BaseModel         `bun:"table:board_templates"`
+	ID                    uuid.UUID
+	Name                  *string
+	Description           *string
+	ShowAuthors           *bool
+	ShowNotesOfOtherUsers *bool
+	ShowNoteReactions     *bool
+	AllowStacking         *bool
+}
+
+// ColumnTemplate the model for a column of a BoardTemplate
+type ColumnTemplate struct {
+	bun.BaseModel `bun:"table:column_templates"`
+	ID            uuid.UUID
+	BoardTemplate uuid.UUID
+	Name          string
+	Color         types.Color
+	Visible       bool
+	Index         int
+}
+
+// ColumnTemplateInsert the insert model for a new ColumnTemplate
+type ColumnTemplateInsert struct {
+	bun.BaseModel `bun:"table:column_templates"`
+	BoardTemplate uuid.UUID
+	Name          string
+	Color         types.Color
+	Visible       *bool
+	Index         *int
+}
+
+// CreateBoardTemplate creates a new template with the specified columns. The indices of the columns will be set by
+// their order in the slice.
+func (d *Database) CreateBoardTemplate(template BoardTemplateInsert, columns []ColumnTemplateInsert) (BoardTemplate, []ColumnTemplate, error) {
+	if template.Name == "" {
+		return BoardTemplate{}, nil, errors.New("template name may not be empty")
+	}
+
+	templateInsert := d.db.NewInsert().Model(&template).Returning("*")
+
+	var t BoardTemplate
+	query := d.db.NewSelect().With("createdTemplate", templateInsert)
+	if len(columns) > 0 {
+		for index := range columns {
+			newColumnIndex := index
+			columns[index].Index = &newColumnIndex
+		}
+
+		query = query.With("createdColumns", d.db.NewInsert().
+			Model(&columns).
+			Value("board_template", "(SELECT id FROM \"createdTemplate\")"))
+	}
+	err := query.
+		Table("createdTemplate").
+		Column("*").
+		Scan(context.Background(), &t)
+	if err != nil {
+		return BoardTemplate{}, nil, err
+	}
+
+	c, err := d.GetColumnTemplates(t.ID)
+	return t, c, err
+}
+
+// UpdateBoardTemplate updates the template and replaces all of its columns, if columns are specified.
+func (d *Database) UpdateBoardTemplate(update BoardTemplateUpdate, columns []ColumnTemplateInsert) (BoardTemplate, []ColumnTemplate, error) {
+	query := d.db.NewUpdate().Model(&update)
+
+	if update.Name != nil {
+		if *update.Name == "" {
+			return BoardTemplate{}, nil, errors.New("template name may not be empty")
+		}
+		query.Set("name = ?", *update.Name)
+	}
+	if update.Description != nil {
+		query.Set("description = ?", *update.Description)
+	}
+	if update.ShowAuthors != nil {
+		query.Set("show_authors = ?", *update.ShowAuthors)
+	}
+	if update.ShowNotesOfOtherUsers != nil {
+		query.Set("show_notes_of_other_users = ?", *update.ShowNotesOfOtherUsers)
+	}
+	if update.ShowNoteReactions != nil {
+		query.Set("show_note_reactions = ?", *update.ShowNoteReactions)
+	}
+	if update.AllowStacking != nil {
+		query.Set("allow_stacking = ?", *update.AllowStacking)
+	}
+
+	if columns != nil {
+		deleteColumns := d.db.NewDelete().Model((*ColumnTemplate)(nil)).Where("board_template = ?", update.ID)
+		query = query.With("deletedColumns", deleteColumns)
+
+		if len(columns) > 0 {
+			for index := range columns {
+				newColumnIndex := index
+				columns[index].Index = &newColumnIndex
+				columns[index].BoardTemplate = update.ID
+			}
+			query = query.With("createdColumns", d.db.NewInsert().Model(&columns))
+		}
+	}
+
+	// keep the statement valid if only the columns are replaced
+	query.Set("id = id")
+
+	var t BoardTemplate
+	_, err := query.
+		Where("id = ?", update.ID).
+		Returning("*").
+		Exec(context.Background(), &t)
+	if err != nil {
+		return BoardTemplate{}, nil, err
+	}
+
+	c, err := d.GetColumnTemplates(t.ID)
+	return t, c, err
+}
+
+// DeleteBoardTemplate deletes the template and all of its columns.
+func (d *Database) DeleteBoardTemplate(id uuid.UUID) error {
+	_, err := d.db.NewDelete().Model((*BoardTemplate)(nil)).Where("id = ?", id).Exec(context.Background())
+	return err
+}
+
+// GetBoardTemplate returns the template for the specified id with all of its columns.
+func (d *Database) GetBoardTemplate(id uuid.UUID) (BoardTemplate, []ColumnTemplate, error) {
+	var template BoardTemplate
+	err := d.db.NewSelect().Model(&template).Where("id = ?", id).Scan(context.Background())
+	if err != nil {
+		return BoardTemplate{}, nil, err
+	}
+
+	columns, err := d.GetColumnTemplates(id)
+	return template, columns, err
+}
+
+// GetBoardTemplates returns all templates of the specified creator with the columns of these templates.
+func (d *Database) GetBoardTemplates(creator uuid.UUID) ([]BoardTemplate, []ColumnTemplate, error) {
+	var templates []BoardTemplate
+	err := d.db.NewSelect().Model(&templates).Where("creator = ?", creator).Order("created_at ASC").Scan(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var columns []ColumnTemplate
+	err = d.db.NewSelect().
+		Model(&columns).
+		Where("board_template IN (SELECT id FROM board_templates WHERE creator = ?)", creator).
+		Order("index ASC").
+		Scan(context.Background())
+	return templates, columns, err
+}
+
+// GetColumnTemplates returns all columns of the specified template.
+func (d *Database) GetColumnTemplates(template uuid.UUID) ([]ColumnTemplate, error) {
+	var columns []ColumnTemplate
+	err := d.db.NewSelect().Model(&columns).Where("board_template = ?", template).Order("index ASC").Scan(context.Background())
+	return columns, err
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
+)
+
+func TestRunnerForTemplates(t *testing.T) {
+	t.Run("Create=0", testCreateTemplateWithDefaults)
+	t.Run("Create=1", testCreateTemplateWithColumns)
+	t.Run("Create=2", testCreateTemplateWithEmptyName)
+
+	t.Run("Update=0", testUpdateTemplateSettings)
+	t.Run("Update=1", testUpdateTemplateReplacesColumns)
+
+	t.Run("Get=0", testGetTemplatesOfCreator)
+
+	t.Run("Delete=0", testDeleteTemplate)
+}
+
+func testCreateTemplateWithDefaults(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+
+	template, columns, err := testDb.CreateBoardTemplate(BoardTemplateInsert{Creator: user.ID, Name: "Defaults"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, user.ID, template.Creator)
+	assert.Equal(t, "Defaults", template.Name)
+	assert.True(t, template.ShowAuthors)
+	assert.True(t, template.ShowNotesOfOtherUsers)
+	assert.True(t, template.ShowNoteReactions)
+	assert.True(t, template.AllowStacking)
+	assert.Empty(t, columns)
+}
+
+func testCreateTemplateWithColumns(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	showAuthors := false
+	visible := true
+
+	template, columns, err := testDb.CreateBoardTemplate(BoardTemplateInsert{Creator: user.ID, Name: "Columns", ShowAuthors: &showAuthors}, []ColumnTemplateInsert{
+		{Name: "A", Color: types.ColorBacklogBlue, Visible: &visible},
+		{Name: "B", Color: types.ColorBacklogBlue},
+	})
+	assert.Nil(t, err)
+	assert.False(t, template.ShowAuthors)
+	assert.Len(t, columns, 2)
+	assert.Equal(t, "A", columns[0].Name)
+	assert.Equal(t, 0, columns[0].Index)
+	assert.True(t, columns[0].Visible)
+	assert.Equal(t, "B", columns[1].Name)
+	assert.Equal(t, 1, columns[1].Index)
+	assert.False(t, columns[1].Visible)
+}
+
+func testCreateTemplateWithEmptyName(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+
+	_, _, err := testDb.CreateBoardTemplate(BoardTemplateInsert{Creator: user.ID}, nil)
+	assert.NotNil(t, err)
+}
+
+func testUpdateTemplateSettings(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	template, _, _ := testDb.CreateBoardTemplate(BoardTemplateInsert{Creator: user.ID, Name: "Update"}, []ColumnTemplateInsert{{Name: "A", Color: types.ColorBacklogBlue}})
+
+	name := "Updated"
+	allowStacking := false
+	updated, columns, err := testDb.UpdateBoardTemplate(BoardTemplateUpdate{ID: template.ID, Name: &name, AllowStacking: &allowStacking}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, name, updated.Name)
+	assert.False(t, updated.AllowStacking)
+	assert.True(t, updated.ShowAuthors)
+	assert.Len(t, columns, 1)
+}
+
+func testUpdateTemplateReplacesColumns(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	template, _, _ := testDb.CreateBoardTemplate(BoardTemplateInsert{Creator: user.ID, Name: "Replace"}, []ColumnTemplateInsert{{Name: "A", Color: types.ColorBacklogBlue}})
+
+	_, columns, err := testDb.UpdateBoardTemplate(BoardTemplateUpdate{ID: template.ID}, []ColumnTemplateInsert{
+		{Name: "B", Color: types.ColorBacklogBlue},
+		{Name: "C", Color: types.ColorBacklogBlue},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, columns, 2)
+	assert.Equal(t, "B", columns[0].Name)
+	assert.Equal(t, "C", columns[1].Name)
+	assert.Equal(t, 1, columns[1].Index)
+}
+
+func testGetTemplatesOfCreator(t *testing.T) {
+	user := fixture.MustRow("User.jay").(*User)
+	first, _, _ := testDb.CreateBoardTemplate(BoardTemplateInsert{Creator: user.ID, Name: "First"}, []ColumnTemplateInsert{{Name: "A", Color: types.ColorBacklogBlue}})
+	second, _, _ := testDb.CreateBoardTemplate(BoardTemplateInsert{Creator: user.ID, Name: "Second"}, nil)
+
+	templates, columns, err := testDb.GetBoardTemplates(user.ID)
+	assert.Nil(t, err)
+	assert.Len(t, templates, 2)
+	assert.Equal(t, first.ID, templates[0].ID)
+	assert.Equal(t, second.ID, templates[1].ID)
+	assert.Len(t, columns, 1)
+	assert.Equal(t, first.ID, columns[0].BoardTemplate)
+}
+
+func testDeleteTemplate(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	template, _, _ := testDb.CreateBoardTemplate(BoardTemplateInsert{Creator: user.ID, Name: "Delete"}, []ColumnTemplateInsert{{Name: "A", Color: types.ColorBacklogBlue}})
+
+	err := testDb.DeleteBoardTemplate(template.ID)
+	assert.Nil(t, err)
+
+	_, _, err = testDb.GetBoardTemplate(template.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	columns, err := testDb.GetColumnTemplates(template.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, columns)
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ActionItemStatus is the progress of an action item and can be one of open, in progress or done.
+type ActionItemStatus string
+
+const (
+	// ActionItemStatusOpen is the state of an action item nobody started working on yet.
+	ActionItemStatusOpen ActionItemStatus = "OPEN"
+
+	// ActionItemStatusInProgress is the state of an action item which is currently worked on.
+	ActionItemStatusInProgress ActionItemStatus = "IN_PROGRESS"
+
+	// ActionItemStatusDone is the state of a completed action item.
+	ActionItemStatusDone ActionItemStatus = "DONE"
+)
+
+func (actionItemStatus *ActionItemStatus) UnmarshalJSON(b []byte) error {
+	var s string
+	json.Unmarshal(b, &s)
+	unmarshalledActionItemStatus := ActionItemStatus(s)
+	switch unmarshalledActionItemStatus {
+	case ActionItemStatusOpen, ActionItemStatusInProgress, ActionItemStatusDone:
+		*actionItemStatus = unmarshalledActionItemStatus
+		return nil
+	}
+	return errors.New("invalid action item status")
+}
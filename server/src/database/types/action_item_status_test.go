@@ -0,0 +1,41 @@
+package types
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestActionItemStatusEnum(t *testing.T) {
+	values := []ActionItemStatus{ActionItemStatusOpen, ActionItemStatusInProgress, ActionItemStatusDone}
+	for _, value := range values {
+		var actionItemStatus ActionItemStatus
+		err := actionItemStatus.UnmarshalJSON([]byte(fmt.Sprintf("\"%s\"", value)))
+		assert.Nil(t, err)
+		assert.Equal(t, value, actionItemStatus)
+	}
+}
+
+func TestUnmarshalActionItemStatusNil(t *testing.T) {
+	var actionItemStatus ActionItemStatus
+	err := actionItemStatus.UnmarshalJSON(nil)
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalActionItemStatusEmptyString(t *testing.T) {
+	var actionItemStatus ActionItemStatus
+	err := actionItemStatus.UnmarshalJSON([]byte(""))
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalActionItemStatusEmptyStringWithQuotation(t *testing.T) {
+	var actionItemStatus ActionItemStatus
+	err := actionItemStatus.UnmarshalJSON([]byte("\"\""))
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalActionItemStatusRandomValue(t *testing.T) {
+	var actionItemStatus ActionItemStatus
+	err := actionItemStatus.UnmarshalJSON([]byte("\"SOME_RANDOM_VALUE\""))
+	assert.NotNil(t, err)
+}
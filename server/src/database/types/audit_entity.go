@@ -0,0 +1,38 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// AuditEntity is the type of entity an entry of the audit log refers to.
+type AuditEntity string
+
+const (
+	// AuditEntityBoard is the entity type for changes of the board settings.
+	AuditEntityBoard AuditEntity = "BOARD"
+
+	// AuditEntitySession is the entity type for changes of the role of a participant.
+	AuditEntitySession AuditEntity = "SESSION"
+
+	// AuditEntitySessionRequest is the entity type for accepted or rejected join requests.
+	AuditEntitySessionRequest AuditEntity = "SESSION_REQUEST"
+
+	// AuditEntityColumn is the entity type for deleted columns.
+	AuditEntityColumn AuditEntity = "COLUMN"
+
+	// AuditEntityVoting is the entity type for created, closed, aborted or reopened votings.
+	AuditEntityVoting AuditEntity = "VOTING"
+)
+
+func (auditEntity *AuditEntity) UnmarshalJSON(b []byte) error {
+	var s string
+	json.Unmarshal(b, &s)
+	unmarshalledAuditEntity := AuditEntity(s)
+	switch unmarshalledAuditEntity {
+	case AuditEntityBoard, AuditEntitySession, AuditEntitySessionRequest, AuditEntityColumn, AuditEntityVoting:
+		*auditEntity = unmarshalledAuditEntity
+		return nil
+	}
+	return errors.New("invalid audit entity")
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAuditEntityEnum(t *testing.T) {
+	values := []AuditEntity{AuditEntityBoard, AuditEntitySession, AuditEntitySessionRequest, AuditEntityColumn, AuditEntityVoting}
+	for _, value := range values {
+		var auditEntity AuditEntity
+		err := auditEntity.UnmarshalJSON([]byte(fmt.Sprintf("\"%s\"", value)))
+		assert.Nil(t, err)
+		assert.Equal(t, value, auditEntity)
+	}
+}
+
+func TestUnmarshalAuditEntityNil(t *testing.T) {
+	var auditEntity AuditEntity
+	err := auditEntity.UnmarshalJSON(nil)
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalAuditEntityEmptyString(t *testing.T) {
+	var auditEntity AuditEntity
+	err := auditEntity.UnmarshalJSON([]byte(""))
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalAuditEntityEmptyStringWithQuotation(t *testing.T) {
+	var auditEntity AuditEntity
+	err := auditEntity.UnmarshalJSON([]byte("\"\""))
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalAuditEntityRandomValue(t *testing.T) {
+	var auditEntity AuditEntity
+	err := auditEntity.UnmarshalJSON([]byte("\"SOME_RANDOM_VALUE\""))
+	assert.NotNil(t, err)
+}
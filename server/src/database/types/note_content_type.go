@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// NoteContentType is the way the text of a note is interpreted and can be one of plain, markdown or checklist.
+type NoteContentType string
+
+const (
+	// NoteContentTypePlain is the type of a note with plain text.
+	NoteContentTypePlain NoteContentType = "PLAIN"
+
+	// NoteContentTypeMarkdown is the type of a note with Markdown text. Raw HTML and unsafe link targets are removed
+	// from the text by the server.
+	NoteContentTypeMarkdown NoteContentType = "MARKDOWN"
+
+	// NoteContentTypeChecklist is the type of a note with checklist items. The text of the note is the title of the
+	// checklist.
+	NoteContentTypeChecklist NoteContentType = "CHECKLIST"
+)
+
+func (contentType *NoteContentType) UnmarshalJSON(b []byte) error {
+	var s string
+	json.Unmarshal(b, &s)
+	unmarshalledContentType := NoteContentType(s)
+	switch unmarshalledContentType {
+	case NoteContentTypePlain, NoteContentTypeMarkdown, NoteContentTypeChecklist:
+		*contentType = unmarshalledContentType
+		return nil
+	}
+	return errors.New("invalid note content type")
+}
+
+// LinkPreview is the unfurl metadata of a link within the text of a note.
+type LinkPreview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+}
+
+// ChecklistItem is a single item of a checklist note.
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNoteContentTypeEnum(t *testing.T) {
+	values := []NoteContentType{NoteContentTypePlain, NoteContentTypeMarkdown, NoteContentTypeChecklist}
+	for _, value := range values {
+		var contentType NoteContentType
+		err := contentType.UnmarshalJSON([]byte(fmt.Sprintf("\"%s\"", value)))
+		assert.Nil(t, err)
+		assert.Equal(t, value, contentType)
+	}
+}
+
+func TestUnmarshalNoteContentTypeNil(t *testing.T) {
+	var contentType NoteContentType
+	err := contentType.UnmarshalJSON(nil)
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalNoteContentTypeEmptyString(t *testing.T) {
+	var contentType NoteContentType
+	err := contentType.UnmarshalJSON([]byte(""))
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalNoteContentTypeEmptyStringWithQuotation(t *testing.T) {
+	var contentType NoteContentType
+	err := contentType.UnmarshalJSON([]byte("\"\""))
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalNoteContentTypeRandomValue(t *testing.T) {
+	var contentType NoteContentType
+	err := contentType.UnmarshalJSON([]byte("\"SOME_RANDOM_VALUE\""))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// VotingMode is the way votes are cast and tallied and can be one of dot, weighted or ranked.
+type VotingMode string
+
+const (
+	// VotingModeDot is the mode of a classic dot voting, in which each vote counts as one point.
+	VotingModeDot VotingMode = "DOT"
+
+	// VotingModeWeighted is the mode of a voting, in which participants spread a budget of points across notes.
+	//
+	// The vote limit of the voting is the budget of each participant.
+	VotingModeWeighted VotingMode = "WEIGHTED"
+
+	// VotingModeRanked is the mode of a ranked-choice voting, in which participants order their top notes.
+	//
+	// The vote limit of the voting is the number of notes to rank. Results are tallied by Borda count, so the note on
+	// rank 1 receives as many points as there are ranks and the note on the last rank receives one point.
+	VotingModeRanked VotingMode = "RANKED"
+)
+
+func (votingMode *VotingMode) UnmarshalJSON(b []byte) error {
+	var s string
+	json.Unmarshal(b, &s)
+	unmarshalledVotingMode := VotingMode(s)
+	switch unmarshalledVotingMode {
+	case VotingModeDot, VotingModeWeighted, VotingModeRanked:
+		*votingMode = unmarshalledVotingMode
+		return nil
+	}
+	return errors.New("invalid voting mode")
+}
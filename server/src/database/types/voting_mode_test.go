@@ -0,0 +1,41 @@
+package types
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVotingModeEnum(t *testing.T) {
+	values := []VotingMode{VotingModeDot, VotingModeWeighted, VotingModeRanked}
+	for _, value := range values {
+		var votingMode VotingMode
+		err := votingMode.UnmarshalJSON([]byte(fmt.Sprintf("\"%s\"", value)))
+		assert.Nil(t, err)
+		assert.Equal(t, value, votingMode)
+	}
+}
+
+func TestUnmarshalVotingModeNil(t *testing.T) {
+	var votingMode VotingMode
+	err := votingMode.UnmarshalJSON(nil)
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalVotingModeEmptyString(t *testing.T) {
+	var votingMode VotingMode
+	err := votingMode.UnmarshalJSON([]byte(""))
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalVotingModeEmptyStringWithQuotation(t *testing.T) {
+	var votingMode VotingMode
+	err := votingMode.UnmarshalJSON([]byte("\"\""))
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalVotingModeRandomValue(t *testing.T) {
+	var votingMode VotingMode
+	err := votingMode.UnmarshalJSON([]byte("\"SOME_RANDOM_VALUE\""))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
+)
+
+// UserBoard the model for a board a user has a session on, including some statistics of the board
+type UserBoard struct {
+	bun.BaseModel `bun:"table:boards"`
+	ID            uuid.UUID
+	Name          *string
+	AccessPolicy  types.AccessPolicy
+	CreatedAt     time.Time
+	Role          types.SessionRole
+	JoinedAt      time.Time
+	NoteCount     int
+	LastActivity  time.Time
+}
+
+// GetUserBoards returns the boards the user has a session on, ordered by their last activity, and the total count of
+// boards matching the filter.
+func (d *Database) GetUserBoards(f filter.UserBoardFilter) ([]UserBoard, int, error) {
+	query := d.db.NewSelect().
+		TableExpr("board_sessions AS s").
+		Join("INNER JOIN boards AS b ON b.id = s.board").
+		Where("s.user = ?", f.User)
+	if f.Role != nil {
+		query = query.Where("s.role = ?", *f.Role)
+	}
+
+	var boards []UserBoard
+	count, err := query.
+		ColumnExpr("b.id, b.name, b.access_policy, b.created_at, s.role, s.created_at AS joined_at").
+		ColumnExpr("(SELECT COUNT(*) FROM notes AS n WHERE n.board = b.id AND n.deleted_at IS NULL) AS note_count").
+		ColumnExpr(lastActivityExpr+" AS last_activity").
+		OrderExpr("last_activity DESC, b.id").
+		Limit(f.Limit).
+		Offset(f.Offset).
+		ScanAndCount(context.Background(), &boards)
+	return boards, count, err
+}
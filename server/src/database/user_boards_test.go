@@ -0,0 +1,74 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
+)
+
+func TestRunnerForUserBoards(t *testing.T) {
+	t.Run("Get=0", testGetUserBoards)
+	t.Run("Get=1", testGetUserBoardsFilteredByRole)
+	t.Run("Get=2", testGetUserBoardsPaginated)
+}
+
+func testGetUserBoards(t *testing.T) {
+	user := fixture.MustRow("User.justin").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{{Name: "Column", Color: types.ColorBacklogBlue}})
+	assert.Nil(t, err)
+	columns, _ := testDb.GetColumns(board.ID)
+	_, _ = testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "First"})
+	_, _ = testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "Second"})
+
+	boards, total, err := testDb.GetUserBoards(filter.UserBoardFilter{User: user.ID, Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, total, len(boards))
+
+	// the most recent activity comes first
+	assert.Equal(t, board.ID, boards[0].ID)
+	assert.Equal(t, types.SessionRoleOwner, boards[0].Role)
+	assert.Equal(t, 2, boards[0].NoteCount)
+	assert.False(t, boards[0].LastActivity.Before(boards[0].CreatedAt))
+}
+
+func testGetUserBoardsFilteredByRole(t *testing.T) {
+	owner := fixture.MustRow("User.justin").(*User)
+	user := fixture.MustRow("User.jennifer").(*User)
+	board, _ := testDb.CreateBoard(owner.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{})
+	_, err := testDb.CreateBoardSession(BoardSessionInsert{Board: board.ID, User: user.ID, Role: types.SessionRoleParticipant})
+	assert.Nil(t, err)
+
+	role := types.SessionRoleParticipant
+	boards, total, err := testDb.GetUserBoards(filter.UserBoardFilter{User: user.ID, Role: &role, Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, total, len(boards))
+	for _, b := range boards {
+		assert.Equal(t, types.SessionRoleParticipant, b.Role)
+	}
+	assert.Contains(t, boardIDs(boards), board.ID.String())
+}
+
+func testGetUserBoardsPaginated(t *testing.T) {
+	user := fixture.MustRow("User.justin").(*User)
+	_, _ = testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{})
+
+	all, total, err := testDb.GetUserBoards(filter.UserBoardFilter{User: user.ID, Limit: 100})
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, total, 2)
+
+	page, pageTotal, err := testDb.GetUserBoards(filter.UserBoardFilter{User: user.ID, Limit: 1, Offset: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, total, pageTotal)
+	assert.Len(t, page, 1)
+	assert.Equal(t, all[1].ID, page[0].ID)
+}
+
+func boardIDs(boards []UserBoard) []string {
+	ids := make([]string, len(boards))
+	for index, board := range boards {
+		ids[index] = board.ID.String()
+	}
+	return ids
+}
@@ -14,61 +14,144 @@ type Vote struct {
 	Voting        uuid.UUID
 	User          uuid.UUID
 	Note          uuid.UUID
+	Weight        int `bun:",nullzero"`
+	Rank          *int
 }
 
+// VoteError is the reason why a vote was rejected.
+type VoteError string
+
+func (e VoteError) Error() string {
+	return string(e)
+}
+
+const (
+	VoteErrorNoOpenVoting         VoteError = "no active voting session found"
+	VoteErrorUnknownNote          VoteError = "note is not part of the board"
+	VoteErrorColumnNotAllowed     VoteError = "notes of this column are not part of the voting"
+	VoteErrorOwnNote              VoteError = "votes on own notes are not allowed in this voting"
+	VoteErrorInvalidWeight        VoteError = "weights other than 1 are only allowed in weighted votings"
+	VoteErrorInvalidRank          VoteError = "a rank between 1 and the vote limit must be set in ranked votings and only there"
+	VoteErrorRankTaken            VoteError = "rank is already assigned to another note"
+	VoteErrorVoteLimitReached     VoteError = "vote limit reached"
+	VoteErrorMultipleVotes        VoteError = "multiple votes on the same note are not allowed in this voting"
+	VoteErrorNoteVoteLimitReached VoteError = "vote limit per note reached"
+)
+
 // AddVote adds a vote to the current open voting session.
 //
-// If the vote limit is reached no further votes will be allowed.
-func (d *Database) AddVote(board, user, note uuid.UUID) (Vote, error) {
+// The weight is the number of points spent on the note, which must be 1 unless the voting mode is 'WEIGHTED'. The
+// rank must be set for votings with the mode 'RANKED' only and has to be between 1 and the vote limit, whereas each
+// rank and each note can only be chosen once by a user.
+//
+// If the vote violates any rule of the voting, such as the vote limit, the columns of the voting or the limit per
+// note, no vote is added and the rule is returned as VoteError.
+func (d *Database) AddVote(board, user, note uuid.UUID, weight int, rank *int) (Vote, error) {
 	openVotingQuery := d.db.NewSelect().
 		Model((*Voting)(nil)).
-		Column("id", "vote_limit", "allow_multiple_votes").
+		Column("id", "vote_limit", "allow_multiple_votes", "mode", "columns", "prevent_self_votes", "max_votes_per_note").
 		Where("board = ?", board).
 		Where("status = ?", types.VotingStatusOpen)
 
-	currentVoteCount := d.db.NewSelect().
-		Model((*Vote)(nil)).ColumnExpr("note").
+	noteQuery := d.db.NewSelect().
+		Model((*Note)(nil)).
+		Column("id", "author", "column").
+		Where("board = ?", board).
+		Where("id = ?", note)
+
+	// votes on deleted notes don't count towards the vote limit
+	currentPoints := d.db.NewSelect().
+		Model((*Vote)(nil)).
+		ColumnExpr("COALESCE(SUM(weight), 0) as points").
 		Where("voting = (SELECT id FROM \"openVotingQuery\")").
-		Where("\"user\" = ?", user)
+		Where("\"user\" = ?", user).
+		Where("note IN (?)", d.db.NewSelect().Model((*Note)(nil)).Column("id").Where("board = ?", board))
 
-	currentVotesOnNoteCount := d.db.NewSelect().
+	currentVotesOnNote := d.db.NewSelect().
 		Model((*Vote)(nil)).
 		ColumnExpr("COUNT(*) as count").
+		ColumnExpr("COALESCE(SUM(weight), 0) as points").
 		Where("voting = (SELECT id FROM \"openVotingQuery\")").
 		Where("\"user\" = ?", user).
 		Where("note = ?", note)
 
+	currentVotesOnRankCount := d.db.NewSelect().
+		Model((*Vote)(nil)).
+		ColumnExpr("COUNT(*) as count").
+		Where("voting = (SELECT id FROM \"openVotingQuery\")").
+		Where("\"user\" = ?", user).
+		Where("rank = ?", rank)
+
+	// the first violated rule is the reason to reject the vote
+	check := d.db.NewSelect().
+		ColumnExpr("CASE "+
+			"WHEN NOT EXISTS (SELECT 1 FROM \"openVotingQuery\") THEN ? "+
+			"WHEN NOT EXISTS (SELECT 1 FROM \"noteQuery\") THEN ? "+
+			"WHEN (SELECT \"columns\" IS NOT NULL AND NOT ((SELECT \"column\" FROM \"noteQuery\") = ANY(\"columns\")) FROM \"openVotingQuery\") THEN ? "+
+			"WHEN (SELECT prevent_self_votes FROM \"openVotingQuery\") AND (SELECT author FROM \"noteQuery\") = ? THEN ? "+
+			"WHEN (SELECT mode <> ? FROM \"openVotingQuery\") AND ? <> 1 THEN ? "+
+			"WHEN (SELECT mode = ? FROM \"openVotingQuery\") <> (?::int IS NOT NULL) THEN ? "+
+			"WHEN ?::int NOT BETWEEN 1 AND (SELECT vote_limit FROM \"openVotingQuery\") THEN ? "+
+			"WHEN (SELECT count FROM \"currentVotesOnRankCount\") > 0 THEN ? "+
+			"WHEN (SELECT points FROM \"currentPoints\") + ? > (SELECT vote_limit FROM \"openVotingQuery\") THEN ? "+
+			"WHEN (SELECT count FROM \"currentVotesOnNote\") > 0 AND (SELECT NOT(allow_multiple_votes) OR mode = ? FROM \"openVotingQuery\") THEN ? "+
+			"WHEN (SELECT points FROM \"currentVotesOnNote\") + ? > (SELECT max_votes_per_note FROM \"openVotingQuery\") THEN ? "+
+			"END AS reason",
+			VoteErrorNoOpenVoting,
+			VoteErrorUnknownNote,
+			VoteErrorColumnNotAllowed,
+			user, VoteErrorOwnNote,
+			types.VotingModeWeighted, weight, VoteErrorInvalidWeight,
+			types.VotingModeRanked, rank, VoteErrorInvalidRank,
+			rank, VoteErrorInvalidRank,
+			VoteErrorRankTaken,
+			weight, VoteErrorVoteLimitReached,
+			types.VotingModeRanked, VoteErrorMultipleVotes,
+			weight, VoteErrorNoteVoteLimitReached,
+		)
+
 	values := d.db.NewSelect().
 		ColumnExpr("(SELECT id FROM \"openVotingQuery\") as voting").
 		ColumnExpr("uuid(?) as board", board).
 		ColumnExpr("uuid(?) as note", note).
 		ColumnExpr("uuid(?) as \"user\"", user).
-		Where("(SELECT COUNT(*) FROM \"currentVoteCount\") < (SELECT vote_limit FROM \"openVotingQuery\")").
-		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
-			return q.
-				WhereOr("(SELECT allow_multiple_votes FROM \"openVotingQuery\")").
-				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
-					return q.
-						Where("(SELECT NOT(allow_multiple_votes) FROM \"openVotingQuery\")").
-						Where("(SELECT count FROM \"currentVotesOnNoteCount\") < 1")
-				})
+		ColumnExpr("? as weight", weight).
+		ColumnExpr("?::int as rank", rank).
+		Where("(SELECT reason FROM \"_check\") IS NULL")
 
-		})
-
-	var result Vote
 	insert := Vote{Board: board, User: user, Note: note}
-	_, err := d.db.NewInsert().
-		With("openVotingQuery", openVotingQuery).
-		With("currentVoteCount", currentVoteCount).
-		With("currentVotesOnNoteCount", currentVotesOnNoteCount).
-		With("_values", values).
+	insertQuery := d.db.NewInsert().
 		Model(&insert).
 		TableExpr("_values").
-		Column("board", "voting", "user", "note").
-		Returning("*").
-		Exec(context.Background(), &result)
+		Column("board", "voting", "user", "note", "weight", "rank").
+		Returning("*")
+
+	var result struct {
+		Vote
+		Reason *string
+	}
+	err := d.db.NewSelect().
+		With("openVotingQuery", openVotingQuery).
+		With("noteQuery", noteQuery).
+		With("currentPoints", currentPoints).
+		With("currentVotesOnNote", currentVotesOnNote).
+		With("currentVotesOnRankCount", currentVotesOnRankCount).
+		With("_check", check).
+		With("_values", values).
+		With("insertedVote", insertQuery).
+		TableExpr("\"_check\"").
+		ColumnExpr("\"insertedVote\".*").
+		ColumnExpr("\"_check\".reason").
+		Join("LEFT JOIN \"insertedVote\" ON true").
+		Scan(context.Background(), &result)
+	if err != nil {
+		return Vote{}, err
+	}
+	if result.Reason != nil {
+		return Vote{}, VoteError(*result.Reason)
+	}
 
-	return result, err
+	return result.Vote, err
 }
 
 // RemoveVote removes a vote from the current voting session.
@@ -87,9 +170,62 @@ func (d *Database) RemoveVote(board, user, note uuid.UUID) error {
 	return err
 }
 
-// GetVotes returns all votes for a closed voting session.
+// removeVotesAboveLimit removes the votes of the open voting on the specified notes, which violate the rules of the
+// voting. This applies to notes that have been restored, since the votes on deleted notes don't count and users may
+// have spent them on other notes in the meantime. All votes on the notes are removed for users who exceed the vote
+// limit, as well as ranked votes whose rank has been assigned to another note in the meantime.
+func (d *Database) removeVotesAboveLimit(board uuid.UUID, notes []uuid.UUID) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	openVotingQuery := d.db.NewSelect().
+		Model((*Voting)(nil)).
+		Column("id", "vote_limit").
+		Where("board = ?", board).
+		Where("status = ?", types.VotingStatusOpen)
+
+	boardNotes := d.db.NewSelect().Model((*Note)(nil)).Column("id").Where("board = ?", board)
+
+	exceedingUsers := d.db.NewSelect().
+		Model((*Vote)(nil)).
+		ColumnExpr("\"user\"").
+		Where("voting = (SELECT id FROM \"openVotingQuery\")").
+		Where("note IN (?)", boardNotes).
+		GroupExpr("\"user\"").
+		Having("COALESCE(SUM(weight), 0) > (SELECT vote_limit FROM \"openVotingQuery\")")
+
+	takenRanks := d.db.NewSelect().
+		Model((*Vote)(nil)).
+		ColumnExpr("\"user\", rank").
+		Where("voting = (SELECT id FROM \"openVotingQuery\")").
+		Where("rank IS NOT NULL").
+		Where("note NOT IN (?)", bun.In(notes)).
+		Where("note IN (?)", boardNotes)
+
+	_, err := d.db.NewDelete().
+		With("openVotingQuery", openVotingQuery).
+		With("exceedingUsers", exceedingUsers).
+		With("takenRanks", takenRanks).
+		Model((*Vote)(nil)).
+		Where("voting = (SELECT id FROM \"openVotingQuery\")").
+		Where("note IN (?)", bun.In(notes)).
+		WhereGroup(" AND ", func(q *bun.DeleteQuery) *bun.DeleteQuery {
+			return q.
+				Where("\"user\" IN (SELECT \"user\" FROM \"exceedingUsers\")").
+				WhereOr("(\"user\", rank) IN (SELECT \"user\", rank FROM \"takenRanks\")")
+		}).
+		Exec(context.Background())
+
+	return err
+}
+
+// GetVotes returns all votes for a closed voting session. Votes on deleted notes are left out.
 func (d *Database) GetVotes(f filter.VoteFilter) ([]Vote, error) {
-	voteQuery := d.db.NewSelect().Model((*Vote)(nil)).Where("board = ?", f.Board)
+	voteQuery := d.db.NewSelect().
+		Model((*Vote)(nil)).
+		Where("board = ?", f.Board).
+		Where("note IN (?)", d.db.NewSelect().Model((*Note)(nil)).Column("id").Where("board = ?", f.Board))
 
 	if f.Voting != nil {
 		voteQuery = voteQuery.Where("voting = ?", *f.Voting)
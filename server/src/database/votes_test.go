@@ -1,7 +1,9 @@
 package database
 
 import (
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"scrumlr.io/server/database/types"
 	"testing"
 )
 
@@ -11,6 +13,12 @@ func TestRunnerForVotes(t *testing.T) {
 	t.Run("Add=2", testAddVoteOnAbortedSessionShouldFailed)
 	t.Run("Add=3", testAddVoteAboveLimit)
 	t.Run("Add=4", testAddMultipleVotesWhenNotAllowedShouldFail)
+	t.Run("Add=5", testAddWeightedVotesWithinBudget)
+	t.Run("Add=6", testAddRankedVotes)
+	t.Run("Add=7", testAddWeightedVoteOnDotVotingShouldFail)
+	t.Run("Add=8", testAddVoteOnColumnOutsideOfVotingShouldFail)
+	t.Run("Add=9", testAddVoteOnOwnNoteShouldFail)
+	t.Run("Add=10", testAddVotesAboveLimitPerNoteShouldFail)
 
 	t.Run("Remove=0", testRemoveVote)
 	t.Run("Remove=1", testRemoveVoteOnClosedSessionShouldFail)
@@ -23,7 +31,7 @@ func testAddVote(t *testing.T) {
 	user := fixture.MustRow("User.jack").(*User)
 	note := fixture.MustRow("Note.openMultipleVotesTestBoardNote").(*Note)
 
-	vote, err := testDb.AddVote(board.ID, user.ID, note.ID)
+	vote, err := testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, voting.ID, vote.Voting)
 	assert.Equal(t, user.ID, vote.User)
@@ -35,7 +43,7 @@ func testAddVoteOnClosedSessionShouldFailed(t *testing.T) {
 	user := fixture.MustRow("User.jack").(*User)
 	note := fixture.MustRow("Note.closedVotesTestBoardNote").(*Note)
 
-	_, err := testDb.AddVote(board.ID, user.ID, note.ID)
+	_, err := testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
 	assert.NotNil(t, err)
 }
 
@@ -44,7 +52,7 @@ func testAddVoteOnAbortedSessionShouldFailed(t *testing.T) {
 	user := fixture.MustRow("User.jack").(*User)
 	note := fixture.MustRow("Note.abortedVotesTestBoardNote").(*Note)
 
-	_, err := testDb.AddVote(board.ID, user.ID, note.ID)
+	_, err := testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
 	assert.NotNil(t, err)
 }
 
@@ -53,10 +61,10 @@ func testAddVoteAboveLimit(t *testing.T) {
 	user := fixture.MustRow("User.jack").(*User)
 	note := fixture.MustRow("Note.openMultipleVotesTestBoardNote").(*Note)
 
-	_, err := testDb.AddVote(board.ID, user.ID, note.ID)
+	_, err := testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
 	assert.Nil(t, err)
 
-	_, err = testDb.AddVote(board.ID, user.ID, note.ID)
+	_, err = testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
 	assert.NotNil(t, err)
 }
 
@@ -65,10 +73,10 @@ func testAddMultipleVotesWhenNotAllowedShouldFail(t *testing.T) {
 	user := fixture.MustRow("User.jack").(*User)
 	note := fixture.MustRow("Note.openSingleVotesTestBoardNote").(*Note)
 
-	_, err := testDb.AddVote(board.ID, user.ID, note.ID)
+	_, err := testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
 	assert.Nil(t, err)
 
-	_, err = testDb.AddVote(board.ID, user.ID, note.ID)
+	_, err = testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
 	assert.NotNil(t, err)
 }
 
@@ -98,3 +106,135 @@ func testRemoveVoteOnAbortedSessionShouldFail(t *testing.T) {
 	err := testDb.RemoveVote(board.ID, user.ID, note.ID)
 	assert.Nil(t, err)
 }
+
+func createVotingModeTestBoard(t *testing.T, mode types.VotingMode, voteLimit int) (Board, Note, Note) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{{Name: "Column", Color: types.ColorBacklogBlue}})
+	assert.Nil(t, err)
+	columns, err := testDb.GetColumns(board.ID)
+	assert.Nil(t, err)
+
+	first, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "First"})
+	assert.Nil(t, err)
+	second, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "Second"})
+	assert.Nil(t, err)
+
+	_, err = testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: voteLimit, AllowMultipleVotes: true, Status: types.VotingStatusOpen, Mode: mode})
+	assert.Nil(t, err)
+	return board, first, second
+}
+
+func testAddWeightedVotesWithinBudget(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, first, second := createVotingModeTestBoard(t, types.VotingModeWeighted, 5)
+
+	vote, err := testDb.AddVote(board.ID, user.ID, first.ID, 3, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, vote.Weight)
+
+	_, err = testDb.AddVote(board.ID, user.ID, second.ID, 3, nil)
+	assert.NotNil(t, err)
+
+	_, err = testDb.AddVote(board.ID, user.ID, second.ID, 2, nil)
+	assert.Nil(t, err)
+}
+
+func testAddRankedVotes(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, first, second := createVotingModeTestBoard(t, types.VotingModeRanked, 2)
+	rank1, rank2, rank3 := 1, 2, 3
+
+	_, err := testDb.AddVote(board.ID, user.ID, first.ID, 1, nil)
+	assert.NotNil(t, err)
+
+	vote, err := testDb.AddVote(board.ID, user.ID, first.ID, 1, &rank1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, *vote.Rank)
+
+	_, err = testDb.AddVote(board.ID, user.ID, first.ID, 1, &rank2)
+	assert.NotNil(t, err)
+
+	_, err = testDb.AddVote(board.ID, user.ID, second.ID, 1, &rank1)
+	assert.NotNil(t, err)
+
+	_, err = testDb.AddVote(board.ID, user.ID, second.ID, 1, &rank3)
+	assert.NotNil(t, err)
+
+	_, err = testDb.AddVote(board.ID, user.ID, second.ID, 1, &rank2)
+	assert.Nil(t, err)
+}
+
+func testAddWeightedVoteOnDotVotingShouldFail(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, first, _ := createVotingModeTestBoard(t, types.VotingModeDot, 5)
+
+	_, err := testDb.AddVote(board.ID, user.ID, first.ID, 2, nil)
+	assert.NotNil(t, err)
+}
+
+// createVotingConstraintsTestBoard creates a board with a note in each of its two columns and opens the voting, which
+// is scoped to the second column if scoped is set.
+func createVotingConstraintsTestBoard(t *testing.T, voting VotingInsert, scoped bool) (Board, Note, Note) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{
+		{Name: "Went well", Color: types.ColorBacklogBlue},
+		{Name: "To improve", Color: types.ColorBacklogBlue},
+	})
+	assert.Nil(t, err)
+	columns, err := testDb.GetColumns(board.ID)
+	assert.Nil(t, err)
+
+	wentWell, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "Went well"})
+	assert.Nil(t, err)
+	toImprove, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[1].ID, Text: "To improve"})
+	assert.Nil(t, err)
+
+	voting.Board = board.ID
+	voting.Status = types.VotingStatusOpen
+	if scoped {
+		voting.Columns = []uuid.UUID{columns[1].ID}
+	}
+	_, err = testDb.CreateVoting(voting)
+	assert.Nil(t, err)
+	return board, wentWell, toImprove
+}
+
+func testAddVoteOnColumnOutsideOfVotingShouldFail(t *testing.T) {
+	user := fixture.MustRow("User.jay").(*User)
+	board, wentWell, toImprove := createVotingConstraintsTestBoard(t, VotingInsert{VoteLimit: 5}, true)
+
+	_, err := testDb.AddVote(board.ID, user.ID, wentWell.ID, 1, nil)
+	assert.Equal(t, VoteErrorColumnNotAllowed, err)
+
+	_, err = testDb.AddVote(board.ID, user.ID, toImprove.ID, 1, nil)
+	assert.Nil(t, err)
+}
+
+func testAddVoteOnOwnNoteShouldFail(t *testing.T) {
+	author := fixture.MustRow("User.jack").(*User)
+	user := fixture.MustRow("User.jay").(*User)
+	board, wentWell, _ := createVotingConstraintsTestBoard(t, VotingInsert{VoteLimit: 5, PreventSelfVotes: true}, false)
+
+	_, err := testDb.AddVote(board.ID, author.ID, wentWell.ID, 1, nil)
+	assert.Equal(t, VoteErrorOwnNote, err)
+
+	_, err = testDb.AddVote(board.ID, user.ID, wentWell.ID, 1, nil)
+	assert.Nil(t, err)
+}
+
+func testAddVotesAboveLimitPerNoteShouldFail(t *testing.T) {
+	user := fixture.MustRow("User.jay").(*User)
+	maxVotesPerNote := 2
+	board, wentWell, toImprove := createVotingConstraintsTestBoard(t, VotingInsert{VoteLimit: 5, AllowMultipleVotes: true, MaxVotesPerNote: &maxVotesPerNote}, false)
+
+	_, err := testDb.AddVote(board.ID, user.ID, wentWell.ID, 1, nil)
+	assert.Nil(t, err)
+	_, err = testDb.AddVote(board.ID, user.ID, wentWell.ID, 1, nil)
+	assert.Nil(t, err)
+
+	_, err = testDb.AddVote(board.ID, user.ID, wentWell.ID, 1, nil)
+	assert.Equal(t, VoteErrorNoteVoteLimitReached, err)
+
+	_, err = testDb.AddVote(board.ID, user.ID, toImprove.ID, 1, nil)
+	assert.Nil(t, err)
+}
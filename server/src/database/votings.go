@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 	"scrumlr.io/server/common"
 	"scrumlr.io/server/common/filter"
 	"scrumlr.io/server/database/types"
@@ -21,6 +22,12 @@ type Voting struct {
 	AllowMultipleVotes bool
 	ShowVotesOfOthers  bool
 	Status             types.VotingStatus
+	Mode               types.VotingMode
+	Anonymous          bool
+	Columns            []uuid.UUID `bun:",array"`
+	PreventSelfVotes   bool
+	MaxVotesPerNote    *int
+	ClosesAt           *time.Time
 }
 
 type VotingInsert struct {
@@ -30,6 +37,12 @@ type VotingInsert struct {
 	AllowMultipleVotes bool
 	ShowVotesOfOthers  bool
 	Status             types.VotingStatus
+	Mode               types.VotingMode
+	Anonymous          bool
+	Columns            []uuid.UUID `bun:",array"`
+	PreventSelfVotes   bool
+	MaxVotesPerNote    *int
+	ClosesAt           *time.Time
 }
 
 type VotingUpdate struct {
@@ -37,6 +50,10 @@ type VotingUpdate struct {
 	ID            uuid.UUID
 	Board         uuid.UUID
 	Status        types.VotingStatus
+	VoteLimit     *int `bun:"-"`
+
+	// Expired restricts closing the voting to the case its closing time has passed.
+	Expired bool `bun:"-"`
 }
 
 func (d *Database) CreateVoting(insert VotingInsert) (Voting, error) {
@@ -44,6 +61,10 @@ func (d *Database) CreateVoting(insert VotingInsert) (Voting, error) {
 		return Voting{}, errors.New("unable to create voting with other state than 'OPEN'")
 	}
 
+	if insert.Mode == "" {
+		insert.Mode = types.VotingModeDot
+	}
+
 	if insert.VoteLimit < 0 {
 		return Voting{}, errors.New("vote limit shall not be a negative number")
 	} else if insert.VoteLimit >= 100 {
@@ -57,6 +78,12 @@ func (d *Database) CreateVoting(insert VotingInsert) (Voting, error) {
 		ColumnExpr("? as show_votes_of_others", insert.ShowVotesOfOthers).
 		ColumnExpr("? as allow_multiple_votes", insert.AllowMultipleVotes).
 		ColumnExpr("?::voting_status as status", insert.Status).
+		ColumnExpr("?::voting_mode as mode", insert.Mode).
+		ColumnExpr("? as anonymous", insert.Anonymous).
+		ColumnExpr("?::uuid[] as \"columns\"", pgdialect.Array(insert.Columns)).
+		ColumnExpr("? as prevent_self_votes", insert.PreventSelfVotes).
+		ColumnExpr("?::int as max_votes_per_note", insert.MaxVotesPerNote).
+		ColumnExpr("?::timestamptz as closes_at", insert.ClosesAt).
 		Where("(SELECT count FROM \"countOpenVotings\") = 0")
 
 	updateBoard := d.db.NewUpdate().Model((*Board)(nil)).Set("show_voting = null").Where("(SELECT count FROM \"countOpenVotings\") = 0")
@@ -68,24 +95,39 @@ func (d *Database) CreateVoting(insert VotingInsert) (Voting, error) {
 		With("_values", values).
 		Model(&insert).
 		TableExpr("_values").
-		Column("board", "vote_limit", "show_votes_of_others", "allow_multiple_votes", "status").
+		Column("board", "vote_limit", "show_votes_of_others", "allow_multiple_votes", "status", "mode", "anonymous", "columns", "prevent_self_votes", "max_votes_per_note", "closes_at").
 		Returning("*").
 		Exec(common.ContextWithValues(context.Background(), "Database", d, "Result", &voting), &voting)
 
 	return voting, err
 }
 
+// UpdateVoting closes, aborts or reopens the voting.
+//
+// Reopening a closed or aborted voting is only possible as long as no other voting of the board is open, whereas the
+// votes of a closed voting are kept and the closing time is removed. The vote limit can be raised on reopening or on
+// an open voting, but it can't be lowered.
 func (d *Database) UpdateVoting(update VotingUpdate) (Voting, error) {
 	if update.Status == types.VotingStatusOpen {
-		return Voting{}, errors.New("only allowed to close or a abort a voting")
+		return d.reopenVoting(update)
+	}
+	if update.VoteLimit != nil {
+		return Voting{}, errors.New("only allowed to change the vote limit of an open voting")
+	}
+	if update.Expired && update.Status != types.VotingStatusClosed {
+		return Voting{}, errors.New("only allowed to close an expired voting")
 	}
 
 	updateQuery := d.db.NewUpdate().
 		Model(&update).
+		Column("status").
 		Where("id = ?", update.ID).
 		Where("board = ?", update.Board).
 		Where("status = ?", types.VotingStatusOpen).
 		Returning("*")
+	if update.Expired {
+		updateQuery = updateQuery.Where("closes_at <= now()")
+	}
 
 	var voting Voting
 	var err error
@@ -118,15 +160,80 @@ func (d *Database) UpdateVoting(update VotingUpdate) (Voting, error) {
 	return voting, err
 }
 
+func (d *Database) reopenVoting(update VotingUpdate) (Voting, error) {
+	if update.VoteLimit != nil {
+		if *update.VoteLimit < 0 {
+			return Voting{}, errors.New("vote limit shall not be a negative number")
+		} else if *update.VoteLimit >= 100 {
+			return Voting{}, errors.New("vote limit shall not be greater than 99")
+		}
+	}
+
+	countOtherOpenVotings := d.db.NewSelect().
+		Model((*Voting)(nil)).
+		ColumnExpr("COUNT(*) as count").
+		Where("board = ?", update.Board).
+		Where("status = ?", types.VotingStatusOpen).
+		Where("id <> ?", update.ID)
+
+	updateQuery := d.db.NewUpdate().
+		Model((*Voting)(nil)).
+		Set("status = ?", types.VotingStatusOpen).
+		// a reopened voting is closed manually, while raising the vote limit keeps the closing time of an open voting
+		Set("closes_at = CASE WHEN status <> ? THEN null ELSE closes_at END", types.VotingStatusOpen).
+		Where("id = ?", update.ID).
+		Where("board = ?", update.Board).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				WhereGroup(" OR ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+					return q.
+						Where("status <> ?", types.VotingStatusOpen).
+						Where("(SELECT count FROM \"countOtherOpenVotings\") = 0")
+				}).
+				WhereGroup(" OR ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+					return q.
+						Where("status = ?", types.VotingStatusOpen).
+						Where("?::int IS NOT NULL", update.VoteLimit)
+				})
+		}).
+		Returning("*")
+	if update.VoteLimit != nil {
+		updateQuery = updateQuery.
+			Set("vote_limit = ?", *update.VoteLimit).
+			Where("vote_limit <= ?", *update.VoteLimit)
+	}
+
+	// the results of the voting are no longer shown once it has been reopened
+	updateBoard := d.db.NewUpdate().
+		Model((*Board)(nil)).
+		Set("show_voting = null").
+		Where("id = ?", update.Board).
+		Where("show_voting = (SELECT id FROM \"updateQuery\")")
+
+	var voting Voting
+	err := d.db.NewSelect().
+		With("countOtherOpenVotings", countOtherOpenVotings).
+		With("updateQuery", updateQuery).
+		With("updateBoard", updateBoard).
+		Model((*Voting)(nil)).
+		ModelTableExpr("\"updateQuery\" AS voting").
+		Scan(common.ContextWithValues(context.Background(), "Database", d, "Result", &voting), &voting)
+
+	return voting, err
+}
+
+// votePointsExpr is the number of points of a vote "v" within its voting "vt", which depends on the mode of the voting.
+const votePointsExpr = "CASE WHEN vt.mode = 'RANKED' THEN vt.vote_limit - v.rank + 1 ELSE v.weight END"
+
 func (d *Database) getRankUpdateQueryForClosedVoting(votingQuery string) *bun.UpdateQuery {
 	newRankSelect := d.db.NewSelect().
 		TableExpr("notes as note").
 		ColumnExpr(fmt.Sprintf(
 			"ROW_NUMBER() OVER (PARTITION BY \"column\" ORDER BY "+
-				"(SELECT COUNT(*) FROM notes AS n INNER JOIN (SELECT * FROM VOTES WHERE voting = (SELECT id FROM \"%s\")) as v ON n.id = v.note WHERE n.id = note.id OR n.stack = note.id), rank)-1 AS new_rank",
-			votingQuery)).
+				"(SELECT COALESCE(SUM(%s), 0) FROM notes AS n INNER JOIN (SELECT * FROM VOTES WHERE voting = (SELECT id FROM \"%s\")) as v ON n.id = v.note INNER JOIN votings AS vt ON vt.id = v.voting WHERE (n.id = note.id OR n.stack = note.id) AND n.deleted_at IS NULL), rank)-1 AS new_rank",
+			votePointsExpr, votingQuery)).
 		Column("id").
-		Where(fmt.Sprintf("stack IS NULL AND board = (SELECT board FROM \"%s\")", votingQuery)).
+		Where(fmt.Sprintf("stack IS NULL AND deleted_at IS NULL AND board = (SELECT board FROM \"%s\")", votingQuery)).
 		GroupExpr("id")
 
 	rankUpdate := d.db.NewUpdate().With("_data", newRankSelect).
@@ -139,6 +246,17 @@ func (d *Database) getRankUpdateQueryForClosedVoting(votingQuery string) *bun.Up
 	return rankUpdate
 }
 
+// GetExpiredVotings returns all open votings, whose closing time has passed.
+func (d *Database) GetExpiredVotings() ([]Voting, error) {
+	var votings []Voting
+	err := d.db.NewSelect().
+		Model(&votings).
+		Where("status = ?", types.VotingStatusOpen).
+		Where("closes_at <= now()").
+		Scan(context.Background())
+	return votings, err
+}
+
 func (d *Database) GetVoting(board, id uuid.UUID) (Voting, []Vote, error) {
 	var voting Voting
 	err := d.db.NewSelect().Model(&voting).Where("board = ?", board).Where("id = ?", id).Scan(context.Background())
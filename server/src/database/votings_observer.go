@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
+	"scrumlr.io/server/database/types"
 )
 
 type VotingObserver interface {
@@ -26,6 +27,7 @@ func (*VotingInsert) AfterInsert(ctx context.Context, _ *bun.InsertQuery) error
 	d := ctx.Value("Database").(*Database)
 	if len(d.observer) > 0 {
 		request := ctx.Value("Result").(*Voting)
+		d.notifyAudit(request.Board, types.AuditEntityVoting, request.ID, "CREATED", newVotingAuditPayload(*request))
 		for _, observer := range d.observer {
 			if o, ok := observer.(VotingObserver); ok {
 				o.CreatedVoting(request.Board, *request)
@@ -43,6 +45,7 @@ func (*Voting) AfterScanRow(ctx context.Context) error {
 	d := ctx.Value("Database").(*Database)
 	if len(d.observer) > 0 {
 		request := ctx.Value("Result").(*Voting)
+		d.notifyAudit(request.Board, types.AuditEntityVoting, request.ID, string(request.Status), newVotingAuditPayload(*request))
 		for _, observer := range d.observer {
 			if o, ok := observer.(VotingObserver); ok {
 				o.UpdatedVoting(request.Board, *request)
@@ -1,10 +1,14 @@
 package database
 
 import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"scrumlr.io/server/common/filter"
 	"scrumlr.io/server/database/types"
-	"testing"
 )
 
 func TestRunnerForVoting(t *testing.T) {
@@ -23,6 +27,15 @@ func TestRunnerForVoting(t *testing.T) {
 	t.Run("Update=4", testCloseVoting)
 	t.Run("Update=5", testCloseVotingUpdateRank)
 
+	t.Run("Reopen=0", testReopenClosedVotingKeepsVotes)
+	t.Run("Reopen=1", testReopenVotingWhileOtherIsOpenShouldFail)
+	t.Run("Reopen=2", testRaiseVoteLimitOfOpenVoting)
+	t.Run("Reopen=3", testLowerVoteLimitShouldFail)
+	t.Run("Reopen=4", testRaiseVoteLimitOfTimedVotingKeepsClosingTime)
+
+	t.Run("Expire=0", testCloseExpiredVoting)
+	t.Run("Expire=1", testCloseVotingBeforeClosingTimeShouldFail)
+
 	t.Run("Create=0", testCreateVotingWithNegativeVoteLimitShouldFail)
 	t.Run("Create=1", testCreateVotingWithVoteLimitGreater99ShouldFail)
 	t.Run("Create=2", testCreateVoting)
@@ -219,3 +232,141 @@ func testCloseVotingUpdateRank(t *testing.T) {
 	assert.Equal(t, note5.Rank, 1)
 	assert.Equal(t, note6.Rank, 1)
 }
+
+func createReopenVotingTestBoard(t *testing.T) (Board, Note, Voting) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{{Name: "Column", Color: types.ColorBacklogBlue}})
+	assert.Nil(t, err)
+	columns, err := testDb.GetColumns(board.ID)
+	assert.Nil(t, err)
+	note, err := testDb.CreateNote(NoteInsert{Author: user.ID, Board: board.ID, Column: columns[0].ID, Text: "Note"})
+	assert.Nil(t, err)
+
+	voting, err := testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: 2, AllowMultipleVotes: true, Status: types.VotingStatusOpen})
+	assert.Nil(t, err)
+	return board, note, voting
+}
+
+func testReopenClosedVotingKeepsVotes(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, note, voting := createReopenVotingTestBoard(t)
+
+	_, err := testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
+	assert.Nil(t, err)
+	_, err = testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusClosed})
+	assert.Nil(t, err)
+
+	voteLimit := 3
+	reopened, err := testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusOpen, VoteLimit: &voteLimit})
+	assert.Nil(t, err)
+	assert.Equal(t, types.VotingStatusOpen, reopened.Status)
+	assert.Equal(t, 3, reopened.VoteLimit)
+
+	gotBoard, err := testDb.GetBoard(board.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, uuid.NullUUID{}, gotBoard.ShowVoting)
+
+	_, err = testDb.AddVote(board.ID, user.ID, note.ID, 1, nil)
+	assert.Nil(t, err)
+	votes, err := testDb.GetVotes(filter.VoteFilter{Board: board.ID, Voting: &voting.ID})
+	assert.Nil(t, err)
+	assert.Len(t, votes, 2)
+}
+
+func testReopenVotingWhileOtherIsOpenShouldFail(t *testing.T) {
+	board, _, voting := createReopenVotingTestBoard(t)
+
+	_, err := testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusAborted})
+	assert.Nil(t, err)
+	_, err = testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: 5, Status: types.VotingStatusOpen})
+	assert.Nil(t, err)
+
+	_, err = testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusOpen})
+	assert.NotNil(t, err)
+}
+
+func testRaiseVoteLimitOfOpenVoting(t *testing.T) {
+	board, _, voting := createReopenVotingTestBoard(t)
+
+	voteLimit := 4
+	updated, err := testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusOpen, VoteLimit: &voteLimit})
+	assert.Nil(t, err)
+	assert.Equal(t, types.VotingStatusOpen, updated.Status)
+	assert.Equal(t, 4, updated.VoteLimit)
+}
+
+func testLowerVoteLimitShouldFail(t *testing.T) {
+	board, _, voting := createReopenVotingTestBoard(t)
+
+	voteLimit := 1
+	_, err := testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusOpen, VoteLimit: &voteLimit})
+	assert.NotNil(t, err)
+}
+
+func testRaiseVoteLimitOfTimedVotingKeepsClosingTime(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{{Name: "Column", Color: types.ColorBacklogBlue}})
+	assert.Nil(t, err)
+
+	closesAt := time.Now().Add(time.Hour)
+	voting, err := testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: 5, Status: types.VotingStatusOpen, ClosesAt: &closesAt})
+	assert.Nil(t, err)
+
+	voteLimit := 6
+	updated, err := testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusOpen, VoteLimit: &voteLimit})
+	assert.Nil(t, err)
+	assert.Equal(t, 6, updated.VoteLimit)
+	assert.NotNil(t, updated.ClosesAt)
+	assert.WithinDuration(t, *voting.ClosesAt, *updated.ClosesAt, time.Millisecond)
+}
+
+func testCloseExpiredVoting(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{{Name: "Column", Color: types.ColorBacklogBlue}})
+	assert.Nil(t, err)
+
+	closesAt := time.Now().Add(-time.Second)
+	voting, err := testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: 5, Status: types.VotingStatusOpen, ClosesAt: &closesAt})
+	assert.Nil(t, err)
+	assert.NotNil(t, voting.ClosesAt)
+
+	expired, err := testDb.GetExpiredVotings()
+	assert.Nil(t, err)
+	assert.Contains(t, votingIDs(expired), voting.ID)
+
+	closed, err := testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusClosed, Expired: true})
+	assert.Nil(t, err)
+	assert.Equal(t, types.VotingStatusClosed, closed.Status)
+
+	gotBoard, err := testDb.GetBoard(board.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, voting.ID, gotBoard.ShowVoting.UUID)
+
+	_, err = testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusClosed, Expired: true})
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	expired, err = testDb.GetExpiredVotings()
+	assert.Nil(t, err)
+	assert.NotContains(t, votingIDs(expired), voting.ID)
+}
+
+func testCloseVotingBeforeClosingTimeShouldFail(t *testing.T) {
+	user := fixture.MustRow("User.jack").(*User)
+	board, err := testDb.CreateBoard(user.ID, BoardInsert{AccessPolicy: types.AccessPolicyPublic}, []ColumnInsert{{Name: "Column", Color: types.ColorBacklogBlue}})
+	assert.Nil(t, err)
+
+	closesAt := time.Now().Add(time.Hour)
+	voting, err := testDb.CreateVoting(VotingInsert{Board: board.ID, VoteLimit: 5, Status: types.VotingStatusOpen, ClosesAt: &closesAt})
+	assert.Nil(t, err)
+
+	_, err = testDb.UpdateVoting(VotingUpdate{ID: voting.ID, Board: board.ID, Status: types.VotingStatusClosed, Expired: true})
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func votingIDs(votings []Voting) []uuid.UUID {
+	ids := make([]uuid.UUID, len(votings))
+	for index, voting := range votings {
+		ids[index] = voting.ID
+	}
+	return ids
+}
@@ -14,9 +14,13 @@ const (
 	BoardEventInit                  BoardEventType = "INIT"
 	BoardEventBoardUpdated          BoardEventType = "BOARD_UPDATED"
 	BoardEventBoardDeleted          BoardEventType = "BOARD_DELETED"
+	BoardEventBoardArchived         BoardEventType = "BOARD_ARCHIVED"
 	BoardEventColumnsUpdated        BoardEventType = "COLUMNS_UPDATED"
 	BoardEventColumnDeleted         BoardEventType = "COLUMN_DELETED"
 	BoardEventNotesUpdated          BoardEventType = "NOTES_UPDATED"
+	BoardEventNoteCreated           BoardEventType = "NOTE_CREATED"
+	BoardEventNotesMoved            BoardEventType = "NOTE_MOVED"
+	BoardEventNoteTextUpdated       BoardEventType = "NOTE_TEXT_UPDATED"
 	BoardEventNoteDeleted           BoardEventType = "NOTE_DELETED"
 	BoardEventNotesSync             BoardEventType = "NOTES_SYNC"
 	BoardEventReactionAdded         BoardEventType = "REACTION_ADDED"
@@ -31,14 +35,25 @@ const (
 	BoardEventVotingCreated         BoardEventType = "VOTING_CREATED"
 	BoardEventVotingUpdated         BoardEventType = "VOTING_UPDATED"
 	BoardEventBoardTimerUpdated     BoardEventType = "BOARD_TIMER_UPDATED"
+	BoardEventBoardTimerExpired     BoardEventType = "BOARD_TIMER_EXPIRED"
 	BoardEventAssignmentCreated     BoardEventType = "ASSIGNMENT_CREATED"
 	BoardEventAssignmentDeleted     BoardEventType = "ASSIGNMENT_DELETED"
 	BoardEventBoardReactionAdded    BoardEventType = "BOARD_REACTION_ADDED"
+	BoardEventActionItemCreated     BoardEventType = "ACTION_ITEM_CREATED"
+	BoardEventActionItemUpdated     BoardEventType = "ACTION_ITEM_UPDATED"
+	BoardEventActionItemDeleted     BoardEventType = "ACTION_ITEM_DELETED"
+	BoardEventPhasesUpdated         BoardEventType = "PHASES_UPDATED"
+	BoardEventPhaseChanged          BoardEventType = "PHASE_CHANGED"
+	BoardEventCommentsUpdated       BoardEventType = "COMMENTS_UPDATED"
 )
 
 type BoardEvent struct {
 	Type BoardEventType `json:"type"`
 	Data interface{}    `json:"data,omitempty"`
+
+	// Seq is the sequence number of the event on the board. It is assigned by the server instance delivering the
+	// events to the clients and is not part of the published events.
+	Seq uint64 `json:"seq,omitempty"`
 }
 
 func (b *Broker) BroadcastToBoard(boardID uuid.UUID, msg BoardEvent) error {
@@ -55,6 +70,15 @@ func (b *Broker) GetBoardChannel(boardID uuid.UUID) chan *BoardEvent {
 	return c
 }
 
+// CloseBoardChannel ends the subscription of the board, which delivers its events to the channel. The channel does not
+// receive any more events afterwards.
+func (b *Broker) CloseBoardChannel(boardID uuid.UUID, c chan *BoardEvent) {
+	err := b.con.UnsubscribeFromBoardEvents(boardsSubject(boardID), c)
+	if err != nil {
+		logger.Get().Errorw("failed to unsubscribe from BoardChannel", "board", boardID, "err", err)
+	}
+}
+
 func boardsSubject(boardID uuid.UUID) string {
 	return fmt.Sprintf("board.%s", boardID)
 }
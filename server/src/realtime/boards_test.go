@@ -115,3 +115,42 @@ func TestRealtime_GetBoardChannel(t *testing.T) {
 		testRealtimeGetBoardChannelWithBroker(t, rt)
 	})
 }
+
+func testRealtimeCloseBoardChannelWithBroker(t *testing.T, rt *realtime.Broker) {
+	testBoard := uuid.New()
+	closedChannel := rt.GetBoardChannel(testBoard)
+	openChannel := rt.GetBoardChannel(testBoard)
+
+	rt.CloseBoardChannel(testBoard, closedChannel)
+
+	err := rt.BroadcastToBoard(testBoard, realtime.BoardEvent{Type: realtime.BoardEventNotesUpdated})
+	assert.Nil(t, err)
+
+	select {
+	case ev := <-openChannel:
+		assert.Equal(t, realtime.BoardEventNotesUpdated, ev.Type)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "event has not been received")
+	}
+
+	select {
+	case ev := <-closedChannel:
+		// closed channels only deliver nil
+		assert.Nil(t, ev)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestRealtime_CloseBoardChannel(t *testing.T) {
+	t.Run("with nats", func(t *testing.T) {
+		rt, err := realtime.NewNats(SetupNatsContainer(t))
+		assert.Nil(t, err)
+		testRealtimeCloseBoardChannelWithBroker(t, rt)
+	})
+
+	t.Run("with redis", func(t *testing.T) {
+		rt, err := realtime.NewRedis(SetupRedisContainer(t))
+		assert.Nil(t, err)
+		testRealtimeCloseBoardChannelWithBroker(t, rt)
+	})
+}
@@ -13,6 +13,10 @@ type Client interface {
 	// SubscribeToBoardEvents subscribes to the given topic and return a channel
 	//	// with the received BoardEvent
 	SubscribeToBoardEvents(subject string) (chan *BoardEvent, error)
+
+	// UnsubscribeFromBoardEvents ends the subscription of the given topic, which
+	// delivers its events to the given channel
+	UnsubscribeFromBoardEvents(subject string, channel chan *BoardEvent) error
 }
 
 // The Broker enables a user to broadcast and receive events
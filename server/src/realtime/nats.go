@@ -2,12 +2,17 @@ package realtime
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/nats-io/nats.go"
 )
 
 type natsClient struct {
 	con *nats.EncodedConn
+
+	// the subscriptions of board events by their channels
+	subscriptions     map[chan *BoardEvent]*nats.Subscription
+	subscriptionsLock sync.Mutex
 }
 
 // Publish the given event to the given subject
@@ -28,13 +33,33 @@ func (n *natsClient) SubscribeToBoardSessionEvents(subject string) (chan *BoardS
 // SubscribeToBoardEvents subscribes to the given subject
 func (n *natsClient) SubscribeToBoardEvents(subject string) (chan *BoardEvent, error) {
 	receiverChan := make(chan *BoardEvent)
-	_, err := n.con.BindRecvChan(subject, receiverChan)
+	subscription, err := n.con.BindRecvChan(subject, receiverChan)
 	if err != nil {
 		return receiverChan, fmt.Errorf("failed to bind to subject %s: %w", subject, err)
 	}
+
+	n.subscriptionsLock.Lock()
+	defer n.subscriptionsLock.Unlock()
+	n.subscriptions[receiverChan] = subscription
 	return receiverChan, nil
 }
 
+// UnsubscribeFromBoardEvents unsubscribes the given channel from the given subject
+func (n *natsClient) UnsubscribeFromBoardEvents(subject string, channel chan *BoardEvent) error {
+	n.subscriptionsLock.Lock()
+	subscription, exists := n.subscriptions[channel]
+	delete(n.subscriptions, channel)
+	n.subscriptionsLock.Unlock()
+
+	if !exists {
+		return fmt.Errorf("no subscription of subject %s", subject)
+	}
+	if err := subscription.Unsubscribe(); err != nil {
+		return fmt.Errorf("failed to unsubscribe from subject %s: %w", subject, err)
+	}
+	return nil
+}
+
 // NewNats returns a new NATs backed Broker
 func NewNats(url string) (*Broker, error) {
 
@@ -49,6 +74,6 @@ func NewNats(url string) (*Broker, error) {
 	}
 
 	return &Broker{
-		con: &natsClient{con: c},
+		con: &natsClient{con: c, subscriptions: make(map[chan *BoardEvent]*nats.Subscription)},
 	}, nil
 }
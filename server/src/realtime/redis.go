@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -15,6 +16,15 @@ func NewRedis(server RedisServer) (*Broker, error) {
 
 type redisClient struct {
 	con *redis.Client
+
+	// the subscriptions of board events by their channels
+	subscriptions     map[chan *BoardEvent]*redisSubscription
+	subscriptionsLock sync.Mutex
+}
+
+type redisSubscription struct {
+	pubsub *redis.PubSub
+	cancel context.CancelFunc
 }
 
 type RedisServer struct {
@@ -30,7 +40,7 @@ func connectRedis(server RedisServer) *redisClient {
 		Password: server.Password,
 		DB:       0, // use default DB
 	})
-	return &redisClient{con: rdb}
+	return &redisClient{con: rdb, subscriptions: make(map[chan *BoardEvent]*redisSubscription)}
 }
 
 func encodeEvent(event interface{}) (string, error) {
@@ -100,11 +110,13 @@ func (r *redisClient) SubscribeToBoardSessionEvents(subject string) (chan *Board
 }
 
 func (r *redisClient) SubscribeToBoardEvents(subject string) (chan *BoardEvent, error) {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
 	retChannel := make(chan *BoardEvent)
 	pubsub := r.con.Subscribe(ctx, subject)
 	event, err := pubsub.Receive(ctx)
 	if err != nil {
+		cancel()
+		_ = pubsub.Close()
 		return nil, fmt.Errorf("failed to subscribe: %w", err)
 	}
 	switch event.(type) {
@@ -119,18 +131,46 @@ func (r *redisClient) SubscribeToBoardEvents(subject string) (chan *BoardEvent,
 	}
 	c := pubsub.Channel(redis.WithChannelHealthCheckInterval(10 * time.Second))
 	go func() {
+		defer close(retChannel)
 		for {
 			select {
-			case msg := <-c:
+			case msg, ok := <-c:
+				if !ok {
+					return
+				}
 				var event BoardEvent
 				err := decodeEvent(msg.Payload, &event)
 				if err == nil {
-					retChannel <- &event
+					select {
+					case retChannel <- &event:
+					case <-ctx.Done():
+						return
+					}
 				}
 			case <-ctx.Done():
-				close(retChannel)
+				return
 			}
 		}
 	}()
+
+	r.subscriptionsLock.Lock()
+	defer r.subscriptionsLock.Unlock()
+	r.subscriptions[retChannel] = &redisSubscription{pubsub: pubsub, cancel: cancel}
 	return retChannel, nil
 }
+
+func (r *redisClient) UnsubscribeFromBoardEvents(subject string, channel chan *BoardEvent) error {
+	r.subscriptionsLock.Lock()
+	subscription, exists := r.subscriptions[channel]
+	delete(r.subscriptions, channel)
+	r.subscriptionsLock.Unlock()
+
+	if !exists {
+		return fmt.Errorf("no subscription of subject %s", subject)
+	}
+	subscription.cancel()
+	if err := subscription.pubsub.Close(); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package action_items
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+	"scrumlr.io/server/logger"
+	"scrumlr.io/server/realtime"
+	"scrumlr.io/server/services"
+)
+
+type ActionItemService struct {
+	database DB
+	realtime *realtime.Broker
+}
+
+type DB interface {
+	GetNote(id uuid.UUID) (database.Note, error)
+	BoardSessionExists(board, user uuid.UUID) (bool, error)
+	CreateActionItem(insert database.ActionItemInsert) (database.ActionItem, error)
+	UpdateActionItem(update database.ActionItemUpdate) (database.ActionItem, error)
+	DeleteActionItem(id uuid.UUID) error
+	GetActionItem(id uuid.UUID) (database.ActionItem, error)
+	GetActionItems(board uuid.UUID) ([]database.ActionItem, error)
+	GetUserActionItems(owner uuid.UUID, status *types.ActionItemStatus) ([]database.ActionItem, error)
+}
+
+func NewActionItemService(db DB, rt *realtime.Broker) services.ActionItems {
+	s := new(ActionItemService)
+	s.database = db
+	s.realtime = rt
+	return s
+}
+
+func (s *ActionItemService) Create(ctx context.Context, body dto.ActionItemCreateRequest) (*dto.ActionItem, error) {
+	log := logger.FromContext(ctx)
+
+	owner := body.User
+	if body.Owner != nil {
+		owner = *body.Owner
+	}
+	isParticipant, err := s.database.BoardSessionExists(body.Board, owner)
+	if err != nil {
+		log.Errorw("unable to check board session", "board", body.Board, "user", owner, "error", err)
+		return nil, common.InternalServerError
+	}
+	if !isParticipant {
+		return nil, common.BadRequestError(errors.New("owner must be a participant of the board"))
+	}
+
+	insert := database.ActionItemInsert{Owner: owner, Board: body.Board, Text: body.Text, DueDate: body.DueDate}
+	if body.Note != nil {
+		note, err := s.database.GetNote(*body.Note)
+		if err != nil || note.Board != body.Board {
+			return nil, common.BadRequestError(errors.New("unknown note"))
+		}
+		insert.Note = uuid.NullUUID{UUID: note.ID, Valid: true}
+		if insert.Text == "" {
+			insert.Text = note.Text
+		}
+	}
+	if insert.Text == "" {
+		return nil, common.BadRequestError(errors.New("action item text may not be empty"))
+	}
+
+	actionItem, err := s.database.CreateActionItem(insert)
+	if err != nil {
+		log.Errorw("unable to create action item", "board", body.Board, "owner", owner, "error", err)
+		return nil, common.InternalServerError
+	}
+
+	s.broadcast(realtime.BoardEventActionItemCreated, actionItem.Board, new(dto.ActionItem).From(actionItem))
+	return new(dto.ActionItem).From(actionItem), nil
+}
+
+func (s *ActionItemService) Get(ctx context.Context, id uuid.UUID) (*dto.ActionItem, error) {
+	log := logger.FromContext(ctx)
+	actionItem, err := s.database.GetActionItem(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get action item", "actionItem", id, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.ActionItem).From(actionItem), nil
+}
+
+func (s *ActionItemService) Update(ctx context.Context, body dto.ActionItemUpdateRequest) (*dto.ActionItem, error) {
+	log := logger.FromContext(ctx)
+	if body.Text != nil && *body.Text == "" {
+		return nil, common.BadRequestError(errors.New("action item text may not be empty"))
+	}
+
+	if body.Owner != nil {
+		current, err := s.database.GetActionItem(body.ID)
+		if err != nil {
+			log.Errorw("unable to get action item", "actionItem", body.ID, "error", err)
+			return nil, common.InternalServerError
+		}
+		if !current.Board.Valid {
+			return nil, common.BadRequestError(errors.New("owner of an action item of a deleted board cannot be changed"))
+		}
+		isParticipant, err := s.database.BoardSessionExists(current.Board.UUID, *body.Owner)
+		if err != nil {
+			log.Errorw("unable to check board session", "board", current.Board.UUID, "user", *body.Owner, "error", err)
+			return nil, common.InternalServerError
+		}
+		if !isParticipant {
+			return nil, common.BadRequestError(errors.New("owner must be a participant of the board"))
+		}
+	}
+
+	actionItem, err := s.database.UpdateActionItem(database.ActionItemUpdate{
+		ID:      body.ID,
+		Owner:   body.Owner,
+		Text:    body.Text,
+		DueDate: body.DueDate,
+		Status:  body.Status,
+	})
+	if err != nil {
+		log.Errorw("unable to update action item", "actionItem", body.ID, "error", err)
+		return nil, common.InternalServerError
+	}
+
+	s.broadcast(realtime.BoardEventActionItemUpdated, actionItem.Board, new(dto.ActionItem).From(actionItem))
+	return new(dto.ActionItem).From(actionItem), nil
+}
+
+func (s *ActionItemService) Delete(ctx context.Context, id uuid.UUID) error {
+	log := logger.FromContext(ctx)
+	actionItem, err := s.database.GetActionItem(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return common.NotFoundError
+		}
+		log.Errorw("unable to get action item", "actionItem", id, "error", err)
+		return common.InternalServerError
+	}
+
+	if err := s.database.DeleteActionItem(id); err != nil {
+		log.Errorw("unable to delete action item", "actionItem", id, "error", err)
+		return common.InternalServerError
+	}
+
+	s.broadcast(realtime.BoardEventActionItemDeleted, actionItem.Board, id)
+	return nil
+}
+
+func (s *ActionItemService) List(ctx context.Context, board uuid.UUID) ([]*dto.ActionItem, error) {
+	log := logger.FromContext(ctx)
+	actionItems, err := s.database.GetActionItems(board)
+	if err != nil {
+		log.Errorw("unable to get action items", "board", board, "error", err)
+		return nil, common.InternalServerError
+	}
+	return dto.ActionItems(actionItems), nil
+}
+
+func (s *ActionItemService) ListForUser(ctx context.Context, user uuid.UUID, status *types.ActionItemStatus) ([]*dto.ActionItem, error) {
+	log := logger.FromContext(ctx)
+	actionItems, err := s.database.GetUserActionItems(user, status)
+	if err != nil {
+		log.Errorw("unable to get action items", "user", user, "error", err)
+		return nil, common.InternalServerError
+	}
+	return dto.ActionItems(actionItems), nil
+}
+
+func (s *ActionItemService) broadcast(eventType realtime.BoardEventType, board uuid.NullUUID, data interface{}) {
+	if !board.Valid {
+		return
+	}
+
+	err := s.realtime.BroadcastToBoard(board.UUID, realtime.BoardEvent{
+		Type: eventType,
+		Data: data,
+	})
+	if err != nil {
+		logger.Get().Errorw("unable to broadcast action item event", "type", eventType, "err", err)
+	}
+}
@@ -2,6 +2,7 @@ package assignments
 
 import (
 	"context"
+	"database/sql"
 	"github.com/google/uuid"
 	"scrumlr.io/server/common/dto"
 	"scrumlr.io/server/database"
@@ -42,6 +43,9 @@ func (s *AssignmentService) Create(ctx context.Context, body dto.AssignmentCreat
     Name: body.Name,
   })
   if err != nil {
+    if err == sql.ErrNoRows {
+      return nil, common.NotFoundError
+    }
     log.Errorw("unable to create assignment", "board", body.Board, "note", body.Note, "error", err)
     return nil, common.InternalServerError
   }
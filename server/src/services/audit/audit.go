@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/logger"
+	"scrumlr.io/server/services"
+)
+
+type AuditService struct {
+	database DB
+}
+
+type DB interface {
+	AttachObserver(observer database.Observer)
+	CreateAuditEntry(insert database.AuditEntryInsert) (database.AuditEntry, error)
+	GetAuditEntries(f filter.AuditFilter) ([]database.AuditEntry, error)
+}
+
+func NewAuditService(db DB) services.Audit {
+	s := new(AuditService)
+	s.database = db
+	s.database.AttachObserver((database.AuditObserver)(s))
+	return s
+}
+
+func (s *AuditService) List(ctx context.Context, f filter.AuditFilter) ([]*dto.AuditEntry, error) {
+	log := logger.FromContext(ctx)
+	entries, err := s.database.GetAuditEntries(f)
+	if err != nil {
+		log.Errorw("unable to get audit log", "board", f.Board, "error", err)
+		return nil, common.InternalServerError
+	}
+	return dto.AuditEntries(entries), nil
+}
+
+// AuditedChange appends the change to the audit log of the board.
+func (s *AuditService) AuditedChange(entry database.AuditEntryInsert) {
+	_, err := s.database.CreateAuditEntry(entry)
+	if err != nil {
+		logger.Get().Errorw("unable to append audit entry", "board", entry.Board, "entity", entry.Entity, "action", entry.Action, "err", err)
+	}
+}
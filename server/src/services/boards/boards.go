@@ -2,6 +2,7 @@ package boards
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 
 	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/common/filter"
 	"scrumlr.io/server/realtime"
 	"scrumlr.io/server/services"
 
@@ -18,6 +20,12 @@ import (
 	"scrumlr.io/server/logger"
 )
 
+// maxTimerMinutes is the longest duration of a board timer.
+const maxTimerMinutes = 24 * 60
+
+// maxTimerPresets is the maximum number of timer presets of a board.
+const maxTimerPresets = 10
+
 type BoardService struct {
 	database *database.Database
 	realtime *realtime.Broker
@@ -62,9 +70,35 @@ func (s *BoardService) Create(ctx context.Context, body dto.CreateBoardRequest)
 
 	// map request on column objects to insert into database
 	columns := make([]database.ColumnInsert, 0, len(body.Columns))
-	for index, value := range body.Columns {
-		var currentIndex = index
-		columns = append(columns, database.ColumnInsert{Name: value.Name, Color: value.Color, Visible: value.Visible, Index: &currentIndex})
+	if body.Template != nil {
+		// use the settings and columns of the template instead
+		template, templateColumns, err := s.database.GetBoardTemplate(*body.Template)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("unknown template")
+			}
+			log.Errorw("unable to get template", "template", *body.Template, "error", err)
+			return nil, err
+		}
+		if template.Creator != body.Owner {
+			return nil, errors.New("unknown template")
+		}
+
+		board.ShowAuthors = &template.ShowAuthors
+		board.ShowNotesOfOtherUsers = &template.ShowNotesOfOtherUsers
+		board.ShowNoteReactions = &template.ShowNoteReactions
+		board.AllowStacking = &template.AllowStacking
+
+		for index, value := range templateColumns {
+			var currentIndex = index
+			var visible = value.Visible
+			columns = append(columns, database.ColumnInsert{Name: value.Name, Color: value.Color, Visible: &visible, Index: &currentIndex})
+		}
+	} else {
+		for index, value := range body.Columns {
+			var currentIndex = index
+			columns = append(columns, database.ColumnInsert{Name: value.Name, Color: value.Color, Visible: value.Visible, Index: &currentIndex})
+		}
 	}
 
 	// create the board
@@ -76,10 +110,262 @@ func (s *BoardService) Create(ctx context.Context, body dto.CreateBoardRequest)
 	return new(dto.Board).From(b), nil
 }
 
-func (s *BoardService) FullBoard(ctx context.Context, boardID uuid.UUID) (*dto.Board, []*dto.BoardSessionRequest, []*dto.BoardSession, []*dto.Column, []*dto.Note, []*dto.Reaction, []*dto.Voting, []*dto.Vote, []*dto.Assignment, error) {
-	board, requests, sessions, columns, notes, reactions, votings, votes, assignments, err := s.database.Get(boardID)
+func (s *BoardService) Import(ctx context.Context, body dto.ImportBoardRequest) (*dto.Board, error) {
+	log := logger.FromContext(ctx)
+	if body.Board == nil {
+		return nil, errors.New("board export must contain a board")
+	}
+
+	board := database.BoardInsert{
+		Name:                  body.Board.Name,
+		AccessPolicy:          body.Board.AccessPolicy,
+		ShowAuthors:           &body.Board.ShowAuthors,
+		ShowNotesOfOtherUsers: &body.Board.ShowNotesOfOtherUsers,
+		ShowNoteReactions:     &body.Board.ShowNoteReactions,
+		AllowStacking:         &body.Board.AllowStacking,
+		TimerPresets:          body.Board.TimerPresets,
+	}
+	if board.AccessPolicy == types.AccessPolicyByPassphrase {
+		// the passphrase of the original board is not part of the export
+		if body.Passphrase == nil || len(*body.Passphrase) == 0 {
+			board.AccessPolicy = types.AccessPolicyByInvite
+		} else {
+			encodedPassphrase, salt, _ := common.Sha512WithSalt(*body.Passphrase)
+			board.Passphrase = encodedPassphrase
+			board.Salt = salt
+		}
+	}
+
+	sessions := make([]database.BoardSessionInsert, 0, len(body.Participants))
+	for _, participant := range body.Participants {
+		sessions = append(sessions, database.BoardSessionInsert{User: participant.User.ID, Role: participant.Role})
+	}
+
+	// the imported entities get new ids, which are mapped from the ids of the export
+	columnIDs := map[uuid.UUID]uuid.UUID{}
+	columns := make([]database.ColumnImport, 0, len(body.Columns))
+	for index, column := range body.Columns {
+		columnIDs[column.ID] = uuid.New()
+		columns = append(columns, database.ColumnImport{
+			ID:      columnIDs[column.ID],
+			Name:    column.Name,
+			Color:   column.Color,
+			Visible: column.Visible,
+			Index:   index,
+		})
+	}
+
+	noteIDs := map[uuid.UUID]uuid.UUID{}
+	for _, note := range body.Notes {
+		if _, ok := columnIDs[note.Position.Column]; ok {
+			noteIDs[note.ID] = uuid.New()
+		}
+	}
+	notes := make([]database.NoteImport, 0, len(noteIDs))
+	for _, note := range body.Notes {
+		id, ok := noteIDs[note.ID]
+		if !ok {
+			continue
+		}
+
+		var stack uuid.NullUUID
+		if parent, ok := noteIDs[note.Position.Stack.UUID]; note.Position.Stack.Valid && ok {
+			stack = uuid.NullUUID{UUID: parent, Valid: true}
+		}
+
+		// the content of an export is not trusted and sanitized the same way as the content of a new note
+		content, err := common.NoteContent{Type: note.ContentType, Text: note.Text, Links: note.Links, Checklist: note.Checklist}.Sanitize()
+		if err != nil {
+			return nil, common.BadRequestError(err)
+		}
+		notes = append(notes, database.NoteImport{
+			ID:          id,
+			Author:      note.Author,
+			Column:      columnIDs[note.Position.Column],
+			Text:        content.Text,
+			ContentType: content.Type,
+			Links:       content.Links,
+			Checklist:   content.Checklist,
+			Stack:       stack,
+			Rank:        note.Position.Rank,
+		})
+	}
+
+	// only the results of closed votings are part of the export
+	votings := []database.VotingImport{}
+	votes := []database.Vote{}
+	for _, voting := range body.Votings {
+		if voting.Status != types.VotingStatusClosed {
+			continue
+		}
+
+		// the ranks of a ranked voting are not part of the export, so its points are imported as a weighted voting
+		mode := voting.Mode
+		if mode == types.VotingModeRanked {
+			mode = types.VotingModeWeighted
+		}
+
+		votingID := uuid.New()
+		votings = append(votings, database.VotingImport{
+			ID:                 votingID,
+			VoteLimit:          voting.VoteLimit,
+			AllowMultipleVotes: voting.AllowMultipleVotes,
+			ShowVotesOfOthers:  voting.ShowVotesOfOthers,
+			Status:             voting.Status,
+			Mode:               mode,
+			Anonymous:          voting.Anonymous,
+		})
+
+		if voting.VotingResults == nil {
+			continue
+		}
+		for note, result := range voting.VotingResults.Votes {
+			noteID, ok := noteIDs[note]
+			if !ok {
+				continue
+			}
+
+			// votes without a known voter are attributed to the importing user
+			remaining := result.Total
+			if result.Users != nil {
+				for _, user := range *result.Users {
+					for i := 0; i < user.Total && remaining > 0; i++ {
+						votes = append(votes, database.Vote{Voting: votingID, User: user.ID, Note: noteID})
+						remaining--
+					}
+				}
+			}
+			for ; remaining > 0; remaining-- {
+				votes = append(votes, database.Vote{Voting: votingID, User: body.Owner, Note: noteID})
+			}
+		}
+	}
+
+	b, err := s.database.ImportBoard(body.Owner, database.BoardImport{
+		Board:    board,
+		Sessions: sessions,
+		Columns:  columns,
+		Notes:    notes,
+		Votings:  votings,
+		Votes:    votes,
+	})
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+		log.Errorw("unable to import board", "owner", body.Owner, "error", err)
+		return nil, err
+	}
+	return new(dto.Board).From(b), nil
+}
+
+func (s *BoardService) Duplicate(ctx context.Context, body dto.DuplicateBoardRequest) (*dto.Board, error) {
+	log := logger.FromContext(ctx)
+	if body.Assignments && !body.Notes {
+		return nil, common.BadRequestError(errors.New("assignments can only be copied along with the notes"))
+	}
+
+	original, err := s.database.GetBoard(body.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get board", "board", body.ID, "error", err)
+		return nil, common.InternalServerError
+	}
+
+	board := database.BoardInsert{
+		Name:                  original.Name,
+		AccessPolicy:          original.AccessPolicy,
+		Passphrase:            original.Passphrase,
+		Salt:                  original.Salt,
+		ShowAuthors:           &original.ShowAuthors,
+		ShowNotesOfOtherUsers: &original.ShowNotesOfOtherUsers,
+		ShowNoteReactions:     &original.ShowNoteReactions,
+		AllowStacking:         &original.AllowStacking,
+		TimerPresets:          original.TimerPresets,
+	}
+	if body.Name != nil {
+		board.Name = body.Name
+	}
+
+	originalColumns, err := s.database.GetColumns(body.ID)
+	if err != nil {
+		log.Errorw("unable to get columns", "board", body.ID, "error", err)
+		return nil, common.InternalServerError
+	}
+
+	// the copied entities get new ids, which are mapped from the ids of the original board
+	columnIDs := map[uuid.UUID]uuid.UUID{}
+	columns := make([]database.ColumnImport, 0, len(originalColumns))
+	for _, column := range originalColumns {
+		columnIDs[column.ID] = uuid.New()
+		columns = append(columns, database.ColumnImport{
+			ID:      columnIDs[column.ID],
+			Name:    column.Name,
+			Color:   column.Color,
+			Visible: column.Visible,
+			Index:   column.Index,
+		})
+	}
+
+	notes := []database.NoteImport{}
+	assignments := []database.AssignmentInsert{}
+	if body.Notes {
+		originalNotes, err := s.database.GetNotes(body.ID)
+		if err != nil {
+			log.Errorw("unable to get notes", "board", body.ID, "error", err)
+			return nil, common.InternalServerError
+		}
+
+		noteIDs := map[uuid.UUID]uuid.UUID{}
+		for _, note := range originalNotes {
+			noteIDs[note.ID] = uuid.New()
+		}
+		for _, note := range originalNotes {
+			var stack uuid.NullUUID
+			if note.Stack.Valid {
+				stack = uuid.NullUUID{UUID: noteIDs[note.Stack.UUID], Valid: true}
+			}
+			notes = append(notes, database.NoteImport{
+				ID:          noteIDs[note.ID],
+				Author:      note.Author,
+				Column:      columnIDs[note.Column],
+				Text:        note.Text,
+				ContentType: note.ContentType,
+				Links:       note.Links,
+				Checklist:   note.Checklist,
+				Stack:       stack,
+				Rank:        note.Rank,
+			})
+		}
+
+		if body.Assignments {
+			originalAssignments, err := s.database.GetAssignments(body.ID)
+			if err != nil {
+				log.Errorw("unable to get assignments", "board", body.ID, "error", err)
+				return nil, common.InternalServerError
+			}
+			for _, assignment := range originalAssignments {
+				assignments = append(assignments, database.AssignmentInsert{Note: noteIDs[assignment.Note], Name: assignment.Name})
+			}
+		}
+	}
+
+	b, err := s.database.ImportBoard(body.Owner, database.BoardImport{
+		Board:       board,
+		Columns:     columns,
+		Notes:       notes,
+		Assignments: assignments,
+	})
+	if err != nil {
+		log.Errorw("unable to duplicate board", "board", body.ID, "owner", body.Owner, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Board).From(b), nil
+}
+
+func (s *BoardService) FullBoard(ctx context.Context, boardID uuid.UUID) (*dto.Board, []*dto.BoardSessionRequest, []*dto.BoardSession, []*dto.Column, []*dto.Note, []*dto.Reaction, []*dto.Voting, []*dto.Vote, []*dto.Assignment, []*dto.Comment, error) {
+	board, requests, sessions, columns, notes, reactions, votings, votes, assignments, comments, err := s.database.Get(boardID)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	personalVotes := []*dto.Vote{}
@@ -89,7 +375,22 @@ func (s *BoardService) FullBoard(ctx context.Context, boardID uuid.UUID) (*dto.B
 		}
 	}
 
-	return new(dto.Board).From(board), dto.BoardSessionRequests(requests), dto.BoardSessions(sessions), dto.Columns(columns), dto.Notes(notes), dto.Reactions(reactions), dto.Votings(votings, votes), personalVotes, dto.Assignments(assignments), err
+	return new(dto.Board).From(board), dto.BoardSessionRequests(requests), dto.BoardSessions(sessions), dto.Columns(columns), dto.Notes(notes), dto.Reactions(reactions), dto.Votings(votings, votes), personalVotes, dto.Assignments(assignments), dto.Comments(comments), err
+}
+
+func (s *BoardService) ListForUser(ctx context.Context, f filter.UserBoardFilter) (*dto.UserBoards, error) {
+	log := logger.FromContext(ctx)
+	boards, total, err := s.database.GetUserBoards(f)
+	if err != nil {
+		log.Errorw("unable to get boards of user", "user", f.User, "error", err)
+		return nil, common.InternalServerError
+	}
+
+	page := dto.UserBoards{Boards: make([]*dto.UserBoard, len(boards)), Total: total, Limit: f.Limit, Offset: f.Offset}
+	for index, board := range boards {
+		page.Boards[index] = new(dto.UserBoard).From(board)
+	}
+	return &page, nil
 }
 
 func (s *BoardService) Delete(_ context.Context, id uuid.UUID) error {
@@ -98,6 +399,20 @@ func (s *BoardService) Delete(_ context.Context, id uuid.UUID) error {
 
 func (s *BoardService) Update(ctx context.Context, body dto.BoardUpdateRequest) (*dto.Board, error) {
 	log := logger.FromContext(ctx)
+
+	current, err := s.database.GetBoard(body.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get board", "board", body.ID, "error", err)
+		return nil, common.InternalServerError
+	}
+	// archived boards are read-only, so the only update allowed is to un-archive them
+	if current.Archived && !isUnarchiveRequest(body) {
+		return nil, common.ConflictError(errors.New("board is archived"))
+	}
+
 	update := database.BoardUpdate{
 		ID:                    body.ID,
 		Name:                  body.Name,
@@ -108,6 +423,7 @@ func (s *BoardService) Update(ctx context.Context, body dto.BoardUpdateRequest)
 		TimerStart:            body.TimerStart,
 		TimerEnd:              body.TimerEnd,
 		SharedNote:            body.SharedNote,
+		Archived:              body.Archived,
 	}
 
 	if body.AccessPolicy != nil {
@@ -128,14 +444,69 @@ func (s *BoardService) Update(ctx context.Context, body dto.BoardUpdateRequest)
 		}
 	}
 
-	board, err := s.database.UpdateBoard(update)
+	if body.TimerPresets != nil {
+		if len(*body.TimerPresets) > maxTimerPresets {
+			return nil, common.BadRequestError(fmt.Errorf("a board may not have more than %d timer presets", maxTimerPresets))
+		}
+		for _, minutes := range *body.TimerPresets {
+			if minutes < 1 || minutes > maxTimerMinutes {
+				return nil, common.BadRequestError(fmt.Errorf("timer presets must be between 1 and %d minutes", maxTimerMinutes))
+			}
+		}
+		update.TimerPresets = *body.TimerPresets
+	}
+
+	var phase *database.BoardPhase
+	if body.Phase != nil {
+		p, err := s.database.GetBoardPhase(body.ID, *body.Phase)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, common.BadRequestError(errors.New("phase is not part of the agenda of the board"))
+			}
+			log.Errorw("unable to get phase", "board", body.ID, "phase", *body.Phase, "error", err)
+			return nil, common.InternalServerError
+		}
+		phase = &p
+		s.applyPhase(&update, p)
+	}
+
+	board, err := s.database.WithActor(ctx).UpdateBoard(update)
 	if err != nil {
 		return nil, err
 	}
+
+	if phase != nil {
+		s.ChangedPhase(board, *phase)
+	}
+	if body.Archived != nil && *body.Archived {
+		s.ArchivedBoard(board)
+	}
 	return new(dto.Board).From(board), err
 }
 
-func (s *BoardService) SetTimer(_ context.Context, id uuid.UUID, minutes uint8) (*dto.Board, error) {
+// isUnarchiveRequest reports whether the request un-archives the board without changing any other setting.
+func isUnarchiveRequest(body dto.BoardUpdateRequest) bool {
+	return body.Archived != nil && !*body.Archived &&
+		body.Name == nil &&
+		body.AccessPolicy == nil &&
+		body.Passphrase == nil &&
+		body.ShowAuthors == nil &&
+		body.ShowNotesOfOtherUsers == nil &&
+		body.ShowNoteReactions == nil &&
+		body.AllowStacking == nil &&
+		body.TimerStart == nil &&
+		body.TimerEnd == nil &&
+		body.TimerPresets == nil &&
+		!body.SharedNote.Valid &&
+		!body.ShowVoting.Valid &&
+		body.Phase == nil
+}
+
+func (s *BoardService) SetTimer(_ context.Context, id uuid.UUID, minutes uint16) (*dto.Board, error) {
+	if minutes > maxTimerMinutes {
+		return nil, common.BadRequestError(fmt.Errorf("timer may not exceed %d minutes", maxTimerMinutes))
+	}
+
 	timerStart := time.Now().Local()
 	timerEnd := timerStart.Add(time.Minute * time.Duration(minutes))
 	update := database.BoardTimerUpdate{
@@ -163,6 +534,32 @@ func (s *BoardService) DeleteTimer(_ context.Context, id uuid.UUID) (*dto.Board,
 	return new(dto.Board).From(board), err
 }
 
+func (s *BoardService) PauseTimer(ctx context.Context, id uuid.UUID) (*dto.Board, error) {
+	log := logger.FromContext(ctx)
+	board, err := s.database.PauseBoardTimer(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ConflictError(errors.New("board has no running timer"))
+		}
+		log.Errorw("unable to pause timer", "board", id, "err", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Board).From(board), err
+}
+
+func (s *BoardService) ResumeTimer(ctx context.Context, id uuid.UUID) (*dto.Board, error) {
+	log := logger.FromContext(ctx)
+	board, err := s.database.ResumeBoardTimer(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ConflictError(errors.New("board has no paused timer"))
+		}
+		log.Errorw("unable to resume timer", "board", id, "err", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Board).From(board), err
+}
+
 func (s *BoardService) UpdatedBoardTimer(board database.Board) {
 	err := s.realtime.BroadcastToBoard(board.ID, realtime.BoardEvent{
 		Type: realtime.BoardEventBoardTimerUpdated,
@@ -218,6 +615,16 @@ func (s *BoardService) SyncBoardSettingChange(boardID uuid.UUID) (string, error)
 	return "", err
 }
 
+func (s *BoardService) ArchivedBoard(board database.Board) {
+	err := s.realtime.BroadcastToBoard(board.ID, realtime.BoardEvent{
+		Type: realtime.BoardEventBoardArchived,
+		Data: new(dto.Board).From(board),
+	})
+	if err != nil {
+		logger.Get().Errorw("unable to broadcast archived board", "err", err)
+	}
+}
+
 func (s *BoardService) DeletedBoard(board uuid.UUID) {
 	err := s.realtime.BroadcastToBoard(board, realtime.BoardEvent{
 		Type: realtime.BoardEventBoardDeleted,
@@ -19,8 +19,22 @@ func (s *BoardService) CreateColumn(_ context.Context, body dto.ColumnRequest) (
 	return new(dto.Column).From(column), err
 }
 
-func (s *BoardService) DeleteColumn(_ context.Context, board, column, user uuid.UUID) error {
-	return s.database.DeleteColumn(board, column, user)
+func (s *BoardService) DeleteColumn(ctx context.Context, board, column, user uuid.UUID) error {
+	return s.database.WithActor(ctx).DeleteColumn(board, column, user)
+}
+
+// RestoreColumn restores a deleted column together with the notes, which were deleted along with it.
+func (s *BoardService) RestoreColumn(ctx context.Context, board, column uuid.UUID) (*dto.Column, error) {
+	log := logger.FromContext(ctx)
+	restored, err := s.database.RestoreColumn(board, column)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to restore column", "board", board, "column", column, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Column).From(restored), err
 }
 
 func (s *BoardService) UpdateColumn(_ context.Context, body dto.ColumnUpdateRequest) (*dto.Column, error) {
@@ -0,0 +1,172 @@
+package boards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+	"scrumlr.io/server/logger"
+	"scrumlr.io/server/realtime"
+)
+
+func (s *BoardService) ListPhases(ctx context.Context, boardID uuid.UUID) ([]*dto.Phase, error) {
+	log := logger.FromContext(ctx)
+	phases, err := s.database.GetBoardPhases(boardID)
+	if err != nil {
+		log.Errorw("unable to get phases", "board", boardID, "error", err)
+		return nil, common.InternalServerError
+	}
+	return dto.Phases(phases), err
+}
+
+func (s *BoardService) SetPhases(ctx context.Context, body dto.PhasesRequest) ([]*dto.Phase, error) {
+	log := logger.FromContext(ctx)
+	columns, err := s.database.GetColumns(body.Board)
+	if err != nil {
+		log.Errorw("unable to get columns", "board", body.Board, "error", err)
+		return nil, common.InternalServerError
+	}
+	boardColumns := map[uuid.UUID]bool{}
+	for _, column := range columns {
+		boardColumns[column.ID] = true
+	}
+
+	phases := make([]database.BoardPhaseInsert, 0, len(body.Phases))
+	for _, phase := range body.Phases {
+		if phase.Name == "" {
+			return nil, common.BadRequestError(errors.New("phase name may not be empty"))
+		}
+
+		insert := database.BoardPhaseInsert{
+			Name:                  phase.Name,
+			ShowAuthors:           phase.ShowAuthors,
+			ShowNotesOfOtherUsers: phase.ShowNotesOfOtherUsers,
+			ShowNoteReactions:     phase.ShowNoteReactions,
+			AllowStacking:         phase.AllowStacking,
+			TimerMinutes:          phase.TimerMinutes,
+		}
+		if phase.VisibleColumns != nil {
+			for _, column := range *phase.VisibleColumns {
+				if !boardColumns[column] {
+					return nil, common.BadRequestError(fmt.Errorf("column '%s' is not part of the board", column))
+				}
+			}
+			insert.VisibleColumns = append([]uuid.UUID{}, *phase.VisibleColumns...)
+		}
+		if phase.Voting != nil {
+			if phase.Voting.VoteLimit < 0 || phase.Voting.VoteLimit > 99 {
+				return nil, common.BadRequestError(errors.New("vote limit must be between 0 and 99"))
+			}
+			insert.VoteLimit = &phase.Voting.VoteLimit
+			insert.AllowMultipleVotes = phase.Voting.AllowMultipleVotes
+			insert.ShowVotesOfOthers = phase.Voting.ShowVotesOfOthers
+		}
+		if phase.TimerMinutes != nil && (*phase.TimerMinutes < 1 || *phase.TimerMinutes > maxTimerMinutes) {
+			return nil, common.BadRequestError(fmt.Errorf("timer must be between 1 and %d minutes", maxTimerMinutes))
+		}
+		phases = append(phases, insert)
+	}
+
+	result, err := s.database.SetBoardPhases(body.Board, phases)
+	if err != nil {
+		log.Errorw("unable to set phases", "board", body.Board, "error", err)
+		return nil, common.InternalServerError
+	}
+
+	s.UpdatedPhases(body.Board, result)
+	return dto.Phases(result), err
+}
+
+// applyPhase adds the settings of the phase to the board update.
+func (s *BoardService) applyPhase(update *database.BoardUpdate, phase database.BoardPhase) {
+	update.Phase = &phase.ID
+	if phase.ShowAuthors != nil {
+		update.ShowAuthors = phase.ShowAuthors
+	}
+	if phase.ShowNotesOfOtherUsers != nil {
+		update.ShowNotesOfOtherUsers = phase.ShowNotesOfOtherUsers
+	}
+	if phase.ShowNoteReactions != nil {
+		update.ShowNoteReactions = phase.ShowNoteReactions
+	}
+	if phase.AllowStacking != nil {
+		update.AllowStacking = phase.AllowStacking
+	}
+	update.VisibleColumns = phase.VisibleColumns
+	if phase.VoteLimit != nil {
+		update.OpenVoting = &database.VotingInsert{
+			Board:              phase.Board,
+			VoteLimit:          *phase.VoteLimit,
+			AllowMultipleVotes: phase.AllowMultipleVotes,
+			ShowVotesOfOthers:  phase.ShowVotesOfOthers,
+			Status:             types.VotingStatusOpen,
+		}
+	}
+	if phase.TimerMinutes != nil {
+		timerStart := time.Now().Local()
+		timerEnd := timerStart.Add(time.Minute * time.Duration(*phase.TimerMinutes))
+		update.TimerStart = &timerStart
+		update.TimerEnd = &timerEnd
+		update.RestartTimer = true
+	}
+}
+
+func (s *BoardService) UpdatedPhases(board uuid.UUID, phases []database.BoardPhase) {
+	err := s.realtime.BroadcastToBoard(board, realtime.BoardEvent{
+		Type: realtime.BoardEventPhasesUpdated,
+		Data: dto.Phases(phases),
+	})
+	if err != nil {
+		logger.Get().Errorw("unable to broadcast updated phases", "err", err)
+	}
+}
+
+// ChangedPhase announces the changes of the columns, votings and timer applied by the phase, followed by the phase
+// change itself.
+func (s *BoardService) ChangedPhase(board database.Board, phase database.BoardPhase) {
+	if phase.VisibleColumns != nil {
+		columns, err := s.database.GetColumns(board.ID)
+		if err != nil {
+			logger.Get().Errorw("unable to get columns, following a phase change", "err", err)
+		} else {
+			s.UpdatedColumns(board.ID, columns)
+		}
+	}
+
+	if phase.VoteLimit != nil {
+		votings, _, err := s.database.GetVotings(board.ID)
+		if err != nil {
+			logger.Get().Errorw("unable to get votings, following a phase change", "err", err)
+		}
+		for _, voting := range votings {
+			if voting.Status != types.VotingStatusOpen {
+				continue
+			}
+			err = s.realtime.BroadcastToBoard(board.ID, realtime.BoardEvent{
+				Type: realtime.BoardEventVotingCreated,
+				Data: new(dto.Voting).From(voting, nil),
+			})
+			if err != nil {
+				logger.Get().Errorw("unable to broadcast created voting", "err", err)
+			}
+		}
+	}
+
+	if phase.TimerMinutes != nil {
+		s.UpdatedBoardTimer(board)
+	}
+
+	err := s.realtime.BroadcastToBoard(board.ID, realtime.BoardEvent{
+		Type: realtime.BoardEventPhaseChanged,
+		Data: dto.PhaseChanged{Board: new(dto.Board).From(board), Phase: new(dto.Phase).From(phase)},
+	})
+	if err != nil {
+		logger.Get().Errorw("unable to broadcast changed phase", "err", err)
+	}
+}
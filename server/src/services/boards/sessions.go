@@ -51,23 +51,15 @@ func (s *BoardSessionService) List(_ context.Context, boardID uuid.UUID, filter
 	return dto.BoardSessions(sessions), err
 }
 
+// Connect counts the connection of the session to this instance and marks the session as connected.
 func (s *BoardSessionService) Connect(_ context.Context, boardID, userID uuid.UUID) error {
-	var connected = true
-	_, err := s.database.UpdateBoardSession(database.BoardSessionUpdate{
-		Board:     boardID,
-		User:      userID,
-		Connected: &connected,
-	})
+	_, err := s.database.ConnectBoardSession(boardID, userID)
 	return err
 }
 
+// Disconnect marks the session as disconnected as soon as the last connection of the session has been closed.
 func (s *BoardSessionService) Disconnect(_ context.Context, boardID, userID uuid.UUID) error {
-	var connected = false
-	_, err := s.database.UpdateBoardSession(database.BoardSessionUpdate{
-		Board:     boardID,
-		User:      userID,
-		Connected: &connected,
-	})
+	_, err := s.database.DisconnectBoardSession(boardID, userID)
 	return err
 }
 
@@ -84,7 +76,7 @@ func (s *BoardSessionService) Get(ctx context.Context, boardID, userID uuid.UUID
 	return new(dto.BoardSession).From(session), err
 }
 
-func (s *BoardSessionService) Update(_ context.Context, body dto.BoardSessionUpdateRequest) (*dto.BoardSession, error) {
+func (s *BoardSessionService) Update(ctx context.Context, body dto.BoardSessionUpdateRequest) (*dto.BoardSession, error) {
 	sessionOfCaller, _ := s.database.GetBoardSession(body.Board, body.Caller)
 	if sessionOfCaller.Role == types.SessionRoleParticipant && body.User != body.Caller {
 		return nil, common.ForbiddenError(errors.New("not allowed to change other users session"))
@@ -101,7 +93,7 @@ func (s *BoardSessionService) Update(_ context.Context, body dto.BoardSessionUpd
 		}
 	}
 
-	session, err := s.database.UpdateBoardSession(database.BoardSessionUpdate{
+	session, err := s.database.WithActor(ctx).UpdateBoardSession(database.BoardSessionUpdate{
 		Board:             body.Board,
 		User:              body.User,
 		Ready:             body.Ready,
@@ -185,8 +177,8 @@ func (s *BoardSessionService) CreateSessionRequest(_ context.Context, boardID, u
 	return new(dto.BoardSessionRequest).From(request), err
 }
 
-func (s *BoardSessionService) UpdateSessionRequest(_ context.Context, body dto.BoardSessionRequestUpdate) (*dto.BoardSessionRequest, error) {
-	request, err := s.database.UpdateBoardSessionRequest(database.BoardSessionRequestUpdate{Board: body.Board, User: body.User, Status: body.Status})
+func (s *BoardSessionService) UpdateSessionRequest(ctx context.Context, body dto.BoardSessionRequestUpdate) (*dto.BoardSessionRequest, error) {
+	request, err := s.database.WithActor(ctx).UpdateBoardSessionRequest(database.BoardSessionRequestUpdate{Board: body.Board, User: body.User, Status: body.Status})
 	if err != nil {
 		return nil, err
 	}
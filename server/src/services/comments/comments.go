@@ -0,0 +1,158 @@
+package comments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+	"scrumlr.io/server/logger"
+	"scrumlr.io/server/realtime"
+	"scrumlr.io/server/services"
+)
+
+type CommentService struct {
+	database DB
+	realtime *realtime.Broker
+}
+
+type Observer interface {
+	AttachObserver(observer database.Observer)
+}
+
+type DB interface {
+	Observer
+	GetNote(id uuid.UUID) (database.Note, error)
+	GetBoardSession(board, user uuid.UUID) (database.BoardSession, error)
+	CreateComment(insert database.CommentInsert) (database.Comment, error)
+	UpdateComment(update database.CommentUpdate) (database.Comment, error)
+	DeleteComment(board, id uuid.UUID) error
+	GetComment(board, id uuid.UUID) (database.Comment, error)
+	GetComments(board uuid.UUID, notes ...uuid.UUID) ([]database.Comment, error)
+}
+
+func NewCommentService(db DB, rt *realtime.Broker) services.Comments {
+	s := new(CommentService)
+	s.database = db
+	s.realtime = rt
+	s.database.AttachObserver((database.CommentsObserver)(s))
+	return s
+}
+
+func (s *CommentService) Create(ctx context.Context, body dto.CommentCreateRequest) (*dto.Comment, error) {
+	log := logger.FromContext(ctx)
+	if strings.TrimSpace(body.Text) == "" {
+		return nil, common.BadRequestError(errors.New("the text of a comment must not be empty"))
+	}
+
+	note, err := s.database.GetNote(body.Note)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get note", "note", body.Note, "error", err)
+		return nil, common.InternalServerError
+	}
+	if note.Board != body.Board {
+		return nil, common.NotFoundError
+	}
+
+	comment, err := s.database.CreateComment(database.CommentInsert{Note: body.Note, Board: body.Board, Author: body.User, Text: body.Text})
+	if err != nil {
+		log.Errorw("unable to create comment", "note", body.Note, "user", body.User, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Comment).From(comment), err
+}
+
+// Update changes the text of a comment, which is only allowed for its author.
+func (s *CommentService) Update(ctx context.Context, body dto.CommentUpdateRequest) (*dto.Comment, error) {
+	log := logger.FromContext(ctx)
+	if strings.TrimSpace(body.Text) == "" {
+		return nil, common.BadRequestError(errors.New("the text of a comment must not be empty"))
+	}
+
+	comment, err := s.get(ctx, body.Board, body.Note, body.ID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Author != body.User {
+		return nil, common.ForbiddenError(errors.New("only the author may edit a comment"))
+	}
+
+	updated, err := s.database.UpdateComment(database.CommentUpdate{ID: body.ID, Board: body.Board, Text: body.Text})
+	if err != nil {
+		log.Errorw("unable to update comment", "comment", body.ID, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Comment).From(updated), err
+}
+
+// Delete removes a comment, which is only allowed for its author and moderators.
+func (s *CommentService) Delete(ctx context.Context, board, note, user, id uuid.UUID) error {
+	log := logger.FromContext(ctx)
+	comment, err := s.get(ctx, board, note, id)
+	if err != nil {
+		return err
+	}
+
+	if comment.Author != user {
+		session, err := s.database.GetBoardSession(board, user)
+		if err != nil {
+			log.Errorw("unable to get board session", "board", board, "user", user, "error", err)
+			return common.InternalServerError
+		}
+		if session.Role != types.SessionRoleModerator && session.Role != types.SessionRoleOwner {
+			return common.ForbiddenError(errors.New("only the author or moderators may delete a comment"))
+		}
+	}
+
+	if err := s.database.DeleteComment(board, id); err != nil {
+		log.Errorw("unable to delete comment", "comment", id, "error", err)
+		return common.InternalServerError
+	}
+	return nil
+}
+
+func (s *CommentService) List(ctx context.Context, board, note uuid.UUID) ([]*dto.Comment, error) {
+	comments, err := s.database.GetComments(board, note)
+	if err != nil {
+		logger.FromContext(ctx).Errorw("unable to get comments", "note", note, "error", err)
+		return nil, common.InternalServerError
+	}
+	return dto.Comments(comments), err
+}
+
+func (s *CommentService) get(ctx context.Context, board, note, id uuid.UUID) (database.Comment, error) {
+	comment, err := s.database.GetComment(board, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return database.Comment{}, common.NotFoundError
+		}
+		logger.FromContext(ctx).Errorw("unable to get comment", "comment", id, "error", err)
+		return database.Comment{}, common.InternalServerError
+	}
+	if comment.Note != note {
+		return database.Comment{}, common.NotFoundError
+	}
+	return comment, nil
+}
+
+func (s *CommentService) UpdatedComments(board uuid.UUID, comments []database.Comment) {
+	eventComments := make([]dto.Comment, len(comments))
+	for index, comment := range comments {
+		eventComments[index] = *new(dto.Comment).From(comment)
+	}
+	err := s.realtime.BroadcastToBoard(board, realtime.BoardEvent{
+		Type: realtime.BoardEventCommentsUpdated,
+		Data: eventComments,
+	})
+	if err != nil {
+		logger.Get().Errorw("unable to broadcast updated comments", "err", err)
+	}
+}
@@ -0,0 +1,155 @@
+package comments
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+)
+
+type CommentServiceTestSuite struct {
+	suite.Suite
+}
+
+type DBMock struct {
+	DB
+	mock.Mock
+}
+
+func (m *DBMock) GetNote(id uuid.UUID) (database.Note, error) {
+	args := m.Called(id)
+	return args.Get(0).(database.Note), args.Error(1)
+}
+
+func (m *DBMock) GetBoardSession(board, user uuid.UUID) (database.BoardSession, error) {
+	args := m.Called(board, user)
+	return args.Get(0).(database.BoardSession), args.Error(1)
+}
+
+func (m *DBMock) CreateComment(insert database.CommentInsert) (database.Comment, error) {
+	args := m.Called(insert)
+	return args.Get(0).(database.Comment), args.Error(1)
+}
+
+func (m *DBMock) UpdateComment(update database.CommentUpdate) (database.Comment, error) {
+	args := m.Called(update)
+	return args.Get(0).(database.Comment), args.Error(1)
+}
+
+func (m *DBMock) DeleteComment(board, id uuid.UUID) error {
+	args := m.Called(board, id)
+	return args.Error(0)
+}
+
+func (m *DBMock) GetComment(board, id uuid.UUID) (database.Comment, error) {
+	args := m.Called(board, id)
+	return args.Get(0).(database.Comment), args.Error(1)
+}
+
+func TestCommentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentServiceTestSuite))
+}
+
+func (suite *CommentServiceTestSuite) TestCreate() {
+	s := new(CommentService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+	txt := "a remark"
+
+	mock.On("GetNote", noteID).Return(database.Note{ID: noteID, Board: boardID}, nil)
+	mock.On("CreateComment", database.CommentInsert{Note: noteID, Board: boardID, Author: authorID, Text: txt}).
+		Return(database.Comment{Note: noteID, Board: boardID, Author: authorID, Text: txt}, nil)
+
+	comment, err := s.Create(context.Background(), dto.CommentCreateRequest{Text: txt, Board: boardID, Note: noteID, User: authorID})
+
+	suite.Nil(err)
+	suite.Equal(txt, comment.Text)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *CommentServiceTestSuite) TestCreateOnNoteOfOtherBoardShouldFail() {
+	s := new(CommentService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+
+	mock.On("GetNote", noteID).Return(database.Note{ID: noteID, Board: uuid.New()}, nil)
+
+	_, err := s.Create(context.Background(), dto.CommentCreateRequest{Text: "a remark", Board: boardID, Note: noteID, User: authorID})
+
+	suite.Equal(common.NotFoundError, err)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *CommentServiceTestSuite) TestUpdateByOtherUserShouldFail() {
+	s := new(CommentService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+	commentID, _ := uuid.NewRandom()
+
+	mock.On("GetComment", boardID, commentID).Return(database.Comment{ID: commentID, Note: noteID, Board: boardID, Author: authorID}, nil)
+
+	_, err := s.Update(context.Background(), dto.CommentUpdateRequest{Text: "changed", ID: commentID, Board: boardID, Note: noteID, User: uuid.New()})
+
+	suite.Equal(http.StatusForbidden, err.(*common.APIError).StatusCode)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *CommentServiceTestSuite) TestDeleteByModerator() {
+	s := new(CommentService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	moderatorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+	commentID, _ := uuid.NewRandom()
+
+	mock.On("GetComment", boardID, commentID).Return(database.Comment{ID: commentID, Note: noteID, Board: boardID, Author: authorID}, nil)
+	mock.On("GetBoardSession", boardID, moderatorID).Return(database.BoardSession{Role: types.SessionRoleModerator}, nil)
+	mock.On("DeleteComment", boardID, commentID).Return(nil)
+
+	err := s.Delete(context.Background(), boardID, noteID, moderatorID, commentID)
+
+	suite.Nil(err)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *CommentServiceTestSuite) TestDeleteByParticipantShouldFail() {
+	s := new(CommentService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	participantID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+	commentID, _ := uuid.NewRandom()
+
+	mock.On("GetComment", boardID, commentID).Return(database.Comment{ID: commentID, Note: noteID, Board: boardID, Author: authorID}, nil)
+	mock.On("GetBoardSession", boardID, participantID).Return(database.BoardSession{Role: types.SessionRoleParticipant}, nil)
+
+	err := s.Delete(context.Background(), boardID, noteID, participantID, commentID)
+
+	suite.Equal(http.StatusForbidden, err.(*common.APIError).StatusCode)
+	mock.AssertExpectations(suite.T())
+}
@@ -0,0 +1,68 @@
+package connections
+
+import (
+	"context"
+	"time"
+
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/logger"
+)
+
+// ConnectionService keeps the connections of the board sessions to this instance of the server alive and expires the
+// connections of instances which stopped without closing them, so that their sessions aren't shown as connected forever.
+type ConnectionService struct {
+	database DB
+	interval time.Duration
+}
+
+type DB interface {
+	RefreshBoardSessionConnections() error
+	ExpireBoardSessionConnections(timeout time.Duration) ([]database.BoardSession, error)
+}
+
+// expiryIntervals is the number of heartbeats an instance may miss before its connections expire.
+const expiryIntervals = 3
+
+func NewConnectionService(db DB, interval time.Duration) *ConnectionService {
+	s := new(ConnectionService)
+	s.database = db
+	s.interval = interval
+	return s
+}
+
+// Run renews the heartbeat of this instance and expires the connections of stopped instances periodically until the
+// context is done. All instances of the server have to use the same interval.
+func (s *ConnectionService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Heartbeat(ctx); err != nil {
+			logger.Get().Errorw("unable to renew the connections of board sessions", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Heartbeat renews the connections to this instance and expires the connections which haven't been renewed by their
+// instance within the last intervals. The changed connection states are announced by the board session observer.
+func (s *ConnectionService) Heartbeat(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	if err := s.database.RefreshBoardSessionConnections(); err != nil {
+		return err
+	}
+
+	sessions, err := s.database.ExpireBoardSessionConnections(expiryIntervals * s.interval)
+	if err != nil {
+		return err
+	}
+	if len(sessions) > 0 {
+		log.Infow("expired connections of stopped instances", "sessions", len(sessions))
+	}
+	return nil
+}
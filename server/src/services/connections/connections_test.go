@@ -0,0 +1,62 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"scrumlr.io/server/database"
+)
+
+type ConnectionServiceTestSuite struct {
+	suite.Suite
+}
+
+type DBMock struct {
+	DB
+	mock.Mock
+}
+
+func (m *DBMock) RefreshBoardSessionConnections() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *DBMock) ExpireBoardSessionConnections(timeout time.Duration) ([]database.BoardSession, error) {
+	args := m.Called(timeout)
+	return args.Get(0).([]database.BoardSession), args.Error(1)
+}
+
+func TestConnectionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ConnectionServiceTestSuite))
+}
+
+func (suite *ConnectionServiceTestSuite) TestHeartbeat() {
+	db := new(DBMock)
+	s := NewConnectionService(db, time.Minute)
+
+	db.On("RefreshBoardSessionConnections").Return(nil)
+	db.On("ExpireBoardSessionConnections", 3*time.Minute).Return([]database.BoardSession{{Board: uuid.New(), User: uuid.New()}}, nil)
+
+	err := s.Heartbeat(context.Background())
+
+	suite.Nil(err)
+	db.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionServiceTestSuite) TestHeartbeatWithoutRefreshDoesNotExpire() {
+	db := new(DBMock)
+	s := NewConnectionService(db, time.Minute)
+
+	// the connections of this instance would expire along with the others, if its own heartbeat failed
+	db.On("RefreshBoardSessionConnections").Return(errors.New("connection refused"))
+
+	err := s.Heartbeat(context.Background())
+
+	suite.NotNil(err)
+	db.AssertNotCalled(suite.T(), "ExpireBoardSessionConnections", mock.Anything)
+}
@@ -3,6 +3,7 @@ package notes
 import (
 	"context"
 	"database/sql"
+	"errors"
 
 	"scrumlr.io/server/common"
 	"scrumlr.io/server/services"
@@ -13,6 +14,7 @@ import (
 	"scrumlr.io/server/realtime"
 
 	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
 	"scrumlr.io/server/logger"
 )
 
@@ -32,6 +34,11 @@ type DB interface {
 	GetNotes(board uuid.UUID, columns ...uuid.UUID) ([]database.Note, error)
 	UpdateNote(caller uuid.UUID, update database.NoteUpdate) (database.Note, error)
 	DeleteNote(caller uuid.UUID, board uuid.UUID, id uuid.UUID, deleteStack bool) error
+	GetNoteRevisions(board, note uuid.UUID) ([]database.NoteRevision, error)
+	GetNoteRevision(board, note, id uuid.UUID) (database.NoteRevision, error)
+	GetDeletedNote(board, id uuid.UUID) (database.Note, error)
+	RestoreNote(board, id uuid.UUID) (database.Note, error)
+	GetBoardSession(board, user uuid.UUID) (database.BoardSession, error)
 }
 
 func NewNoteService(db DB, rt *realtime.Broker) services.Notes {
@@ -44,7 +51,20 @@ func NewNoteService(db DB, rt *realtime.Broker) services.Notes {
 
 func (s *NoteService) Create(ctx context.Context, body dto.NoteCreateRequest) (*dto.Note, error) {
 	log := logger.FromContext(ctx)
-	note, err := s.database.CreateNote(database.NoteInsert{Author: body.User, Board: body.Board, Column: body.Column, Text: body.Text})
+	content, err := common.NoteContent{Type: body.ContentType, Text: body.Text, Links: body.Links, Checklist: body.Checklist}.Sanitize()
+	if err != nil {
+		return nil, common.BadRequestError(err)
+	}
+
+	note, err := s.database.CreateNote(database.NoteInsert{
+		Author:      body.User,
+		Board:       body.Board,
+		Column:      body.Column,
+		Text:        content.Text,
+		ContentType: content.Type,
+		Links:       content.Links,
+		Checklist:   content.Checklist,
+	})
 	if err != nil {
 		log.Errorw("unable to create note", "board", body.Board, "user", body.User, "error", err)
 		return nil, common.InternalServerError
@@ -81,13 +101,22 @@ func (s *NoteService) Update(ctx context.Context, body dto.NoteUpdateRequest) (*
 			Stack:  body.Position.Stack,
 		}
 	}
+	text, contentUpdate, err := s.updatedContent(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
 	note, err := s.database.UpdateNote(ctx.Value("User").(uuid.UUID), database.NoteUpdate{
 		ID:       body.ID,
 		Board:    body.Board,
-		Text:     body.Text,
+		Text:     text,
+		Content:  contentUpdate,
 		Position: positionUpdate,
 	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
 		log.Errorw("unable to update note", "error", err, "note", body.ID)
 		return nil, common.InternalServerError
 	}
@@ -95,10 +124,157 @@ func (s *NoteService) Update(ctx context.Context, body dto.NoteUpdateRequest) (*
 	return new(dto.Note).From(note), err
 }
 
+// updatedContent merges the changes of the text and the rich content with the current content of the note. The
+// content is sanitized again as a whole, since a change of the text or the content type affects the links and the
+// Markdown of the note.
+func (s *NoteService) updatedContent(ctx context.Context, body dto.NoteUpdateRequest) (*string, *database.NoteUpdateContent, error) {
+	if body.Text == nil && body.ContentType == nil && body.Links == nil && body.Checklist == nil {
+		return nil, nil, nil
+	}
+
+	current, err := s.database.GetNote(body.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, common.NotFoundError
+		}
+		logger.FromContext(ctx).Errorw("unable to get note", "note", body.ID, "error", err)
+		return nil, nil, common.InternalServerError
+	}
+
+	content := common.NoteContent{Type: current.ContentType, Text: current.Text, Links: current.Links, Checklist: current.Checklist}
+	if body.Text != nil {
+		content.Text = *body.Text
+	}
+	if body.ContentType != nil {
+		content.Type = *body.ContentType
+		if content.Type != types.NoteContentTypeChecklist {
+			content.Checklist = nil
+		}
+	}
+	if body.Links != nil {
+		content.Links = body.Links
+	}
+	if body.Checklist != nil {
+		content.Checklist = *body.Checklist
+	}
+
+	sanitized, err := content.Sanitize()
+	if err != nil {
+		return nil, nil, common.BadRequestError(err)
+	}
+
+	var text *string
+	if body.Text != nil || sanitized.Text != current.Text {
+		text = &sanitized.Text
+	}
+	return text, &database.NoteUpdateContent{ContentType: sanitized.Type, Links: sanitized.Links, Checklist: sanitized.Checklist}, nil
+}
+
 func (s *NoteService) Delete(ctx context.Context, body dto.NoteDeleteRequest, id uuid.UUID) error {
 	return s.database.DeleteNote(ctx.Value("User").(uuid.UUID), ctx.Value("Board").(uuid.UUID), id, body.DeleteStack)
 }
 
+func (s *NoteService) History(ctx context.Context, board, id uuid.UUID) ([]*dto.NoteRevision, error) {
+	log := logger.FromContext(ctx)
+	revisions, err := s.database.GetNoteRevisions(board, id)
+	if err != nil {
+		log.Errorw("unable to get note revisions", "note", id, "error", err)
+		return nil, common.InternalServerError
+	}
+	return dto.NoteRevisions(revisions), err
+}
+
+// Restore sets the text of the note to the text of a previous revision or, if no revision is specified, restores the
+// deleted note. Both is only allowed for the author of the note and moderators.
+func (s *NoteService) Restore(ctx context.Context, body dto.NoteRestoreRequest) (*dto.Note, error) {
+	if body.Revision == nil {
+		return s.restoreDeleted(ctx, body)
+	}
+
+	log := logger.FromContext(ctx)
+	note, err := s.database.GetNote(body.Note)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get note", "note", body.Note, "error", err)
+		return nil, common.InternalServerError
+	}
+	if note.Board != body.Board {
+		return nil, common.NotFoundError
+	}
+	if err := s.checkRestorePermission(ctx, note, body.User); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.database.GetNoteRevision(body.Board, body.Note, *body.Revision)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get note revision", "note", body.Note, "revision", body.Revision, "error", err)
+		return nil, common.InternalServerError
+	}
+
+	content, err := common.NoteContent{Type: revision.ContentType, Text: revision.Text, Links: revision.Links, Checklist: revision.Checklist}.Sanitize()
+	if err != nil {
+		return nil, common.BadRequestError(err)
+	}
+
+	updated, err := s.database.UpdateNote(body.User, database.NoteUpdate{
+		ID:      body.Note,
+		Board:   body.Board,
+		Text:    &content.Text,
+		Content: &database.NoteUpdateContent{ContentType: content.Type, Links: content.Links, Checklist: content.Checklist},
+	})
+	if err != nil {
+		log.Errorw("unable to restore note", "note", body.Note, "revision", body.Revision, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Note).From(updated), err
+}
+
+func (s *NoteService) restoreDeleted(ctx context.Context, body dto.NoteRestoreRequest) (*dto.Note, error) {
+	log := logger.FromContext(ctx)
+	note, err := s.database.GetDeletedNote(body.Board, body.Note)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get deleted note", "note", body.Note, "error", err)
+		return nil, common.InternalServerError
+	}
+	if err := s.checkRestorePermission(ctx, note, body.User); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.database.RestoreNote(body.Board, body.Note)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ConflictError(errors.New("the column of the note has been deleted"))
+		}
+		log.Errorw("unable to restore deleted note", "note", body.Note, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Note).From(restored), err
+}
+
+func (s *NoteService) checkRestorePermission(ctx context.Context, note database.Note, user uuid.UUID) error {
+	if note.Author == user {
+		return nil
+	}
+
+	session, err := s.database.GetBoardSession(note.Board, user)
+	if err != nil {
+		logger.FromContext(ctx).Errorw("unable to get board session", "board", note.Board, "user", user, "error", err)
+		return common.InternalServerError
+	}
+	if session.Role != types.SessionRoleModerator && session.Role != types.SessionRoleOwner {
+		return common.ForbiddenError(errors.New("only the author or moderators may restore a note"))
+	}
+	return nil
+}
+
 func (s *NoteService) UpdatedNotes(board uuid.UUID, notes []database.Note) {
 	eventNotes := make([]dto.Note, len(notes))
 	for index, note := range notes {
@@ -112,6 +288,41 @@ func (s *NoteService) UpdatedNotes(board uuid.UUID, notes []database.Note) {
 		logger.Get().Errorw("unable to broadcast updated notes", "err", err)
 	}
 }
+
+func (s *NoteService) CreatedNote(board uuid.UUID, note database.Note) {
+	err := s.realtime.BroadcastToBoard(board, realtime.BoardEvent{
+		Type: realtime.BoardEventNoteCreated,
+		Data: new(dto.Note).From(note),
+	})
+	if err != nil {
+		logger.Get().Errorw("unable to broadcast created note", "err", err)
+	}
+}
+
+func (s *NoteService) MovedNotes(board uuid.UUID, notes []database.Note) {
+	eventNotes := make([]dto.Note, len(notes))
+	for index, note := range notes {
+		eventNotes[index] = *new(dto.Note).From(note)
+	}
+	err := s.realtime.BroadcastToBoard(board, realtime.BoardEvent{
+		Type: realtime.BoardEventNotesMoved,
+		Data: eventNotes,
+	})
+	if err != nil {
+		logger.Get().Errorw("unable to broadcast moved notes", "err", err)
+	}
+}
+
+func (s *NoteService) UpdatedNoteText(board uuid.UUID, note database.Note) {
+	err := s.realtime.BroadcastToBoard(board, realtime.BoardEvent{
+		Type: realtime.BoardEventNoteTextUpdated,
+		Data: new(dto.Note).From(note),
+	})
+	if err != nil {
+		logger.Get().Errorw("unable to broadcast updated note", "err", err)
+	}
+}
+
 func (s *NoteService) DeletedNote(user, board, note uuid.UUID, votes []database.Vote, deleteStack bool) {
 	noteData := map[string]interface{}{
 		"note":        note,
@@ -2,6 +2,9 @@ package notes
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
+	"scrumlr.io/server/common"
 	"scrumlr.io/server/common/dto"
 	"testing"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
 )
 
 type NoteServiceTestSuite struct {
@@ -25,6 +29,36 @@ func (m *DBMock) CreateNote(insert database.NoteInsert) (database.Note, error) {
 	return args.Get(0).(database.Note), args.Error(1)
 }
 
+func (m *DBMock) GetNote(id uuid.UUID) (database.Note, error) {
+	args := m.Called(id)
+	return args.Get(0).(database.Note), args.Error(1)
+}
+
+func (m *DBMock) UpdateNote(caller uuid.UUID, update database.NoteUpdate) (database.Note, error) {
+	args := m.Called(caller, update)
+	return args.Get(0).(database.Note), args.Error(1)
+}
+
+func (m *DBMock) GetNoteRevision(board, note, id uuid.UUID) (database.NoteRevision, error) {
+	args := m.Called(board, note, id)
+	return args.Get(0).(database.NoteRevision), args.Error(1)
+}
+
+func (m *DBMock) GetDeletedNote(board, id uuid.UUID) (database.Note, error) {
+	args := m.Called(board, id)
+	return args.Get(0).(database.Note), args.Error(1)
+}
+
+func (m *DBMock) RestoreNote(board, id uuid.UUID) (database.Note, error) {
+	args := m.Called(board, id)
+	return args.Get(0).(database.Note), args.Error(1)
+}
+
+func (m *DBMock) GetBoardSession(board, user uuid.UUID) (database.BoardSession, error) {
+	args := m.Called(board, user)
+	return args.Get(0).(database.BoardSession), args.Error(1)
+}
+
 func TestNoteServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(NoteServiceTestSuite))
 }
@@ -40,10 +74,11 @@ func (suite *NoteServiceTestSuite) TestCreate() {
 	txt := "aaaaaaaaaaaaaaaaaaaa"
 
 	mock.On("CreateNote", database.NoteInsert{
-		Author: authorID,
-		Board:  boardID,
-		Column: colID,
-		Text:   txt,
+		Author:      authorID,
+		Board:       boardID,
+		Column:      colID,
+		Text:        txt,
+		ContentType: types.NoteContentTypePlain,
 	}).Return(database.Note{}, nil)
 
 	s.Create(context.Background(), dto.NoteCreateRequest{
@@ -56,3 +91,188 @@ func (suite *NoteServiceTestSuite) TestCreate() {
 	mock.AssertExpectations(suite.T())
 
 }
+
+func (suite *NoteServiceTestSuite) TestCreateMarkdown() {
+	s := new(NoteService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	colID, _ := uuid.NewRandom()
+
+	mock.On("CreateNote", database.NoteInsert{
+		Author:      authorID,
+		Board:       boardID,
+		Column:      colID,
+		Text:        "**Read** x[this](#) https://scrumlr.io",
+		ContentType: types.NoteContentTypeMarkdown,
+		Links:       []types.LinkPreview{{URL: "https://scrumlr.io", Title: "Scrumlr"}},
+	}).Return(database.Note{}, nil)
+
+	_, err := s.Create(context.Background(), dto.NoteCreateRequest{
+		User:        authorID,
+		Board:       boardID,
+		Column:      colID,
+		Text:        "**Read** <script>x</script>[this](javascript:void) https://scrumlr.io",
+		ContentType: types.NoteContentTypeMarkdown,
+		Links:       []types.LinkPreview{{URL: "https://scrumlr.io", Title: "Scrumlr"}, {URL: "https://example.com"}},
+	})
+
+	suite.Nil(err)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *NoteServiceTestSuite) TestCreateWithInvalidChecklistShouldFail() {
+	s := new(NoteService)
+	mock := new(DBMock)
+	s.database = mock
+
+	_, err := s.Create(context.Background(), dto.NoteCreateRequest{
+		Text:        "Actions",
+		ContentType: types.NoteContentTypeChecklist,
+		Checklist:   []types.ChecklistItem{{Text: ""}},
+	})
+
+	suite.Equal(http.StatusBadRequest, err.(*common.APIError).StatusCode)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *NoteServiceTestSuite) TestUpdateChecklistItem() {
+	s := new(NoteService)
+	mock := new(DBMock)
+	s.database = mock
+
+	userID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+	checklist := []types.ChecklistItem{{Text: "Book room", Done: true}, {Text: "Invite team"}}
+
+	mock.On("GetNote", noteID).Return(database.Note{
+		ID:          noteID,
+		Board:       boardID,
+		Text:        "Offsite",
+		ContentType: types.NoteContentTypeChecklist,
+		Checklist:   []types.ChecklistItem{{Text: "Book room"}, {Text: "Invite team"}},
+	}, nil)
+	mock.On("UpdateNote", userID, database.NoteUpdate{
+		ID:      noteID,
+		Board:   boardID,
+		Content: &database.NoteUpdateContent{ContentType: types.NoteContentTypeChecklist, Checklist: checklist},
+	}).Return(database.Note{ID: noteID, ContentType: types.NoteContentTypeChecklist, Checklist: checklist}, nil)
+
+	note, err := s.Update(context.WithValue(context.Background(), "User", userID), dto.NoteUpdateRequest{
+		ID:        noteID,
+		Board:     boardID,
+		Checklist: &checklist,
+	})
+
+	suite.Nil(err)
+	suite.Equal(checklist, note.Checklist)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *NoteServiceTestSuite) TestRestoreByModerator() {
+	s := new(NoteService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	moderatorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+	revisionID, _ := uuid.NewRandom()
+	txt := "previous text"
+
+	mock.On("GetNote", noteID).Return(database.Note{ID: noteID, Board: boardID, Author: authorID}, nil)
+	mock.On("GetBoardSession", boardID, moderatorID).Return(database.BoardSession{Role: types.SessionRoleModerator}, nil)
+	mock.On("GetNoteRevision", boardID, noteID, revisionID).Return(database.NoteRevision{ID: revisionID, Note: noteID, Text: txt}, nil)
+	mock.On("UpdateNote", moderatorID, database.NoteUpdate{ID: noteID, Board: boardID, Text: &txt, Content: &database.NoteUpdateContent{ContentType: types.NoteContentTypePlain}}).Return(database.Note{ID: noteID, Text: txt}, nil)
+
+	note, err := s.Restore(context.Background(), dto.NoteRestoreRequest{Revision: &revisionID, Note: noteID, Board: boardID, User: moderatorID})
+
+	suite.Nil(err)
+	suite.Equal(txt, note.Text)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *NoteServiceTestSuite) TestRestoreContentOfRevision() {
+	s := new(NoteService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+	revisionID, _ := uuid.NewRandom()
+	txt := "Read https://scrumlr.io"
+	links := []types.LinkPreview{{URL: "https://scrumlr.io", Title: "Scrumlr"}}
+
+	mock.On("GetNote", noteID).Return(database.Note{ID: noteID, Board: boardID, Author: authorID, Text: "Read it", ContentType: types.NoteContentTypeChecklist, Checklist: []types.ChecklistItem{{Text: "Read it", Done: true}}}, nil)
+	mock.On("GetNoteRevision", boardID, noteID, revisionID).Return(database.NoteRevision{ID: revisionID, Note: noteID, Text: txt, ContentType: types.NoteContentTypeMarkdown, Links: links}, nil)
+	mock.On("UpdateNote", authorID, database.NoteUpdate{ID: noteID, Board: boardID, Text: &txt, Content: &database.NoteUpdateContent{ContentType: types.NoteContentTypeMarkdown, Links: links}}).Return(database.Note{ID: noteID, Text: txt, ContentType: types.NoteContentTypeMarkdown, Links: links}, nil)
+
+	note, err := s.Restore(context.Background(), dto.NoteRestoreRequest{Revision: &revisionID, Note: noteID, Board: boardID, User: authorID})
+
+	suite.Nil(err)
+	suite.Equal(types.NoteContentTypeMarkdown, note.ContentType)
+	suite.Equal(links, note.Links)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *NoteServiceTestSuite) TestRestoreByParticipantShouldFail() {
+	s := new(NoteService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	participantID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+	revisionID, _ := uuid.NewRandom()
+
+	mock.On("GetNote", noteID).Return(database.Note{ID: noteID, Board: boardID, Author: authorID}, nil)
+	mock.On("GetBoardSession", boardID, participantID).Return(database.BoardSession{Role: types.SessionRoleParticipant}, nil)
+
+	_, err := s.Restore(context.Background(), dto.NoteRestoreRequest{Revision: &revisionID, Note: noteID, Board: boardID, User: participantID})
+
+	suite.Equal(http.StatusForbidden, err.(*common.APIError).StatusCode)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *NoteServiceTestSuite) TestRestoreDeletedByAuthor() {
+	s := new(NoteService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+
+	mock.On("GetDeletedNote", boardID, noteID).Return(database.Note{ID: noteID, Board: boardID, Author: authorID}, nil)
+	mock.On("RestoreNote", boardID, noteID).Return(database.Note{ID: noteID, Board: boardID, Author: authorID}, nil)
+
+	note, err := s.Restore(context.Background(), dto.NoteRestoreRequest{Note: noteID, Board: boardID, User: authorID})
+
+	suite.Nil(err)
+	suite.Equal(noteID, note.ID)
+	mock.AssertExpectations(suite.T())
+}
+
+func (suite *NoteServiceTestSuite) TestRestoreDeletedWithinDeletedColumnShouldFail() {
+	s := new(NoteService)
+	mock := new(DBMock)
+	s.database = mock
+
+	authorID, _ := uuid.NewRandom()
+	boardID, _ := uuid.NewRandom()
+	noteID, _ := uuid.NewRandom()
+
+	mock.On("GetDeletedNote", boardID, noteID).Return(database.Note{ID: noteID, Board: boardID, Author: authorID}, nil)
+	mock.On("RestoreNote", boardID, noteID).Return(database.Note{}, sql.ErrNoRows)
+
+	_, err := s.Restore(context.Background(), dto.NoteRestoreRequest{Note: noteID, Board: boardID, User: authorID})
+
+	suite.Equal(http.StatusConflict, err.(*common.APIError).StatusCode)
+	mock.AssertExpectations(suite.T())
+}
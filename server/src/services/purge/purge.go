@@ -0,0 +1,67 @@
+package purge
+
+import (
+	"context"
+	"time"
+
+	"scrumlr.io/server/logger"
+)
+
+// PurgeService removes deleted notes and columns for good, once the window in which they may be restored has passed.
+type PurgeService struct {
+	database DB
+	window   time.Duration
+	interval time.Duration
+}
+
+type DB interface {
+	PurgeDeletedNotes(before time.Time) (int64, error)
+	PurgeDeletedColumns(before time.Time) (int64, error)
+}
+
+func NewPurgeService(db DB, window, interval time.Duration) *PurgeService {
+	s := new(PurgeService)
+	s.database = db
+	s.window = window
+	s.interval = interval
+	return s
+}
+
+// Run purges the deleted notes and columns periodically until the context is done.
+func (s *PurgeService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Purge(ctx, time.Now()); err != nil {
+			logger.Get().Errorw("unable to purge deleted notes and columns", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes all notes and columns, which have been deleted before the restore window ending at the specified time.
+// Columns are purged first, since their notes are removed together with them.
+func (s *PurgeService) Purge(ctx context.Context, now time.Time) error {
+	log := logger.FromContext(ctx)
+	before := now.Add(-s.window)
+
+	columns, err := s.database.PurgeDeletedColumns(before)
+	if err != nil {
+		return err
+	}
+	notes, err := s.database.PurgeDeletedNotes(before)
+	if err != nil {
+		return err
+	}
+
+	if columns > 0 || notes > 0 {
+		log.Infow("purged deleted notes and columns", "columns", columns, "notes", notes)
+	}
+	return nil
+}
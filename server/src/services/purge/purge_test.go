@@ -0,0 +1,61 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PurgeServiceTestSuite struct {
+	suite.Suite
+}
+
+type DBMock struct {
+	DB
+	mock.Mock
+}
+
+func (m *DBMock) PurgeDeletedNotes(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *DBMock) PurgeDeletedColumns(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestPurgeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PurgeServiceTestSuite))
+}
+
+func (suite *PurgeServiceTestSuite) TestPurge() {
+	db := new(DBMock)
+	s := NewPurgeService(db, 24*time.Hour, time.Hour)
+
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	before := time.Date(2022, 5, 31, 12, 0, 0, 0, time.UTC)
+	db.On("PurgeDeletedColumns", before).Return(int64(1), nil)
+	db.On("PurgeDeletedNotes", before).Return(int64(3), nil)
+
+	err := s.Purge(context.Background(), now)
+
+	suite.Nil(err)
+	db.AssertExpectations(suite.T())
+}
+
+func (suite *PurgeServiceTestSuite) TestPurgeStopsOnColumnError() {
+	db := new(DBMock)
+	s := NewPurgeService(db, 24*time.Hour, time.Hour)
+
+	db.On("PurgeDeletedColumns", mock.Anything).Return(int64(0), errors.New("failed"))
+
+	err := s.Purge(context.Background(), time.Now())
+
+	suite.NotNil(err)
+	db.AssertNotCalled(suite.T(), "PurgeDeletedNotes", mock.Anything)
+}
@@ -2,6 +2,7 @@ package reactions
 
 import (
 	"context"
+	"database/sql"
 	"github.com/google/uuid"
 	"scrumlr.io/server/common"
 	"scrumlr.io/server/common/dto"
@@ -60,6 +61,9 @@ func (s *ReactionService) Create(ctx context.Context, board uuid.UUID, body dto.
 		})
 
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
 		log.Errorw("unable to create reaction", "note", body.Note, "user", body.User, "type", body.ReactionType, "error", err)
 		return nil, common.InternalServerError
 	}
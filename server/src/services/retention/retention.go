@@ -0,0 +1,143 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/logger"
+	"scrumlr.io/server/services"
+)
+
+// Action is the operation applied to boards exceeding the retention period.
+type Action string
+
+const (
+	// ActionDelete deletes inactive boards. The statistics of deleted boards are still recorded.
+	ActionDelete Action = "delete"
+
+	// ActionArchive archives inactive boards, which makes them read-only.
+	ActionArchive Action = "archive"
+)
+
+// ParseAction returns the action for the specified name.
+func ParseAction(name string) (Action, error) {
+	switch Action(name) {
+	case ActionDelete, ActionArchive:
+		return Action(name), nil
+	}
+	return "", errors.New("invalid retention action")
+}
+
+// Policy is the configuration of the retention of boards.
+type Policy struct {
+	// MaxInactivity is the duration after which a board without note or session activity is considered stale.
+	MaxInactivity time.Duration
+
+	// Action is the operation applied to stale boards.
+	Action Action
+
+	// Interval is the duration between two runs of the retention job.
+	Interval time.Duration
+
+	// DryRun reports stale boards without applying the action.
+	DryRun bool
+}
+
+// Report lists the boards affected by a single run of the retention job.
+type Report struct {
+	Action Action
+	DryRun bool
+	Boards []database.InactiveBoard
+}
+
+type RetentionService struct {
+	database DB
+	boards   services.Boards
+	policy   Policy
+}
+
+type DB interface {
+	GetInactiveBoards(since time.Time, includeArchived bool) ([]database.InactiveBoard, error)
+}
+
+func NewRetentionService(db DB, boards services.Boards, policy Policy) *RetentionService {
+	s := new(RetentionService)
+	s.database = db
+	s.boards = boards
+	s.policy = policy
+	return s
+}
+
+// Run applies the retention policy periodically until the context is done.
+func (s *RetentionService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.policy.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Apply(ctx); err != nil {
+			logger.Get().Errorw("unable to apply retention policy", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Apply deletes or archives all boards exceeding the retention period, unless this is a dry run.
+func (s *RetentionService) Apply(ctx context.Context) (*Report, error) {
+	log := logger.FromContext(ctx)
+
+	// archived boards may still be deleted later on, but don't need to be archived again
+	boards, err := s.database.GetInactiveBoards(time.Now().Add(-s.policy.MaxInactivity), s.policy.Action == ActionDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	report := Report{Action: s.policy.Action, DryRun: s.policy.DryRun, Boards: boards}
+	for _, board := range boards {
+		if s.policy.DryRun {
+			log.Infow("board exceeds retention period", "board", board.ID, "lastActivity", board.LastActivity, "action", s.policy.Action)
+			continue
+		}
+
+		switch s.policy.Action {
+		case ActionDelete:
+			err = s.boards.Delete(ctx, board.ID)
+		case ActionArchive:
+			err = s.archive(ctx, board.ID)
+		}
+		if err != nil {
+			log.Errorw("unable to apply retention policy on board", "board", board.ID, "action", s.policy.Action, "err", err)
+			continue
+		}
+		log.Infow("applied retention policy on board", "board", board.ID, "lastActivity", board.LastActivity, "action", s.policy.Action)
+	}
+
+	log.Infow("retention policy applied", "boards", len(boards), "action", s.policy.Action, "dryRun", s.policy.DryRun)
+	return &report, nil
+}
+
+func (s *RetentionService) archive(ctx context.Context, id uuid.UUID) error {
+	board, err := s.boards.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// keep the timer and shared note, since they are always part of a board update
+	archived := true
+	_, err = s.boards.Update(ctx, dto.BoardUpdateRequest{
+		ID:         id,
+		TimerStart: board.TimerStart,
+		TimerEnd:   board.TimerEnd,
+		SharedNote: board.SharedNote,
+		Archived:   &archived,
+	})
+	return err
+}
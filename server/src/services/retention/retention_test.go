@@ -0,0 +1,84 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/services"
+)
+
+type RetentionServiceTestSuite struct {
+	suite.Suite
+}
+
+type DBMock struct {
+	DB
+	mock.Mock
+}
+
+func (m *DBMock) GetInactiveBoards(since time.Time, includeArchived bool) ([]database.InactiveBoard, error) {
+	args := m.Called(includeArchived)
+	return args.Get(0).([]database.InactiveBoard), args.Error(1)
+}
+
+type BoardsMock struct {
+	services.Boards
+	mock.Mock
+}
+
+func (m *BoardsMock) Delete(_ context.Context, id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestRetentionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RetentionServiceTestSuite))
+}
+
+func (suite *RetentionServiceTestSuite) TestApplyDeletesInactiveBoards() {
+	db := new(DBMock)
+	boards := new(BoardsMock)
+	s := NewRetentionService(db, boards, Policy{MaxInactivity: time.Hour, Action: ActionDelete})
+
+	board := database.InactiveBoard{ID: uuid.New()}
+	db.On("GetInactiveBoards", true).Return([]database.InactiveBoard{board}, nil)
+	boards.On("Delete", board.ID).Return(nil)
+
+	report, err := s.Apply(context.Background())
+
+	suite.Nil(err)
+	suite.Len(report.Boards, 1)
+	db.AssertExpectations(suite.T())
+	boards.AssertExpectations(suite.T())
+}
+
+func (suite *RetentionServiceTestSuite) TestApplyDryRunOnlyReports() {
+	db := new(DBMock)
+	boards := new(BoardsMock)
+	s := NewRetentionService(db, boards, Policy{MaxInactivity: time.Hour, Action: ActionArchive, DryRun: true})
+
+	board := database.InactiveBoard{ID: uuid.New()}
+	db.On("GetInactiveBoards", false).Return([]database.InactiveBoard{board}, nil)
+
+	report, err := s.Apply(context.Background())
+
+	suite.Nil(err)
+	suite.True(report.DryRun)
+	suite.Equal(board.ID, report.Boards[0].ID)
+	db.AssertExpectations(suite.T())
+	boards.AssertNotCalled(suite.T(), "Delete", mock.Anything)
+}
+
+func (suite *RetentionServiceTestSuite) TestParseAction() {
+	action, err := ParseAction("archive")
+	suite.Nil(err)
+	suite.Equal(ActionArchive, action)
+
+	_, err = ParseAction("purge")
+	suite.NotNil(err)
+}
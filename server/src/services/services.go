@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"scrumlr.io/server/common/dto"
 	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database/types"
 )
 
 type Users interface {
@@ -26,20 +27,30 @@ type Feedback interface {
 
 type Boards interface {
 	Create(ctx context.Context, body dto.CreateBoardRequest) (*dto.Board, error)
+	Import(ctx context.Context, body dto.ImportBoardRequest) (*dto.Board, error)
+	Duplicate(ctx context.Context, body dto.DuplicateBoardRequest) (*dto.Board, error)
 	Get(ctx context.Context, id uuid.UUID) (*dto.Board, error)
 	Update(ctx context.Context, body dto.BoardUpdateRequest) (*dto.Board, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	SetTimer(ctx context.Context, id uuid.UUID, minutes uint8) (*dto.Board, error)
+	SetTimer(ctx context.Context, id uuid.UUID, minutes uint16) (*dto.Board, error)
 	DeleteTimer(ctx context.Context, id uuid.UUID) (*dto.Board, error)
+	PauseTimer(ctx context.Context, id uuid.UUID) (*dto.Board, error)
+	ResumeTimer(ctx context.Context, id uuid.UUID) (*dto.Board, error)
 
 	CreateColumn(ctx context.Context, body dto.ColumnRequest) (*dto.Column, error)
 	DeleteColumn(ctx context.Context, board, column, user uuid.UUID) error
+	RestoreColumn(ctx context.Context, board, column uuid.UUID) (*dto.Column, error)
 	UpdateColumn(ctx context.Context, body dto.ColumnUpdateRequest) (*dto.Column, error)
 	GetColumn(ctx context.Context, boardID, columnID uuid.UUID) (*dto.Column, error)
 	ListColumns(ctx context.Context, boardID uuid.UUID) ([]*dto.Column, error)
 
-	FullBoard(ctx context.Context, boardID uuid.UUID) (*dto.Board, []*dto.BoardSessionRequest, []*dto.BoardSession, []*dto.Column, []*dto.Note, []*dto.Reaction, []*dto.Voting, []*dto.Vote, []*dto.Assignment, error)
+	ListPhases(ctx context.Context, boardID uuid.UUID) ([]*dto.Phase, error)
+	SetPhases(ctx context.Context, body dto.PhasesRequest) ([]*dto.Phase, error)
+
+	ListForUser(ctx context.Context, f filter.UserBoardFilter) (*dto.UserBoards, error)
+
+	FullBoard(ctx context.Context, boardID uuid.UUID) (*dto.Board, []*dto.BoardSessionRequest, []*dto.BoardSession, []*dto.Column, []*dto.Note, []*dto.Reaction, []*dto.Voting, []*dto.Vote, []*dto.Assignment, []*dto.Comment, error)
 }
 
 type BoardSessions interface {
@@ -67,6 +78,15 @@ type Notes interface {
 	Update(ctx context.Context, body dto.NoteUpdateRequest) (*dto.Note, error)
 	List(ctx context.Context, id uuid.UUID) ([]*dto.Note, error)
 	Delete(ctx context.Context, body dto.NoteDeleteRequest, id uuid.UUID) error
+	History(ctx context.Context, board, id uuid.UUID) ([]*dto.NoteRevision, error)
+	Restore(ctx context.Context, body dto.NoteRestoreRequest) (*dto.Note, error)
+}
+
+type Comments interface {
+	Create(ctx context.Context, body dto.CommentCreateRequest) (*dto.Comment, error)
+	Update(ctx context.Context, body dto.CommentUpdateRequest) (*dto.Comment, error)
+	Delete(ctx context.Context, board, note, user, id uuid.UUID) error
+	List(ctx context.Context, board, note uuid.UUID) ([]*dto.Comment, error)
 }
 
 type Reactions interface {
@@ -82,6 +102,7 @@ type Votings interface {
 	Update(ctx context.Context, body dto.VotingUpdateRequest) (*dto.Voting, error)
 	Get(ctx context.Context, board, id uuid.UUID) (*dto.Voting, error)
 	List(ctx context.Context, board uuid.UUID) ([]*dto.Voting, error)
+	Results(ctx context.Context, board, id uuid.UUID) (*dto.VotingReport, error)
 
 	AddVote(ctx context.Context, req dto.VoteRequest) (*dto.Vote, error)
 	RemoveVote(ctx context.Context, req dto.VoteRequest) error
@@ -101,3 +122,24 @@ type Assignments interface {
 type BoardReactions interface {
 	Create(ctx context.Context, board uuid.UUID, body dto.BoardReactionCreateRequest)
 }
+
+type Templates interface {
+	Create(ctx context.Context, body dto.TemplateCreateRequest) (*dto.Template, error)
+	Get(ctx context.Context, id uuid.UUID) (*dto.Template, error)
+	List(ctx context.Context, creator uuid.UUID) ([]*dto.Template, error)
+	Update(ctx context.Context, body dto.TemplateUpdateRequest) (*dto.Template, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type ActionItems interface {
+	Create(ctx context.Context, body dto.ActionItemCreateRequest) (*dto.ActionItem, error)
+	Get(ctx context.Context, id uuid.UUID) (*dto.ActionItem, error)
+	Update(ctx context.Context, body dto.ActionItemUpdateRequest) (*dto.ActionItem, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, board uuid.UUID) ([]*dto.ActionItem, error)
+	ListForUser(ctx context.Context, user uuid.UUID, status *types.ActionItemStatus) ([]*dto.ActionItem, error)
+}
+
+type Audit interface {
+	List(ctx context.Context, f filter.AuditFilter) ([]*dto.AuditEntry, error)
+}
@@ -0,0 +1,155 @@
+package templates
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"scrumlr.io/server/common"
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/logger"
+	"scrumlr.io/server/services"
+)
+
+type TemplateService struct {
+	database DB
+}
+
+type DB interface {
+	GetBoard(id uuid.UUID) (database.Board, error)
+	GetColumns(board uuid.UUID) ([]database.Column, error)
+	CreateBoardTemplate(template database.BoardTemplateInsert, columns []database.ColumnTemplateInsert) (database.BoardTemplate, []database.ColumnTemplate, error)
+	UpdateBoardTemplate(update database.BoardTemplateUpdate, columns []database.ColumnTemplateInsert) (database.BoardTemplate, []database.ColumnTemplate, error)
+	DeleteBoardTemplate(id uuid.UUID) error
+	GetBoardTemplate(id uuid.UUID) (database.BoardTemplate, []database.ColumnTemplate, error)
+	GetBoardTemplates(creator uuid.UUID) ([]database.BoardTemplate, []database.ColumnTemplate, error)
+}
+
+func NewTemplateService(db DB) services.Templates {
+	t := new(TemplateService)
+	t.database = db
+	return t
+}
+
+func (s *TemplateService) Create(ctx context.Context, body dto.TemplateCreateRequest) (*dto.Template, error) {
+	log := logger.FromContext(ctx)
+	if body.Name == "" {
+		return nil, common.BadRequestError(errors.New("template name may not be empty"))
+	}
+
+	template := database.BoardTemplateInsert{
+		Creator:               body.Creator,
+		Name:                  body.Name,
+		Description:           body.Description,
+		ShowAuthors:           body.ShowAuthors,
+		ShowNotesOfOtherUsers: body.ShowNotesOfOtherUsers,
+		ShowNoteReactions:     body.ShowNoteReactions,
+		AllowStacking:         body.AllowStacking,
+	}
+
+	var columns []database.ColumnTemplateInsert
+	if body.Board != nil {
+		// capture the settings and columns of an existing board
+		board, err := s.database.GetBoard(*body.Board)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, common.NotFoundError
+			}
+			log.Errorw("unable to get board", "board", *body.Board, "error", err)
+			return nil, common.InternalServerError
+		}
+		boardColumns, err := s.database.GetColumns(*body.Board)
+		if err != nil {
+			log.Errorw("unable to get columns", "board", *body.Board, "error", err)
+			return nil, common.InternalServerError
+		}
+
+		template.ShowAuthors = &board.ShowAuthors
+		template.ShowNotesOfOtherUsers = &board.ShowNotesOfOtherUsers
+		template.ShowNoteReactions = &board.ShowNoteReactions
+		template.AllowStacking = &board.AllowStacking
+
+		columns = make([]database.ColumnTemplateInsert, 0, len(boardColumns))
+		for _, column := range boardColumns {
+			visible := column.Visible
+			columns = append(columns, database.ColumnTemplateInsert{Name: column.Name, Color: column.Color, Visible: &visible})
+		}
+	} else {
+		columns = columnTemplateInserts(body.Columns)
+	}
+
+	t, c, err := s.database.CreateBoardTemplate(template, columns)
+	if err != nil {
+		log.Errorw("unable to create template", "creator", body.Creator, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Template).From(t, c), nil
+}
+
+func (s *TemplateService) Get(ctx context.Context, id uuid.UUID) (*dto.Template, error) {
+	log := logger.FromContext(ctx)
+	template, columns, err := s.database.GetBoardTemplate(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get template", "template", id, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Template).From(template, columns), nil
+}
+
+func (s *TemplateService) List(ctx context.Context, creator uuid.UUID) ([]*dto.Template, error) {
+	log := logger.FromContext(ctx)
+	templates, columns, err := s.database.GetBoardTemplates(creator)
+	if err != nil {
+		log.Errorw("unable to get templates", "creator", creator, "error", err)
+		return nil, common.InternalServerError
+	}
+	return dto.Templates(templates, columns), nil
+}
+
+func (s *TemplateService) Update(ctx context.Context, body dto.TemplateUpdateRequest) (*dto.Template, error) {
+	log := logger.FromContext(ctx)
+	if body.Name != nil && *body.Name == "" {
+		return nil, common.BadRequestError(errors.New("template name may not be empty"))
+	}
+
+	var columns []database.ColumnTemplateInsert
+	if body.Columns != nil {
+		columns = columnTemplateInserts(*body.Columns)
+	}
+
+	template, c, err := s.database.UpdateBoardTemplate(database.BoardTemplateUpdate{
+		ID:                    body.ID,
+		Name:                  body.Name,
+		Description:           body.Description,
+		ShowAuthors:           body.ShowAuthors,
+		ShowNotesOfOtherUsers: body.ShowNotesOfOtherUsers,
+		ShowNoteReactions:     body.ShowNoteReactions,
+		AllowStacking:         body.AllowStacking,
+	}, columns)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to update template", "template", body.ID, "error", err)
+		return nil, common.InternalServerError
+	}
+	return new(dto.Template).From(template, c), nil
+}
+
+func (s *TemplateService) Delete(_ context.Context, id uuid.UUID) error {
+	return s.database.DeleteBoardTemplate(id)
+}
+
+func columnTemplateInserts(columns []dto.ColumnTemplateRequest) []database.ColumnTemplateInsert {
+	// an empty, non-nil slice replaces all existing columns on update
+	inserts := make([]database.ColumnTemplateInsert, 0, len(columns))
+	for _, column := range columns {
+		inserts = append(inserts, database.ColumnTemplateInsert{Name: column.Name, Color: column.Color, Visible: column.Visible})
+	}
+	return inserts
+}
@@ -0,0 +1,106 @@
+package timers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"scrumlr.io/server/common/dto"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+	"scrumlr.io/server/logger"
+	"scrumlr.io/server/realtime"
+)
+
+// TimerService announces the expiry of board timers, so that clients don't have to compute it on their own, and closes
+// timed votings once their closing time has passed.
+type TimerService struct {
+	database DB
+	realtime *realtime.Broker
+	interval time.Duration
+}
+
+type DB interface {
+	ExpireBoardTimers() ([]database.Board, error)
+	GetExpiredVotings() ([]database.Voting, error)
+	UpdateVoting(update database.VotingUpdate) (database.Voting, error)
+}
+
+func NewTimerService(db DB, rt *realtime.Broker, interval time.Duration) *TimerService {
+	s := new(TimerService)
+	s.database = db
+	s.realtime = rt
+	s.interval = interval
+	return s
+}
+
+// Run checks for expired timers and votings periodically until the context is done. Since expired timers are claimed
+// by a single statement and votings are only closed as long as they are open, multiple instances of the server may run
+// this concurrently without announcing a timer or closing a voting twice. Both are persisted, so that timers and
+// votings which expired while no server was running are handled on the next start.
+func (s *TimerService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Expire(ctx); err != nil {
+			logger.Get().Errorw("unable to expire board timers", "err", err)
+		}
+		if err := s.CloseVotings(ctx); err != nil {
+			logger.Get().Errorw("unable to close expired votings", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Expire broadcasts the expiry of all timers, which ended since the last check.
+func (s *TimerService) Expire(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	boards, err := s.database.ExpireBoardTimers()
+	if err != nil {
+		return err
+	}
+
+	for _, board := range boards {
+		err := s.realtime.BroadcastToBoard(board.ID, realtime.BoardEvent{
+			Type: realtime.BoardEventBoardTimerExpired,
+			Data: new(dto.Board).From(board),
+		})
+		if err != nil {
+			log.Errorw("unable to broadcast expired timer", "board", board.ID, "err", err)
+		}
+	}
+	return nil
+}
+
+// CloseVotings closes all open votings, whose closing time has passed. The closing is announced by the voting observer,
+// just like the manual closing of a voting.
+func (s *TimerService) CloseVotings(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	votings, err := s.database.GetExpiredVotings()
+	if err != nil {
+		return err
+	}
+
+	for _, voting := range votings {
+		_, err := s.database.UpdateVoting(database.VotingUpdate{
+			ID:      voting.ID,
+			Board:   voting.Board,
+			Status:  types.VotingStatusClosed,
+			Expired: true,
+		})
+		if err == sql.ErrNoRows {
+			// the voting was closed by someone else in the meantime
+			continue
+		}
+		if err != nil {
+			log.Errorw("unable to close expired voting", "voting", voting.ID, "err", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package timers
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"scrumlr.io/server/database"
+	"scrumlr.io/server/database/types"
+)
+
+type TimerServiceTestSuite struct {
+	suite.Suite
+}
+
+type DBMock struct {
+	DB
+	mock.Mock
+}
+
+func (m *DBMock) GetExpiredVotings() ([]database.Voting, error) {
+	args := m.Called()
+	return args.Get(0).([]database.Voting), args.Error(1)
+}
+
+func (m *DBMock) UpdateVoting(update database.VotingUpdate) (database.Voting, error) {
+	args := m.Called(update)
+	return args.Get(0).(database.Voting), args.Error(1)
+}
+
+func TestTimerServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TimerServiceTestSuite))
+}
+
+func (suite *TimerServiceTestSuite) TestCloseVotings() {
+	db := new(DBMock)
+	s := NewTimerService(db, nil, time.Second)
+
+	closed := database.Voting{ID: uuid.New(), Board: uuid.New(), Status: types.VotingStatusOpen}
+	closedElsewhere := database.Voting{ID: uuid.New(), Board: uuid.New(), Status: types.VotingStatusOpen}
+	db.On("GetExpiredVotings").Return([]database.Voting{closed, closedElsewhere}, nil)
+	db.On("UpdateVoting", database.VotingUpdate{ID: closed.ID, Board: closed.Board, Status: types.VotingStatusClosed, Expired: true}).
+		Return(database.Voting{ID: closed.ID, Board: closed.Board, Status: types.VotingStatusClosed}, nil)
+	db.On("UpdateVoting", database.VotingUpdate{ID: closedElsewhere.ID, Board: closedElsewhere.Board, Status: types.VotingStatusClosed, Expired: true}).
+		Return(database.Voting{}, sql.ErrNoRows)
+
+	err := s.CloseVotings(context.Background())
+
+	suite.Nil(err)
+	db.AssertExpectations(suite.T())
+}
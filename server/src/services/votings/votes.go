@@ -2,20 +2,35 @@ package votings
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"scrumlr.io/server/common"
 	"scrumlr.io/server/common/dto"
 	"scrumlr.io/server/common/filter"
+	"scrumlr.io/server/database"
 	"scrumlr.io/server/logger"
 )
 
 func (s *VotingService) AddVote(ctx context.Context, body dto.VoteRequest) (*dto.Vote, error) {
 	log := logger.FromContext(ctx)
-	v, err := s.database.AddVote(body.Board, body.User, body.Note)
+	weight := 1
+	if body.Weight != nil {
+		if *body.Weight < 1 {
+			return nil, common.BadRequestError(errors.New("weight must be a positive number"))
+		}
+		weight = *body.Weight
+	}
+
+	v, err := s.database.AddVote(body.Board, body.User, body.Note, weight, body.Rank)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, common.ForbiddenError(errors.New("voting limit reached or no active voting session found"))
+		var voteErr database.VoteError
+		if errors.As(err, &voteErr) {
+			switch voteErr {
+			case database.VoteErrorUnknownNote:
+				return nil, common.NotFoundError
+			case database.VoteErrorInvalidWeight, database.VoteErrorInvalidRank:
+				return nil, common.BadRequestError(voteErr)
+			}
+			return nil, common.ForbiddenError(voteErr)
 		}
 		log.Warnw("unable to add vote", "board", body.Board, "user", body.User, "note", body.Note, "err", err)
 		return nil, err
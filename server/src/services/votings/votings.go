@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -18,6 +20,9 @@ import (
 	"scrumlr.io/server/logger"
 )
 
+// maxVotingDurationSeconds is the maximum duration of a timed voting, which is a day.
+const maxVotingDurationSeconds = 24 * 60 * 60
+
 type VotingService struct {
 	database *database.Database
 	realtime *realtime.Broker
@@ -51,12 +56,61 @@ func (s *VotingService) Get(ctx context.Context, boardID, id uuid.UUID) (*dto.Vo
 
 func (s *VotingService) Create(ctx context.Context, body dto.VotingCreateRequest) (*dto.Voting, error) {
 	log := logger.FromContext(ctx)
-	voting, err := s.database.CreateVoting(database.VotingInsert{
+	if body.MaxVotesPerNote != nil && *body.MaxVotesPerNote < 1 {
+		return nil, common.BadRequestError(errors.New("vote limit per note must be a positive number"))
+	}
+
+	closesAt := body.ClosesAt
+	if body.DurationSeconds != nil {
+		if body.ClosesAt != nil {
+			return nil, common.BadRequestError(errors.New("either the duration or the closing time can be set"))
+		}
+		if *body.DurationSeconds < 1 || *body.DurationSeconds > maxVotingDurationSeconds {
+			return nil, common.BadRequestError(fmt.Errorf("duration must be between 1 and %d seconds", maxVotingDurationSeconds))
+		}
+		end := time.Now().Add(time.Duration(*body.DurationSeconds) * time.Second)
+		closesAt = &end
+	} else if closesAt != nil {
+		remaining := time.Until(*closesAt)
+		if remaining <= 0 || remaining > maxVotingDurationSeconds*time.Second {
+			return nil, common.BadRequestError(fmt.Errorf("closing time must be within the next %d seconds", maxVotingDurationSeconds))
+		}
+	}
+
+	var votingColumns []uuid.UUID
+	if body.Columns != nil {
+		if len(*body.Columns) == 0 {
+			return nil, common.BadRequestError(errors.New("at least one column must be part of the voting"))
+		}
+		columns, err := s.database.GetColumns(body.Board)
+		if err != nil {
+			log.Errorw("unable to get columns", "board", body.Board, "error", err)
+			return nil, common.InternalServerError
+		}
+		boardColumns := map[uuid.UUID]bool{}
+		for _, column := range columns {
+			boardColumns[column.ID] = true
+		}
+		for _, column := range *body.Columns {
+			if !boardColumns[column] {
+				return nil, common.BadRequestError(fmt.Errorf("column '%s' is not part of the board", column))
+			}
+		}
+		votingColumns = append([]uuid.UUID{}, *body.Columns...)
+	}
+
+	voting, err := s.database.WithActor(ctx).CreateVoting(database.VotingInsert{
 		Board:              body.Board,
 		VoteLimit:          body.VoteLimit,
 		AllowMultipleVotes: body.AllowMultipleVotes,
 		ShowVotesOfOthers:  body.ShowVotesOfOthers,
 		Status:             types.VotingStatusOpen,
+		Mode:               body.Mode,
+		Anonymous:          body.Anonymous,
+		Columns:            votingColumns,
+		PreventSelfVotes:   body.PreventSelfVotes,
+		MaxVotesPerNote:    body.MaxVotesPerNote,
+		ClosesAt:           closesAt,
 	})
 
 	if err != nil {
@@ -71,19 +125,47 @@ func (s *VotingService) Create(ctx context.Context, body dto.VotingCreateRequest
 }
 
 func (s *VotingService) Update(ctx context.Context, body dto.VotingUpdateRequest) (*dto.Voting, error) {
+	log := logger.FromContext(ctx)
+	if body.VoteLimit != nil {
+		if body.Status != types.VotingStatusOpen {
+			return nil, common.BadRequestError(errors.New("vote limit can only be changed on an open voting"))
+		}
+		if *body.VoteLimit < 0 || *body.VoteLimit > 99 {
+			return nil, common.BadRequestError(errors.New("vote limit must be between 0 and 99"))
+		}
+	}
+
 	if body.Status == types.VotingStatusOpen {
-		return nil, common.BadRequestError(errors.New("not allowed ot change to open state"))
+		current, _, err := s.database.GetVoting(body.Board, body.ID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, common.NotFoundError
+			}
+			log.Errorw("unable to get voting session", "voting", body.ID, "error", err)
+			return nil, common.InternalServerError
+		}
+		if current.Status == types.VotingStatusOpen && body.VoteLimit == nil {
+			return nil, common.BadRequestError(errors.New("voting is already open"))
+		}
+		if body.VoteLimit != nil && *body.VoteLimit < current.VoteLimit {
+			return nil, common.BadRequestError(errors.New("vote limit can only be raised"))
+		}
 	}
 
-	voting, err := s.database.UpdateVoting(database.VotingUpdate{
-		ID:     body.ID,
-		Board:  body.Board,
-		Status: body.Status,
+	voting, err := s.database.WithActor(ctx).UpdateVoting(database.VotingUpdate{
+		ID:        body.ID,
+		Board:     body.Board,
+		Status:    body.Status,
+		VoteLimit: body.VoteLimit,
 	})
 	if err != nil {
 		if err == sql.ErrNoRows {
+			if body.Status == types.VotingStatusOpen {
+				return nil, common.ConflictError(errors.New("only one open voting session is allowed"))
+			}
 			return nil, common.NotFoundError
 		}
+		log.Errorw("unable to update voting", "voting", body.ID, "error", err)
 		return nil, common.InternalServerError
 	}
 
@@ -99,6 +181,41 @@ func (s *VotingService) List(_ context.Context, boardID uuid.UUID) ([]*dto.Votin
 	return dto.Votings(votings, votes), err
 }
 
+// Results returns the report on the results of a closed voting. The votes per user are only part of the report if the
+// votes of others are shown.
+func (s *VotingService) Results(ctx context.Context, boardID, id uuid.UUID) (*dto.VotingReport, error) {
+	log := logger.FromContext(ctx)
+	voting, votes, err := s.database.GetVoting(boardID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFoundError
+		}
+		log.Errorw("unable to get voting session", "voting", id, "error", err)
+		return nil, common.InternalServerError
+	}
+	if voting.Status != types.VotingStatusClosed {
+		return nil, common.ConflictError(errors.New("results are only available for closed votings"))
+	}
+
+	notes, err := s.database.GetNotes(boardID)
+	if err != nil {
+		log.Errorw("unable to get notes", "board", boardID, "error", err)
+		return nil, common.InternalServerError
+	}
+	columns, err := s.database.GetColumns(boardID)
+	if err != nil {
+		log.Errorw("unable to get columns", "board", boardID, "error", err)
+		return nil, common.InternalServerError
+	}
+	sessions, err := s.database.GetBoardSessions(boardID)
+	if err != nil {
+		log.Errorw("unable to get board sessions", "board", boardID, "error", err)
+		return nil, common.InternalServerError
+	}
+
+	return new(dto.VotingReport).From(voting, votes, notes, columns, sessions), nil
+}
+
 func (s *VotingService) getVotes(_ context.Context, boardID, id uuid.UUID) ([]database.Vote, error) {
 	return s.database.GetVotes(filter.VoteFilter{Board: boardID, Voting: &id})
 }